@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultIdlePollingInterval is the polling interval used by adaptive polling while the object is idle
+	defaultIdlePollingInterval = 300
+	// defaultActivationFraction is the fraction of the activation threshold which switches adaptive polling to pollingInterval
+	defaultActivationFraction = 0.5
+)
+
+// AdaptivePolling polls the triggers less often while the ScaledObject or ScaledJob is idle
+type AdaptivePolling struct {
+	// IdlePollingInterval is the polling interval in seconds used while none of the triggers is active
+	// or near its activation threshold and the target is scaled to zero or idleReplicaCount, or a ScaledJob
+	// has no running jobs, pollingInterval is used otherwise
+	// +optional
+	IdlePollingInterval *int32 `json:"idlePollingInterval,omitempty"`
+	// ActivationFraction is the fraction of the activation threshold of a trigger, pollingInterval is used
+	// once the trigger value rises past it
+	// +optional
+	ActivationFraction string `json:"activationFraction,omitempty"`
+}
+
+// GetIdlePollingInterval returns the polling interval used while the object is idle
+func (a *AdaptivePolling) GetIdlePollingInterval() time.Duration {
+	if a.IdlePollingInterval != nil {
+		return time.Second * time.Duration(*a.IdlePollingInterval)
+	}
+	return time.Second * time.Duration(defaultIdlePollingInterval)
+}
+
+// GetActivationFraction returns the fraction of the activation threshold which switches to pollingInterval
+func (a *AdaptivePolling) GetActivationFraction() float64 {
+	if a.ActivationFraction == "" {
+		return defaultActivationFraction
+	}
+	fraction, err := strconv.ParseFloat(a.ActivationFraction, 64)
+	if err != nil {
+		return defaultActivationFraction
+	}
+	return fraction
+}
+
+// GetActivationThreshold returns the activation threshold of the trigger, it is read from the trigger metadata
+// following the "activation<Target>" convention of the scalers. The second return value is false if the trigger
+// doesn't set any activation threshold.
+func (t *ScaleTriggers) GetActivationThreshold() (float64, bool) {
+	for _, key := range slices.Sorted(maps.Keys(t.Metadata)) {
+		if !strings.HasPrefix(key, "activation") || strings.HasSuffix(key, "FromEnv") {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(t.Metadata[key], 64)
+		if err == nil {
+			return threshold, true
+		}
+	}
+	return 0, false
+}
+
+// ValidateAdaptivePolling checks the adaptive polling of an object with the given pollingInterval
+func ValidateAdaptivePolling(pollingIntervalSeconds *int32, adaptivePolling *AdaptivePolling) error {
+	if adaptivePolling == nil {
+		return nil
+	}
+	pollingInterval := (&WithTriggers{Spec: WithTriggersSpec{PollingInterval: pollingIntervalSeconds}}).GetPollingInterval()
+	if adaptivePolling.IdlePollingInterval != nil && *adaptivePolling.IdlePollingInterval <= 0 {
+		return fmt.Errorf("adaptivePolling.idlePollingInterval must be greater than 0")
+	}
+	if adaptivePolling.GetIdlePollingInterval() < pollingInterval {
+		return fmt.Errorf("adaptivePolling.idlePollingInterval=%s must not be less than pollingInterval=%s", adaptivePolling.GetIdlePollingInterval(), pollingInterval)
+	}
+	if adaptivePolling.ActivationFraction != "" {
+		fraction, err := strconv.ParseFloat(adaptivePolling.ActivationFraction, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing adaptivePolling.activationFraction: %w", err)
+		}
+		if fraction < 0 || fraction > 1 {
+			return fmt.Errorf("adaptivePolling.activationFraction must be between 0 and 1")
+		}
+	}
+	return nil
+}
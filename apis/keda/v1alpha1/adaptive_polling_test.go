@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetActivationThreshold(t *testing.T) {
+	tests := []struct {
+		name              string
+		metadata          map[string]string
+		expectedThreshold float64
+		expectedFound     bool
+	}{
+		{
+			name:              "activation threshold",
+			metadata:          map[string]string{"queueLength": "10", "activationQueueLength": "5"},
+			expectedThreshold: 5,
+			expectedFound:     true,
+		},
+		{
+			name:     "no activation threshold",
+			metadata: map[string]string{"queueLength": "10"},
+		},
+		{
+			name:     "activation threshold from env",
+			metadata: map[string]string{"activationQueueLengthFromEnv": "ACTIVATION"},
+		},
+		{
+			name:     "non numeric activation",
+			metadata: map[string]string{"activationMode": "eager"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trigger := ScaleTriggers{Type: "aws-sqs-queue", Metadata: test.metadata}
+			threshold, found := trigger.GetActivationThreshold()
+			assert.Equal(t, test.expectedFound, found)
+			assert.Equal(t, test.expectedThreshold, threshold)
+		})
+	}
+}
+
+func TestValidateAdaptivePolling(t *testing.T) {
+	tests := []struct {
+		name            string
+		pollingInterval *int32
+		adaptivePolling *AdaptivePolling
+		expectedErrMsg  string
+	}{
+		{
+			name: "adaptive polling not set",
+		},
+		{
+			name:            "defaults",
+			adaptivePolling: &AdaptivePolling{},
+		},
+		{
+			name:            "valid adaptive polling",
+			pollingInterval: int32Ptr(10),
+			adaptivePolling: &AdaptivePolling{IdlePollingInterval: int32Ptr(120), ActivationFraction: "0.8"},
+		},
+		{
+			name:            "idle polling interval less than polling interval",
+			pollingInterval: int32Ptr(600),
+			adaptivePolling: &AdaptivePolling{},
+			expectedErrMsg:  "adaptivePolling.idlePollingInterval=5m0s must not be less than pollingInterval=10m0s",
+		},
+		{
+			name:            "non positive idle polling interval",
+			adaptivePolling: &AdaptivePolling{IdlePollingInterval: int32Ptr(0)},
+			expectedErrMsg:  "adaptivePolling.idlePollingInterval must be greater than 0",
+		},
+		{
+			name:            "invalid activation fraction",
+			adaptivePolling: &AdaptivePolling{ActivationFraction: "half"},
+			expectedErrMsg:  "error parsing adaptivePolling.activationFraction",
+		},
+		{
+			name:            "activation fraction out of range",
+			adaptivePolling: &AdaptivePolling{ActivationFraction: "1.5"},
+			expectedErrMsg:  "adaptivePolling.activationFraction must be between 0 and 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAdaptivePolling(test.pollingInterval, test.adaptivePolling)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestAdaptivePollingDefaults(t *testing.T) {
+	adaptivePolling := AdaptivePolling{}
+	assert.Equal(t, 5*time.Minute, adaptivePolling.GetIdlePollingInterval())
+	assert.Equal(t, 0.5, adaptivePolling.GetActivationFraction())
+}
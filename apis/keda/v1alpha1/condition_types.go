@@ -45,6 +45,14 @@ const (
 	ScaledObjectConditionPausedReason = "ScaledObjectPaused"
 	// ScaledObjectConditionPausedMessage defines the default Message for paused ScaledObject
 	ScaledObjectConditionPausedMessage = "ScaledObject is paused"
+	// ScaledObjectConditionPauseExpiredReason defines the Reason for ScaledObject unpaused by an expired pause
+	ScaledObjectConditionPauseExpiredReason = "ScaledObjectPauseExpired"
+	// ScaledObjectConditionPauseExpiredMessage defines the Message for ScaledObject unpaused by an expired pause
+	ScaledObjectConditionPauseExpiredMessage = "pause of ScaledObject expired"
+	// ScaledObjectConditionScalingFreezeActiveReason defines the Reason for ScaledObject frozen by a ScalingFreeze or ClusterScalingFreeze
+	ScaledObjectConditionScalingFreezeActiveReason = "ScalingFreezeActive"
+	// ScaledObjectConditionScalingFreezeEndedReason defines the Reason for ScaledObject unfrozen after its ScalingFreeze or ClusterScalingFreeze ended
+	ScaledObjectConditionScalingFreezeEndedReason = "ScalingFreezeEnded"
 )
 
 const (
@@ -56,6 +64,14 @@ const (
 	ScaledJobConditionPausedMessage = "ScaledJob is paused"
 	// ScaledJobConditionPausedMessage defines the default Message for paused ScaledJob
 	ScaledJobConditionUnpausedMessage = "ScaledJob is unpaused"
+	// ScaledJobConditionPauseExpiredReason defines the Reason for ScaledJob unpaused by an expired pause
+	ScaledJobConditionPauseExpiredReason = "ScaledJobPauseExpired"
+	// ScaledJobConditionPauseExpiredMessage defines the Message for ScaledJob unpaused by an expired pause
+	ScaledJobConditionPauseExpiredMessage = "pause of ScaledJob expired"
+	// ScaledJobConditionScalingFreezeActiveReason defines the Reason for ScaledJob frozen by a ScalingFreeze or ClusterScalingFreeze
+	ScaledJobConditionScalingFreezeActiveReason = "ScalingFreezeActive"
+	// ScaledJobConditionScalingFreezeEndedReason defines the Reason for ScaledJob unfrozen after its ScalingFreeze or ClusterScalingFreeze ended
+	ScaledJobConditionScalingFreezeEndedReason = "ScalingFreezeEnded"
 )
 
 // Condition to store the condition state
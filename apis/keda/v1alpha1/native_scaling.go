@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// ScalingMode defines whether an HPA or KEDA itself computes the replicas of the scale target
+// +kubebuilder:validation:Enum=hpa;native
+type ScalingMode string
+
+const (
+	// ScalingModeHPA scales the target by an HPA created for the ScaledObject
+	ScalingModeHPA ScalingMode = "hpa"
+	// ScalingModeNative computes the replicas with the HPA algorithm and behavior in KEDA
+	// and updates the scale subresource of the target directly, no HPA is created
+	ScalingModeNative ScalingMode = "native"
+)
+
+// IsNativeScaling returns true if KEDA computes the replicas of the scale target itself instead of an HPA
+func (so *ScaledObject) IsNativeScaling() bool {
+	return so.Spec.Advanced != nil && so.Spec.Advanced.ScalingMode == ScalingModeNative
+}
+
+// GetHPABehavior returns the HPA behavior of the ScaledObject, nil if not defined
+func (so *ScaledObject) GetHPABehavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if so.Spec.Advanced == nil || so.Spec.Advanced.HorizontalPodAutoscalerConfig == nil {
+		return nil
+	}
+	return so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
+}
+
+// ValidateScalingMode checks the triggers of the ScaledObject are supported by its scaling mode,
+// cpu and memory triggers are evaluated from the pod metrics by the HPA only
+func ValidateScalingMode(so *ScaledObject) error {
+	if !so.IsNativeScaling() {
+		return nil
+	}
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Type == cpuString || trigger.Type == memoryString {
+			return fmt.Errorf("%s trigger is not supported in %s scaling mode", trigger.Type, ScalingModeNative)
+		}
+	}
+	return nil
+}
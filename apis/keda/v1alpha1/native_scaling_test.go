@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScalingMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           ScalingMode
+		triggerType    string
+		expectedErrMsg string
+	}{
+		{
+			name:        "hpa mode with cpu trigger",
+			mode:        ScalingModeHPA,
+			triggerType: cpuString,
+		},
+		{
+			name:        "native mode with external trigger",
+			mode:        ScalingModeNative,
+			triggerType: "kafka",
+		},
+		{
+			name:           "native mode with cpu trigger",
+			mode:           ScalingModeNative,
+			triggerType:    cpuString,
+			expectedErrMsg: "cpu trigger is not supported in native scaling mode",
+		},
+		{
+			name:           "native mode with memory trigger",
+			mode:           ScalingModeNative,
+			triggerType:    memoryString,
+			expectedErrMsg: "memory trigger is not supported in native scaling mode",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{ScalingMode: test.mode},
+					Triggers: []ScaleTriggers{{Type: test.triggerType}},
+				},
+			}
+			err := ValidateScalingMode(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// PausedUntilAnnotation bounds the pause of PausedAnnotation or PausedReplicasAnnotation, on its own it pauses
+// the object. The value is a RFC3339 time or a duration, which KEDA converts into the time relative to when
+// it observed the annotation. Once the time passed, KEDA removes all the pause annotations. An invalid value is
+// rejected by the admission webhooks and never pauses the object on its own.
+const PausedUntilAnnotation = "autoscaling.keda.sh/paused-until"
+
+// ParsePausedUntil parses the value of PausedUntilAnnotation into the time the pause expires at,
+// a duration is resolved relative to now and isDuration is set, so the value can be replaced by the time
+func ParsePausedUntil(value string, now time.Time) (deadline time.Time, isDuration bool, err error) {
+	if deadline, err := time.Parse(time.RFC3339, value); err == nil {
+		return deadline, false, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%s must be a RFC3339 time or a duration, got %q", PausedUntilAnnotation, value)
+	}
+	return now.Add(duration), true, nil
+}
+
+// GetPausedUntil returns the time the pause set by PausedUntilAnnotation expires at. It returns false if there
+// is no annotation, if it is invalid or if it is a duration which wasn't converted into a time yet.
+func GetPausedUntil(annotations map[string]string) (time.Time, bool) {
+	value, found := annotations[PausedUntilAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// HasPausedUntil returns whether there is a valid PausedUntilAnnotation, an invalid one doesn't pause the object
+func HasPausedUntil(annotations map[string]string) bool {
+	_, found := annotations[PausedUntilAnnotation]
+	return found && ValidatePausedUntil(annotations) == nil
+}
+
+// ValidatePausedUntil checks that PausedUntilAnnotation, if present, is a RFC3339 time or a duration
+func ValidatePausedUntil(annotations map[string]string) error {
+	value, found := annotations[PausedUntilAnnotation]
+	if !found {
+		return nil
+	}
+	_, _, err := ParsePausedUntil(value, time.Now())
+	return err
+}
+
+// IsPauseExpired returns whether the pause set by the annotations expired
+func IsPauseExpired(annotations map[string]string, now time.Time) bool {
+	deadline, found := GetPausedUntil(annotations)
+	return found && !deadline.After(now)
+}
+
+// RemovePauseAnnotations removes PausedAnnotation, PausedReplicasAnnotation and PausedUntilAnnotation
+func RemovePauseAnnotations(annotations map[string]string) {
+	delete(annotations, PausedAnnotation)
+	delete(annotations, PausedReplicasAnnotation)
+	delete(annotations, PausedUntilAnnotation)
+}
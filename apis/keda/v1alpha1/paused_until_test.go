@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePausedUntil(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+	deadline, isDuration, err := ParsePausedUntil("2025-03-03T14:00:00Z", now)
+	assert.NoError(t, err)
+	assert.False(t, isDuration)
+	assert.True(t, time.Date(2025, time.March, 3, 14, 0, 0, 0, time.UTC).Equal(deadline))
+
+	deadline, isDuration, err = ParsePausedUntil("90m", now)
+	assert.NoError(t, err)
+	assert.True(t, isDuration)
+	assert.True(t, time.Date(2025, time.March, 3, 13, 30, 0, 0, time.UTC).Equal(deadline))
+
+	_, _, err = ParsePausedUntil("tomorrow", now)
+	assert.ErrorContains(t, err, "autoscaling.keda.sh/paused-until must be a RFC3339 time or a duration")
+}
+
+func TestNeedToBePausedByAnnotationWithPausedUntil(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "paused until a future time",
+			annotations: map[string]string{PausedAnnotation: "true", PausedUntilAnnotation: future},
+			expected:    true,
+		},
+		{
+			name:        "paused until an expired time",
+			annotations: map[string]string{PausedAnnotation: "true", PausedUntilAnnotation: past},
+			expected:    false,
+		},
+		{
+			name:        "paused-until on its own",
+			annotations: map[string]string{PausedUntilAnnotation: future},
+			expected:    true,
+		},
+		{
+			name:        "paused-until duration not resolved yet",
+			annotations: map[string]string{PausedUntilAnnotation: "2h"},
+			expected:    true,
+		},
+		{
+			name:        "invalid paused-until on its own",
+			annotations: map[string]string{PausedUntilAnnotation: "tomorrow"},
+			expected:    false,
+		},
+		{
+			name:        "invalid paused-until with paused",
+			annotations: map[string]string{PausedAnnotation: "true", PausedUntilAnnotation: "tomorrow"},
+			expected:    true,
+		},
+		{
+			name:        "paused-until with paused false",
+			annotations: map[string]string{PausedAnnotation: "false", PausedUntilAnnotation: future},
+			expected:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			assert.Equal(t, test.expected, so.NeedToBePausedByAnnotation())
+		})
+	}
+}
+
+func TestValidatePausedUntil(t *testing.T) {
+	assert.NoError(t, ValidatePausedUntil(map[string]string{}))
+	assert.NoError(t, ValidatePausedUntil(map[string]string{PausedUntilAnnotation: "2025-03-03T14:00:00Z"}))
+	assert.NoError(t, ValidatePausedUntil(map[string]string{PausedUntilAnnotation: "2h"}))
+	assert.ErrorContains(t, ValidatePausedUntil(map[string]string{PausedUntilAnnotation: "tomorrow"}), "must be a RFC3339 time or a duration")
+}
+
+func TestRemovePauseAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		PausedAnnotation:         "true",
+		PausedReplicasAnnotation: "2",
+		PausedUntilAnnotation:    "2025-03-03T14:00:00Z",
+		"other":                  "value",
+	}
+	RemovePauseAnnotations(annotations)
+	assert.Equal(t, map[string]string{"other": "value"}, annotations)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cronWindowParser parses the cron expressions which open recurring time windows
+var cronWindowParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// isCronWindowActive returns whether the window opened by the schedule and staying open for duration contains now
+func isCronWindowActive(schedule cron.Schedule, location *time.Location, duration time.Duration, now time.Time) bool {
+	// the window is open if the schedule fired within the last duration
+	lastStart := schedule.Next(now.In(location).Add(-duration))
+	return !lastStart.After(now)
+}
+
+// nextCronWindowTransition returns the time when the window opened by the schedule and staying open for duration opens or closes next
+func nextCronWindowTransition(schedule cron.Schedule, location *time.Location, duration time.Duration, now time.Time) time.Time {
+	lastStart := schedule.Next(now.In(location).Add(-duration))
+	if !lastStart.After(now) {
+		return lastStart.Add(duration)
+	}
+	return schedule.Next(now.In(location))
+}
+
+// ReplicaCountSchedule overrides the replica bounds of a ScaledObject during a recurring time window
+type ReplicaCountSchedule struct {
+	Name string `json:"name"`
+	// Start is a cron expression which opens the window
+	Start string `json:"start"`
+	// Duration is how long the window stays open after each Start
+	Duration metav1.Duration `json:"duration"`
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// +optional
+	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+}
+
+// parse returns the parsed cron schedule and the location the schedule is evaluated in
+func (s *ReplicaCountSchedule) parse() (cron.Schedule, *time.Location, error) {
+	schedule, err := cronWindowParser.Parse(s.Start)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing start of replicaCountSchedule %q: %w", s.Name, err)
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading timezone of replicaCountSchedule %q: %w", s.Name, err)
+	}
+	return schedule, location, nil
+}
+
+// IsActive returns whether the window of the schedule contains the given time
+func (s *ReplicaCountSchedule) IsActive(now time.Time) (bool, error) {
+	schedule, location, err := s.parse()
+	if err != nil {
+		return false, err
+	}
+	return isCronWindowActive(schedule, location, s.Duration.Duration, now), nil
+}
+
+// NextTransition returns the time when the window of the schedule opens or closes next
+func (s *ReplicaCountSchedule) NextTransition(now time.Time) (time.Time, error) {
+	schedule, location, err := s.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return nextCronWindowTransition(schedule, location, s.Duration.Duration, now), nil
+}
+
+// validate checks that the schedule can be evaluated
+func (s *ReplicaCountSchedule) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("replicaCountSchedule name is mandatory")
+	}
+	if s.Duration.Duration <= 0 {
+		return fmt.Errorf("duration of replicaCountSchedule %q must be greater than 0", s.Name)
+	}
+	_, _, err := s.parse()
+	return err
+}
+
+// GetActiveReplicaCountSchedule returns the first schedule whose window contains the given time,
+// nil is returned if no schedule is active
+func (so *ScaledObject) GetActiveReplicaCountSchedule(now time.Time) *ReplicaCountSchedule {
+	for i := range so.Spec.ReplicaCountSchedules {
+		schedule := &so.Spec.ReplicaCountSchedules[i]
+		active, err := schedule.IsActive(now)
+		if err != nil {
+			scaledobjecttypeslog.Error(err, "error evaluating replicaCountSchedule", "name", so.Name, "namespace", so.Namespace)
+			continue
+		}
+		if active {
+			return schedule
+		}
+	}
+	return nil
+}
+
+// GetNextReplicaCountScheduleTransition returns the earliest time when any schedule window opens or closes,
+// the second return value is false if no schedule is defined
+func (so *ScaledObject) GetNextReplicaCountScheduleTransition(now time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for i := range so.Spec.ReplicaCountSchedules {
+		transition, err := so.Spec.ReplicaCountSchedules[i].NextTransition(now)
+		if err != nil || transition.IsZero() {
+			continue
+		}
+		if !found || transition.Before(next) {
+			next = transition
+			found = true
+		}
+	}
+	return next, found
+}
+
+// GetIdleReplicaCount returns IdleReplicaCount in effect at the moment, taking the active replicaCountSchedule into account
+func (so *ScaledObject) GetIdleReplicaCount() *int32 {
+	if schedule := so.GetActiveReplicaCountSchedule(time.Now()); schedule != nil && schedule.IdleReplicaCount != nil {
+		return schedule.IdleReplicaCount
+	}
+	return so.Spec.IdleReplicaCount
+}
+
+// GetMinReplicaCount returns MinReplicaCount in effect at the moment, taking the active replicaCountSchedule into account
+func (so *ScaledObject) GetMinReplicaCount() *int32 {
+	if schedule := so.GetActiveReplicaCountSchedule(time.Now()); schedule != nil && schedule.MinReplicaCount != nil {
+		return schedule.MinReplicaCount
+	}
+	return so.Spec.MinReplicaCount
+}
+
+// GetMaxReplicaCount returns MaxReplicaCount in effect at the moment, taking the active replicaCountSchedule into account
+func (so *ScaledObject) GetMaxReplicaCount() *int32 {
+	if schedule := so.GetActiveReplicaCountSchedule(time.Now()); schedule != nil && schedule.MaxReplicaCount != nil {
+		return schedule.MaxReplicaCount
+	}
+	return so.Spec.MaxReplicaCount
+}
+
+// checkReplicaCountSchedulesAreValid checks that all replicaCountSchedules can be evaluated
+// and that the bounds they produce are correctly specified
+func checkReplicaCountSchedulesAreValid(scaledObject *ScaledObject) error {
+	names := make(map[string]bool, len(scaledObject.Spec.ReplicaCountSchedules))
+	for _, schedule := range scaledObject.Spec.ReplicaCountSchedules {
+		if err := schedule.validate(); err != nil {
+			return err
+		}
+		if names[schedule.Name] {
+			return fmt.Errorf("replicaCountSchedule %q is defined multiple times, but it must be unique", schedule.Name)
+		}
+		names[schedule.Name] = true
+
+		idleReplicas, minReplicas, maxReplicas := scaledObject.Spec.IdleReplicaCount, scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount
+		if schedule.IdleReplicaCount != nil {
+			idleReplicas = schedule.IdleReplicaCount
+		}
+		if schedule.MinReplicaCount != nil {
+			minReplicas = schedule.MinReplicaCount
+		}
+		if schedule.MaxReplicaCount != nil {
+			maxReplicas = schedule.MaxReplicaCount
+		}
+		if err := checkReplicaCountBounds(idleReplicas, minReplicas, maxReplicas); err != nil {
+			return fmt.Errorf("replicaCountSchedule %q: %w", schedule.Name, err)
+		}
+	}
+	return nil
+}
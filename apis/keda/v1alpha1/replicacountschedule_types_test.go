@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReplicaCountScheduleIsActive(t *testing.T) {
+	// business hours, Monday to Friday 08:00 - 18:00 UTC
+	schedule := ReplicaCountSchedule{
+		Name:     "business-hours",
+		Start:    "0 8 * * 1-5",
+		Duration: metav1.Duration{Duration: 10 * time.Hour},
+		Timezone: "UTC",
+	}
+
+	tests := []struct {
+		name               string
+		now                time.Time
+		expectedActive     bool
+		expectedTransition time.Time
+	}{
+		{
+			name:               "before window opens",
+			now:                time.Date(2025, time.March, 3, 7, 30, 0, 0, time.UTC),
+			expectedActive:     false,
+			expectedTransition: time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "window opening",
+			now:                time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC),
+			expectedActive:     true,
+			expectedTransition: time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "inside window",
+			now:                time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC),
+			expectedActive:     true,
+			expectedTransition: time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "after window closes",
+			now:                time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC),
+			expectedActive:     false,
+			expectedTransition: time.Date(2025, time.March, 4, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "weekend",
+			now:                time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC),
+			expectedActive:     false,
+			expectedTransition: time.Date(2025, time.March, 10, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active, err := schedule.IsActive(test.now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedActive, active)
+
+			transition, err := schedule.NextTransition(test.now)
+			assert.NoError(t, err)
+			assert.True(t, test.expectedTransition.Equal(transition), "expected %s, got %s", test.expectedTransition, transition)
+		})
+	}
+}
+
+func TestGetActiveReplicaCountSchedule(t *testing.T) {
+	so := &ScaledObject{
+		Spec: ScaledObjectSpec{
+			MinReplicaCount: int32Ptr(1),
+			MaxReplicaCount: int32Ptr(10),
+			ReplicaCountSchedules: []ReplicaCountSchedule{
+				{
+					Name:            "night",
+					Start:           "0 22 * * *",
+					Duration:        metav1.Duration{Duration: 8 * time.Hour},
+					MaxReplicaCount: int32Ptr(2),
+				},
+				{
+					Name:            "morning-peak",
+					Start:           "0 7 * * *",
+					Duration:        metav1.Duration{Duration: 2 * time.Hour},
+					MinReplicaCount: int32Ptr(5),
+				},
+			},
+		},
+	}
+
+	active := so.GetActiveReplicaCountSchedule(time.Date(2025, time.March, 3, 23, 0, 0, 0, time.UTC))
+	assert.NotNil(t, active)
+	assert.Equal(t, "night", active.Name)
+
+	active = so.GetActiveReplicaCountSchedule(time.Date(2025, time.March, 3, 7, 30, 0, 0, time.UTC))
+	assert.NotNil(t, active)
+	assert.Equal(t, "morning-peak", active.Name)
+
+	active = so.GetActiveReplicaCountSchedule(time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, active)
+
+	next, found := so.GetNextReplicaCountScheduleTransition(time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC))
+	assert.True(t, found)
+	assert.True(t, time.Date(2025, time.March, 3, 22, 0, 0, 0, time.UTC).Equal(next))
+}
+
+func TestCheckReplicaCountSchedulesAreValid(t *testing.T) {
+	tests := []struct {
+		name           string
+		schedules      []ReplicaCountSchedule
+		expectedErrMsg string
+	}{
+		{
+			name: "valid schedule",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}, MinReplicaCount: int32Ptr(5)},
+			},
+		},
+		{
+			name: "invalid cron expression",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedErrMsg: "error parsing start of replicaCountSchedule \"peak\"",
+		},
+		{
+			name: "invalid timezone",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *", Timezone: "Mars/Olympus", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedErrMsg: "error loading timezone of replicaCountSchedule \"peak\"",
+		},
+		{
+			name: "missing duration",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *"},
+			},
+			expectedErrMsg: "duration of replicaCountSchedule \"peak\" must be greater than 0",
+		},
+		{
+			name: "duplicate names",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Name: "peak", Start: "0 18 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedErrMsg: "replicaCountSchedule \"peak\" is defined multiple times, but it must be unique",
+		},
+		{
+			name: "min greater than max of the spec",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}, MinReplicaCount: int32Ptr(20)},
+			},
+			expectedErrMsg: "replicaCountSchedule \"peak\": MinReplicaCount=20 must be less than MaxReplicaCount=10",
+		},
+		{
+			name: "idle not lower than min",
+			schedules: []ReplicaCountSchedule{
+				{Name: "peak", Start: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}, IdleReplicaCount: int32Ptr(2)},
+			},
+			expectedErrMsg: "replicaCountSchedule \"peak\": IdleReplicaCount=2 must be less than MinReplicaCount=2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					MinReplicaCount:       int32Ptr(2),
+					MaxReplicaCount:       int32Ptr(10),
+					ReplicaCountSchedules: test.schedules,
+				},
+			}
+			err := CheckReplicaCountBoundsAreValid(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
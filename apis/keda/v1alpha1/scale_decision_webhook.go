@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleDecisionFailurePolicy defines what happens to a proposed scale change when the scale decision webhook fails
+type ScaleDecisionFailurePolicy string
+
+const (
+	// ScaleDecisionFailurePolicyIgnore applies the proposed scale change when the webhook fails
+	ScaleDecisionFailurePolicyIgnore ScaleDecisionFailurePolicy = "Ignore"
+	// ScaleDecisionFailurePolicyFail vetoes the proposed scale change when the webhook fails
+	ScaleDecisionFailurePolicyFail ScaleDecisionFailurePolicy = "Fail"
+
+	defaultScaleDecisionWebhookTimeout = 5 * time.Second
+)
+
+// ScaleDecisionWebhook is an HTTP endpoint KEDA posts every proposed scale change to before acting on it,
+// the endpoint approves, clamps or vetoes the change
+type ScaleDecisionWebhook struct {
+	// URL is the http(s) endpoint the proposed scale change is posted to
+	URL string `json:"url"`
+	// Timeout of the request to the endpoint, 5s by default
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy defines whether the proposed scale change is applied (Ignore) or vetoed (Fail) when the endpoint
+	// can't be reached or returns an invalid response, Ignore by default
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	// +optional
+	FailurePolicy ScaleDecisionFailurePolicy `json:"failurePolicy,omitempty"`
+	// AuthModes is a comma separated list of the authentication modes of the request (basic, bearer, tls, custom),
+	// their parameters are resolved from AuthenticationRef the same way as for triggers
+	// +optional
+	AuthModes string `json:"authModes,omitempty"`
+	// +optional
+	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// GetTimeout returns the timeout of the request to the endpoint
+func (w *ScaleDecisionWebhook) GetTimeout() time.Duration {
+	if w.Timeout != nil {
+		return w.Timeout.Duration
+	}
+	return defaultScaleDecisionWebhookTimeout
+}
+
+// FailsClosed returns whether the proposed scale change is vetoed when the endpoint fails
+func (w *ScaleDecisionWebhook) FailsClosed() bool {
+	return w.FailurePolicy == ScaleDecisionFailurePolicyFail
+}
+
+// ValidateScaleDecisionWebhook checks that the scale decision webhook can be called
+func ValidateScaleDecisionWebhook(webhook *ScaleDecisionWebhook) error {
+	if webhook == nil {
+		return nil
+	}
+	endpoint, err := url.Parse(webhook.URL)
+	if err != nil {
+		return fmt.Errorf("error parsing url of scaleDecisionWebhook: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("url of scaleDecisionWebhook must be an absolute http or https url")
+	}
+	if webhook.Timeout != nil && webhook.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout of scaleDecisionWebhook must be greater than 0")
+	}
+	switch webhook.FailurePolicy {
+	case "", ScaleDecisionFailurePolicyIgnore, ScaleDecisionFailurePolicyFail:
+	default:
+		return fmt.Errorf("failurePolicy of scaleDecisionWebhook must be %s or %s", ScaleDecisionFailurePolicyIgnore, ScaleDecisionFailurePolicyFail)
+	}
+	if webhook.AuthModes != "" && webhook.AuthenticationRef == nil {
+		return fmt.Errorf("authModes of scaleDecisionWebhook require an authenticationRef")
+	}
+	return nil
+}
+
+// GetScaleDecisionWebhook returns the scale decision webhook of the ScaledObject, nil if it isn't set
+func (so *ScaledObject) GetScaleDecisionWebhook() *ScaleDecisionWebhook {
+	if so.Spec.Advanced == nil {
+		return nil
+	}
+	return so.Spec.Advanced.ScaleDecisionWebhook
+}
+
+// GetScaleDecisionWebhook returns the scale decision webhook of the ScaledJob, nil if it isn't set
+func (s *ScaledJob) GetScaleDecisionWebhook() *ScaleDecisionWebhook {
+	if s.Spec.Advanced == nil {
+		return nil
+	}
+	return s.Spec.Advanced.ScaleDecisionWebhook
+}
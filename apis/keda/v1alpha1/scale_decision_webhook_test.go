@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateScaleDecisionWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *ScaleDecisionWebhook
+		isError bool
+	}{
+		{"not set", nil, false},
+		{"defaults", &ScaleDecisionWebhook{URL: "http://decider.default.svc/decide"}, false},
+		{"properly formed", &ScaleDecisionWebhook{URL: "https://decider.default.svc/decide", Timeout: &metav1.Duration{Duration: time.Second}, FailurePolicy: ScaleDecisionFailurePolicyFail, AuthModes: "bearer", AuthenticationRef: &AuthenticationRef{Name: "decider"}}, false},
+		{"relative url", &ScaleDecisionWebhook{URL: "/decide"}, true},
+		{"unsupported scheme", &ScaleDecisionWebhook{URL: "grpc://decider.default.svc"}, true},
+		{"zero timeout", &ScaleDecisionWebhook{URL: "http://decider.default.svc", Timeout: &metav1.Duration{}}, true},
+		{"unknown failure policy", &ScaleDecisionWebhook{URL: "http://decider.default.svc", FailurePolicy: "Retry"}, true},
+		{"authModes without authenticationRef", &ScaleDecisionWebhook{URL: "http://decider.default.svc", AuthModes: "basic"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateScaleDecisionWebhook(test.webhook)
+			assert.Equal(t, test.isError, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func TestScaleDecisionWebhookDefaults(t *testing.T) {
+	webhook := &ScaleDecisionWebhook{URL: "http://decider.default.svc"}
+	assert.Equal(t, 5*time.Second, webhook.GetTimeout())
+	assert.False(t, webhook.FailsClosed())
+
+	webhook.FailurePolicy = ScaleDecisionFailurePolicyFail
+	assert.True(t, webhook.FailsClosed())
+}
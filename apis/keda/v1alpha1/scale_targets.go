@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// WeightedScaleTarget is an additional target scaled by the triggers of the ScaledObject next to scaleTargetRef
+type WeightedScaleTarget struct {
+	ScaleTarget `json:",inline"`
+	// Weight is the ratio of the replicas of this target to the replicas of scaleTargetRef, defaults to 1,
+	// it isn't supported with metrics of type Value
+	// +optional
+	Weight string `json:"weight,omitempty"`
+}
+
+// ScaleTargetStatus is the state of an additional target of the ScaledObject
+type ScaleTargetStatus struct {
+	Name string `json:"name"`
+	// +optional
+	ScaleTargetKind string `json:"scaleTargetKind,omitempty"`
+	// +optional
+	ScaleTargetGVKR *GroupVersionKindResource `json:"scaleTargetGVKR,omitempty"`
+	// HpaName is the HPA scaling this target
+	// +optional
+	HpaName string `json:"hpaName,omitempty"`
+	// +optional
+	Weight string `json:"weight,omitempty"`
+}
+
+// GetWeight returns the ratio of the replicas of the target to the replicas of scaleTargetRef
+func (t *WeightedScaleTarget) GetWeight() float64 {
+	return parseScaleTargetWeight(t.Weight)
+}
+
+// GetWeight returns the ratio of the replicas of the target to the replicas of scaleTargetRef
+func (s *ScaleTargetStatus) GetWeight() float64 {
+	return parseScaleTargetWeight(s.Weight)
+}
+
+func parseScaleTargetWeight(weight string) float64 {
+	if weight == "" {
+		return 1
+	}
+	value, err := strconv.ParseFloat(weight, 64)
+	if err != nil || value <= 0 {
+		return 1
+	}
+	return value
+}
+
+// WeightedReplicaCount returns the replicas of a target with the weight for the replicas of scaleTargetRef,
+// a target which should run is never scaled below 1 replica
+func WeightedReplicaCount(replicas int32, weight float64) int32 {
+	if replicas <= 0 {
+		return replicas
+	}
+	return max(1, int32(math.Ceil(float64(replicas)*weight)))
+}
+
+// HasScaleTargetRefs returns true if the ScaledObject scales additional targets
+func (so *ScaledObject) HasScaleTargetRefs() bool {
+	return len(so.Spec.ScaleTargetRefs) > 0
+}
+
+// getScaleTargets returns scaleTargetRef followed by the additional targets
+func (so *ScaledObject) getScaleTargets() []ScaleTarget {
+	var targets []ScaleTarget
+	if so.Spec.ScaleTargetRef != nil {
+		targets = append(targets, *so.Spec.ScaleTargetRef)
+	}
+	for _, target := range so.Spec.ScaleTargetRefs {
+		targets = append(targets, target.ScaleTarget)
+	}
+	return targets
+}
+
+// ValidateScaleTargetRefs checks the additional targets are unique, differ from scaleTargetRef and have a valid weight,
+// the additional targets are scaled by their HPAs only, which aren't created in native scaling mode or dry-run
+func ValidateScaleTargetRefs(so *ScaledObject) error {
+	if so.HasScaleTargetRefs() && so.IsNativeScaling() {
+		return fmt.Errorf("scaleTargetRefs are not supported in %s scaling mode", ScalingModeNative)
+	}
+	if so.HasScaleTargetRefs() && so.IsDryRun() {
+		return fmt.Errorf("scaleTargetRefs are not supported in dry-run")
+	}
+	seen := map[string]bool{}
+	if so.Spec.ScaleTargetRef != nil {
+		seen[scaleTargetKey(*so.Spec.ScaleTargetRef)] = true
+	}
+	for _, target := range so.Spec.ScaleTargetRefs {
+		if target.Name == "" {
+			return fmt.Errorf("name of scaleTargetRefs must not be empty")
+		}
+		key := scaleTargetKey(target.ScaleTarget)
+		if seen[key] {
+			return fmt.Errorf("scale target %q is defined multiple times, but it must be unique", target.Name)
+		}
+		seen[key] = true
+		if target.Weight != "" {
+			weight, err := strconv.ParseFloat(target.Weight, 64)
+			if err != nil {
+				return fmt.Errorf("error parsing weight of scale target %q: %w", target.Name, err)
+			}
+			if weight <= 0 {
+				return fmt.Errorf("weight of scale target %q must be greater than 0", target.Name)
+			}
+			// the weight adjusts the AverageValue targets only, a Value target doesn't depend on the replicas
+			if weight != 1 && so.usesValueMetric() {
+				return fmt.Errorf("weight of scale target %q is not supported with metrics of type %s", target.Name, autoscalingv2.ValueMetricType)
+			}
+		}
+	}
+	return nil
+}
+
+// usesValueMetric returns whether any metric of the HPA has a Value target,
+// the HPA gets the composite metrics instead of the trigger metrics with scalingModifiers
+func (so *ScaledObject) usesValueMetric() bool {
+	if so.IsUsingModifiers() {
+		for _, composite := range so.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+			if composite.GetMetricType() == autoscalingv2.ValueMetricType {
+				return true
+			}
+		}
+		return false
+	}
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.MetricType == autoscalingv2.ValueMetricType {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleTargetKey identifies the target, an empty kind defaults to Deployment
+func scaleTargetKey(target ScaleTarget) string {
+	return getScaleTargetKind(target) + "/" + target.Name
+}
+
+func getScaleTargetKind(target ScaleTarget) string {
+	if target.Kind == "" {
+		return "Deployment"
+	}
+	return target.Kind
+}
+
+// GetScaleTargetHPAName returns the name of the HPA of an additional target of the ScaledObject with the given HPA name,
+// the name contains the kind of the target as targets of different kinds may have the same name
+func GetScaleTargetHPAName(hpaName string, target ScaleTarget) string {
+	return fmt.Sprintf("%s-%s-%s", hpaName, strings.ToLower(getScaleTargetKind(target)), target.Name)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestWeightedReplicaCount(t *testing.T) {
+	assert.Equal(t, int32(0), WeightedReplicaCount(0, 0.5))
+	assert.Equal(t, int32(1), WeightedReplicaCount(1, 0.1))
+	assert.Equal(t, int32(3), WeightedReplicaCount(5, 0.5))
+	assert.Equal(t, int32(10), WeightedReplicaCount(5, 2))
+}
+
+func TestValidateScaleTargetRefs(t *testing.T) {
+	tests := []struct {
+		name           string
+		targets        []WeightedScaleTarget
+		scalingMode    ScalingMode
+		annotations    map[string]string
+		metricType     autoscalingv2.MetricTargetType
+		expectedErrMsg string
+	}{
+		{
+			name: "valid targets",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}, Weight: "0.5"},
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-c"}},
+			},
+		},
+		{
+			name: "same as scaleTargetRef",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer", Kind: "Deployment"}},
+			},
+			expectedErrMsg: "scale target \"consumer\" is defined multiple times, but it must be unique",
+		},
+		{
+			name: "same name with other kind",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer", Kind: "StatefulSet"}},
+			},
+		},
+		{
+			name: "invalid weight",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}, Weight: "half"},
+			},
+			expectedErrMsg: "error parsing weight of scale target \"consumer-zone-b\"",
+		},
+		{
+			name: "negative weight",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}, Weight: "-1"},
+			},
+			expectedErrMsg: "weight of scale target \"consumer-zone-b\" must be greater than 0",
+		},
+		{
+			name: "weight with Value metric",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}, Weight: "0.5"},
+			},
+			metricType:     autoscalingv2.ValueMetricType,
+			expectedErrMsg: "weight of scale target \"consumer-zone-b\" is not supported with metrics of type Value",
+		},
+		{
+			name: "weight of 1 with Value metric",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}, Weight: "1"},
+			},
+			metricType: autoscalingv2.ValueMetricType,
+		},
+		{
+			name: "native scaling mode",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}},
+			},
+			scalingMode:    ScalingModeNative,
+			expectedErrMsg: "scaleTargetRefs are not supported in native scaling mode",
+		},
+		{
+			name:        "native scaling mode without targets",
+			scalingMode: ScalingModeNative,
+		},
+		{
+			name: "dry-run",
+			targets: []WeightedScaleTarget{
+				{ScaleTarget: ScaleTarget{Name: "consumer-zone-b"}},
+			},
+			annotations:    map[string]string{DryRunAnnotation: "true"},
+			expectedErrMsg: "scaleTargetRefs are not supported in dry-run",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					ScaleTargetRef:  &ScaleTarget{Name: "consumer"},
+					ScaleTargetRefs: test.targets,
+					Advanced:        &AdvancedConfig{ScalingMode: test.scalingMode},
+					Triggers:        []ScaleTriggers{{Type: "kafka", MetricType: test.metricType}},
+				},
+			}
+			so.Annotations = test.annotations
+			err := ValidateScaleTargetRefs(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestGetHpaNames(t *testing.T) {
+	so := ScaledObject{}
+	so.Name = "consumer"
+	so.Spec.ScaleTargetRefs = []WeightedScaleTarget{
+		{ScaleTarget: ScaleTarget{Name: "worker"}},
+		{ScaleTarget: ScaleTarget{Name: "worker", Kind: "StatefulSet"}},
+	}
+
+	// targets of different kinds with the same name get different HPAs
+	assert.Equal(t, []string{"keda-hpa-consumer", "keda-hpa-consumer-deployment-worker", "keda-hpa-consumer-statefulset-worker"}, getHpaNames(so))
+}
@@ -54,6 +54,8 @@ type ScaledJobSpec struct {
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	AdaptivePolling *AdaptivePolling `json:"adaptivePolling,omitempty"`
+	// +optional
 	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
 	// +optional
 	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
@@ -69,7 +71,9 @@ type ScaledJobSpec struct {
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	// +optional
+	Advanced *ScaledJobAdvancedConfig `json:"advanced,omitempty"`
+	Triggers []ScaleTriggers          `json:"triggers"`
 }
 
 // ScaledJobStatus defines the observed state of ScaledJob
@@ -85,6 +89,9 @@ type ScaledJobStatus struct {
 	TriggersTypes *string `json:"triggersTypes,omitempty"`
 	// +optional
 	AuthenticationsTypes *string `json:"authenticationsTypes,omitempty"`
+	// ScalingFreeze is the active ScalingFreeze or ClusterScalingFreeze the ScaledJob is frozen by
+	// +optional
+	ScalingFreeze *ScalingFreezeStatus `json:"scalingFreeze,omitempty"`
 }
 
 // ScaledJobList contains a list of ScaledJob
@@ -95,6 +102,13 @@ type ScaledJobList struct {
 	Items           []ScaledJob `json:"items"`
 }
 
+// ScaledJobAdvancedConfig specifies advance scaling options
+type ScaledJobAdvancedConfig struct {
+	// ScaleDecisionWebhook is consulted before KEDA creates Jobs for a proposed scale change
+	// +optional
+	ScaleDecisionWebhook *ScaleDecisionWebhook `json:"scaleDecisionWebhook,omitempty"`
+}
+
 // ScalingStrategy defines the strategy of Scaling
 // +optional
 type ScalingStrategy struct {
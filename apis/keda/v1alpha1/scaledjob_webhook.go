@@ -44,7 +44,13 @@ var _ webhook.Validator = &ScaledJob{}
 func (s *ScaledJob) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(s, "", "  ")
 	scaledjoblog.Info(fmt.Sprintf("validating scaledjob creation for %s", string(val)))
-	return nil, verifyTriggers(s, "create", false)
+	if err := verifyTriggers(s, "create", false); err != nil {
+		return nil, err
+	}
+	if err := verifyPausedUntil(s, "create", false); err != nil {
+		return nil, err
+	}
+	return nil, verifyScaleDecisionWebhook(s, "create", false)
 }
 
 func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -56,7 +62,13 @@ func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 		scaledjoblog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return nil, verifyTriggers(s, "update", false)
+	if err := verifyTriggers(s, "update", false); err != nil {
+		return nil, err
+	}
+	if err := verifyPausedUntil(s, "update", false); err != nil {
+		return nil, err
+	}
+	return nil, verifyScaleDecisionWebhook(s, "update", false)
 }
 
 func (s *ScaledJob) ValidateDelete() (admission.Warnings, error) {
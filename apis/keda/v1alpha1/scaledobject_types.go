@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -59,10 +61,14 @@ const ScaledObjectTransferHpaOwnershipAnnotation = "scaledobject.keda.sh/transfe
 const ValidationsHpaOwnershipAnnotation = "validations.keda.sh/hpa-ownership"
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 const PausedAnnotation = "autoscaling.keda.sh/paused"
+const DryRunAnnotation = "autoscaling.keda.sh/dry-run"
 const FallbackBehaviorStatic = "static"
 const FallbackBehaviorCurrentReplicas = "currentReplicas"
 const FallbackBehaviorCurrentReplicasIfHigher = "currentReplicasIfHigher"
 const FallbackBehaviorCurrentReplicasIfLower = "currentReplicasIfLower"
+const FallbackBehaviorLastKnownGood = "lastKnownGood"
+
+const defaultFallbackMaxAge = 5 * time.Minute
 
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
@@ -70,11 +76,47 @@ type HealthStatus struct {
 	NumberOfFailures *int32 `json:"numberOfFailures,omitempty"`
 	// +optional
 	Status HealthStatusType `json:"status,omitempty"`
+	// +optional
+	FallbackPolicy FallbackPolicyType `json:"fallbackPolicy,omitempty"`
+}
+
+// TriggerStatus is the last observed state of a trigger of the ScaledObject
+type TriggerStatus struct {
+	// Name is the name of the trigger, or the scaler if the trigger has no name
+	Name string `json:"name"`
+	// Index is the index of the trigger in the triggers of the ScaledObject
+	// +optional
+	Index int32 `json:"index"`
+	// +optional
+	Type string `json:"type,omitempty"`
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+	// Value is the last metric value returned by the trigger
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// LastValueTime is the time when Value was returned by the trigger
+	// +optional
+	LastValueTime *metav1.Time `json:"lastValueTime,omitempty"`
+	// Active is the activation result of the trigger
+	// +optional
+	Active bool `json:"active,omitempty"`
+	// Disabled is set while the condition of the trigger evaluates to false
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// LastError is the error of the last failed query of the trigger, it is cleared once the trigger succeeds
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// DesiredReplicas is the replica count the HPA computes from Value
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
 }
 
 // HealthStatusType is an indication of whether the health status is happy or failing
 type HealthStatusType string
 
+// FallbackPolicyType is an indication of which fallback has been applied to a metric
+type FallbackPolicyType string
+
 const (
 	// HealthStatusHappy means the status of the health object is happy
 	HealthStatusHappy HealthStatusType = "Happy"
@@ -82,6 +124,12 @@ const (
 	// HealthStatusFailing means the status of the health object is failing
 	HealthStatusFailing HealthStatusType = "Failing"
 
+	// FallbackPolicyScaledObject means the fallback defined on the ScaledObject has been applied
+	FallbackPolicyScaledObject FallbackPolicyType = "ScaledObject"
+
+	// FallbackPolicyTrigger means the fallback defined on the trigger has been applied
+	FallbackPolicyTrigger FallbackPolicyType = "Trigger"
+
 	// CompositeMetricName is used for scalingModifiers composite metric
 	CompositeMetricName string = "composite-metric"
 
@@ -92,9 +140,20 @@ const (
 // ScaledObjectSpec is the spec for a ScaledObject resource
 type ScaledObjectSpec struct {
 	ScaleTargetRef *ScaleTarget `json:"scaleTargetRef"`
+	// TemplateRef references the ScaledObjectTemplate or ClusterScaledObjectTemplate the ScaledObject is rendered from,
+	// the fields set in the ScaledObject take precedence over the template
+	// +optional
+	TemplateRef *ScaledObjectTemplateRef `json:"templateRef,omitempty"`
+	// ScaleTargetRefs are additional targets driven by the same triggers as ScaleTargetRef,
+	// every target is scaled by its own HPA owned by the ScaledObject, so they are neither supported
+	// in native scaling mode nor in dry-run
+	// +optional
+	ScaleTargetRefs []WeightedScaleTarget `json:"scaleTargetRefs,omitempty"`
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	AdaptivePolling *AdaptivePolling `json:"adaptivePolling,omitempty"`
+	// +optional
 	InitialCooldownPeriod *int32 `json:"initialCooldownPeriod,omitempty"`
 	// +optional
 	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
@@ -105,9 +164,13 @@ type ScaledObjectSpec struct {
 	// +optional
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
+	ReplicaCountSchedules []ReplicaCountSchedule `json:"replicaCountSchedules,omitempty"`
+	// +optional
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 
-	Triggers []ScaleTriggers `json:"triggers"`
+	// Triggers are required unless they are provided by the template of TemplateRef
+	// +optional
+	Triggers []ScaleTriggers `json:"triggers,omitempty"`
 	// +optional
 	Fallback *Fallback `json:"fallback,omitempty"`
 }
@@ -118,8 +181,20 @@ type Fallback struct {
 	Replicas         int32 `json:"replicas"`
 	// +optional
 	// +kubebuilder:default=static
-	// +kubebuilder:validation:Enum=static;currentReplicas;currentReplicasIfHigher;currentReplicasIfLower
+	// +kubebuilder:validation:Enum=static;currentReplicas;currentReplicasIfHigher;currentReplicasIfLower;lastKnownGood
 	Behavior string `json:"behavior,omitempty"`
+	// MaxAge is the number of seconds the last known good metric is served for with the lastKnownGood behavior,
+	// Replicas are used once the metric is older
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+}
+
+// GetMaxAge returns how long the last known good metric can be served for
+func (f *Fallback) GetMaxAge() time.Duration {
+	if f.MaxAge != nil {
+		return time.Duration(*f.MaxAge) * time.Second
+	}
+	return defaultFallbackMaxAge
 }
 
 // AdvancedConfig specifies advance scaling options
@@ -130,6 +205,16 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// ScalingMode defines whether an HPA (default) scales the target or KEDA computes the replicas
+	// with the HPA algorithm and the behavior of HorizontalPodAutoscalerConfig itself
+	// +optional
+	ScalingMode ScalingMode `json:"scalingMode,omitempty"`
+	// ScaleDecisionWebhook is consulted before KEDA acts on a proposed scale change. In dry-run and native
+	// scaling mode the webhook decides every change of replicas, with an HPA it decides only the changes made
+	// by KEDA (activation, deactivation and fallback), as the HPA scales between min and max replicas on its own.
+	// A vetoed change keeps the current replicas
+	// +optional
+	ScaleDecisionWebhook *ScaleDecisionWebhook `json:"scaleDecisionWebhook,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -140,6 +225,24 @@ type ScalingModifiers struct {
 	ActivationTarget string `json:"activationTarget,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// Timezone in which the time variables of the formula are evaluated, defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// CompositeMetrics are named formulas exposed to the HPA as separate external metrics,
+	// next to the composite metric of Formula. The HPA scales on the highest of them.
+	// +optional
+	CompositeMetrics []CompositeMetric `json:"compositeMetrics,omitempty"`
+}
+
+// CompositeMetric is a named scalingModifiers formula with its own target
+type CompositeMetric struct {
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Target  string `json:"target"`
+	// +optional
+	ActivationTarget string `json:"activationTarget,omitempty"`
+	// +optional
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
@@ -178,20 +281,46 @@ type ScaledObjectStatus struct {
 	ExternalMetricNames []string `json:"externalMetricNames,omitempty"`
 	// +optional
 	ResourceMetricNames []string `json:"resourceMetricNames,omitempty"`
+	// CompositeScalerName is the first of CompositeScalerNames, kept for compatibility
 	// +optional
 	CompositeScalerName string `json:"compositeScalerName,omitempty"`
+	// CompositeScalerNames are the names of the composite metrics of scalingModifiers
+	// +optional
+	CompositeScalerNames []string `json:"compositeScalerNames,omitempty"`
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
 	// +optional
 	Health map[string]HealthStatus `json:"health,omitempty"`
+	// Triggers is the last observed state of the triggers, the updates are rate-limited
+	// +optional
+	Triggers []TriggerStatus `json:"triggers,omitempty"`
 	// +optional
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
+	// DryRunReplicaCount is the replica count KEDA would have scaled the target to, set only in dry-run mode
+	// +optional
+	DryRunReplicaCount *int32 `json:"dryRunReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// ScaleTargets are the additional targets of ScaleTargetRefs together with their HPAs,
+	// ScaleTargetKind, ScaleTargetGVKR and HpaName describe ScaleTargetRef
+	// +optional
+	ScaleTargets []ScaleTargetStatus `json:"scaleTargets,omitempty"`
 	// +optional
 	TriggersTypes *string `json:"triggersTypes,omitempty"`
 	// +optional
 	AuthenticationsTypes *string `json:"authenticationsTypes,omitempty"`
+	// +optional
+	ActiveReplicaCountSchedule string `json:"activeReplicaCountSchedule,omitempty"`
+	// ScalingFreeze is the active ScalingFreeze or ClusterScalingFreeze the ScaledObject is frozen by
+	// +optional
+	ScalingFreeze *ScalingFreezeStatus `json:"scalingFreeze,omitempty"`
+	// ScalingBudget is the ScalingBudget limiting the replicas of the ScaledObject the most, the HPA doesn't scale
+	// the target beyond its MaxReplicas
+	// +optional
+	ScalingBudget *ScalingBudgetAllocation `json:"scalingBudget,omitempty"`
+	// RenderedSpec is the effective spec of a ScaledObject rendered from its TemplateRef
+	// +optional
+	RenderedSpec *ScaledObjectSpec `json:"renderedSpec,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -217,15 +346,35 @@ func (so *ScaledObject) HasPausedReplicaAnnotation() bool {
 	return pausedReplicasAnnotationFound
 }
 
-// HasPausedAnnotation returns whether this ScaledObject has PausedAnnotation or PausedReplicasAnnotation
+// HasPausedAnnotation returns whether this ScaledObject has PausedAnnotation, PausedReplicasAnnotation or a valid PausedUntilAnnotation
 func (so *ScaledObject) HasPausedAnnotation() bool {
 	_, pausedAnnotationFound := so.GetAnnotations()[PausedAnnotation]
 	_, pausedReplicasAnnotationFound := so.GetAnnotations()[PausedReplicasAnnotation]
-	return pausedAnnotationFound || pausedReplicasAnnotationFound
+	return pausedAnnotationFound || pausedReplicasAnnotationFound || HasPausedUntil(so.GetAnnotations())
+}
+
+// IsDryRun returns whether this ScaledObject has DryRunAnnotation set to true, in dry-run mode KEDA
+// evaluates the scaling but neither scales the target nor creates an HPA
+func (so *ScaledObject) IsDryRun() bool {
+	dryRunAnnotationValue, dryRunAnnotationFound := so.GetAnnotations()[DryRunAnnotation]
+	if !dryRunAnnotationFound {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(dryRunAnnotationValue)
+	if err != nil {
+		// if annotation value is not a boolean, we assume user wants to keep the ScaledObject from scaling
+		return true
+	}
+	return dryRun
 }
 
-// NeedToBePausedByAnnotation will check whether ScaledObject needs to be paused based on PausedAnnotation or PausedReplicaCount
+// NeedToBePausedByAnnotation will check whether ScaledObject needs to be paused based on PausedAnnotation or PausedReplicaCount,
+// the pause is over once PausedUntilAnnotation expired
 func (so *ScaledObject) NeedToBePausedByAnnotation() bool {
+	if IsPauseExpired(so.GetAnnotations(), time.Now()) {
+		return false
+	}
+
 	_, pausedReplicasAnnotationFound := so.GetAnnotations()[PausedReplicasAnnotation]
 	if pausedReplicasAnnotationFound {
 		return so.Status.PausedReplicaCount != nil
@@ -233,7 +382,8 @@ func (so *ScaledObject) NeedToBePausedByAnnotation() bool {
 
 	pausedAnnotationValue, pausedAnnotationFound := so.GetAnnotations()[PausedAnnotation]
 	if !pausedAnnotationFound {
-		return false
+		// a valid PausedUntilAnnotation on its own pauses the ScaledObject until it expires
+		return HasPausedUntil(so.GetAnnotations())
 	}
 	shouldPause, err := strconv.ParseBool(pausedAnnotationValue)
 	if err != nil {
@@ -248,62 +398,162 @@ func (so *ScaledObject) IsUsingModifiers() bool {
 	return so.Spec.Advanced != nil && !reflect.DeepEqual(so.Spec.Advanced.ScalingModifiers, ScalingModifiers{})
 }
 
-// GetHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
+// HasFallback determines whether a fallback is defined on the ScaledObject or on any of its triggers
+func (so *ScaledObject) HasFallback() bool {
+	if so.Spec.Fallback != nil {
+		return true
+	}
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Fallback != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFallbackForTriggers determines whether a fallback applies to any of the triggers with the given indexes,
+// all triggers are considered if no indexes are given
+func (so *ScaledObject) HasFallbackForTriggers(triggerIndexes []int) bool {
+	if len(triggerIndexes) == 0 {
+		return so.HasFallback()
+	}
+	for _, triggerIndex := range triggerIndexes {
+		if fallback, _ := so.GetFallbackForTrigger(triggerIndex); fallback != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFallbackReplicasForTriggers determines whether the fallback applying to any of the triggers with the given indexes
+// falls back to a non-zero replica count, all triggers are considered if no indexes are given
+func (so *ScaledObject) HasFallbackReplicasForTriggers(triggerIndexes []int) bool {
+	if len(triggerIndexes) == 0 {
+		if so.Spec.Fallback != nil && so.Spec.Fallback.Replicas != 0 {
+			return true
+		}
+		for _, trigger := range so.Spec.Triggers {
+			if trigger.Fallback != nil && trigger.Fallback.Replicas != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	for _, triggerIndex := range triggerIndexes {
+		if fallback, _ := so.GetFallbackForTrigger(triggerIndex); fallback != nil && fallback.Replicas != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFallbackForTrigger returns the fallback which applies to the trigger with the given index together with its policy,
+// the fallback defined on the trigger takes precedence over the one defined on the ScaledObject
+func (so *ScaledObject) GetFallbackForTrigger(triggerIndex int) (*Fallback, FallbackPolicyType) {
+	if triggerIndex >= 0 && triggerIndex < len(so.Spec.Triggers) && so.Spec.Triggers[triggerIndex].Fallback != nil {
+		return so.Spec.Triggers[triggerIndex].Fallback, FallbackPolicyTrigger
+	}
+	if so.Spec.Fallback != nil {
+		return so.Spec.Fallback, FallbackPolicyScaledObject
+	}
+	return nil, ""
+}
+
+// GetHPAMinReplicas returns MinReplicas based on definition in ScaledObject (including the active replicaCountSchedule)
+// or default value if not defined
 func (so *ScaledObject) GetHPAMinReplicas() *int32 {
-	if so.Spec.MinReplicaCount != nil && *so.Spec.MinReplicaCount > 0 {
-		return so.Spec.MinReplicaCount
+	return getHPAMinReplicas(so.GetMinReplicaCount())
+}
+
+// GetHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject (including the active replicaCountSchedule)
+// or default value if not defined, limited by the ScalingBudget of the ScaledObject
+func (so *ScaledObject) GetHPAMaxReplicas() int32 {
+	maxReplicas := getHPAMaxReplicas(so.GetMaxReplicaCount())
+	if budget := so.Status.ScalingBudget; budget != nil && budget.MaxReplicas < maxReplicas {
+		// the HPA requires MaxReplicas not to be lower than MinReplicas
+		maxReplicas = max(budget.MaxReplicas, *so.GetHPAMinReplicas())
+	}
+	return maxReplicas
+}
+
+func getHPAMinReplicas(minReplicaCount *int32) *int32 {
+	if minReplicaCount != nil && *minReplicaCount > 0 {
+		return minReplicaCount
 	}
 	tmp := defaultHPAMinReplicas
 	return &tmp
 }
 
-// GetHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
-func (so *ScaledObject) GetHPAMaxReplicas() int32 {
-	if so.Spec.MaxReplicaCount != nil {
-		return *so.Spec.MaxReplicaCount
+func getHPAMaxReplicas(maxReplicaCount *int32) int32 {
+	if maxReplicaCount != nil {
+		return *maxReplicaCount
 	}
 	return defaultHPAMaxReplicas
 }
 
 // CheckReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
-// i.e. that Min is not greater than Max or Idle greater or equal to Min
+// i.e. that Min is not greater than Max or Idle greater or equal to Min, for the spec and for every replicaCountSchedule
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
+	if err := checkReplicaCountBounds(scaledObject.Spec.IdleReplicaCount, scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount); err != nil {
+		return err
+	}
+	return checkReplicaCountSchedulesAreValid(scaledObject)
+}
+
+func checkReplicaCountBounds(idleReplicaCount, minReplicaCount, maxReplicaCount *int32) error {
 	minReplicas := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		minReplicas = *scaledObject.GetHPAMinReplicas()
+	if minReplicaCount != nil {
+		minReplicas = *getHPAMinReplicas(minReplicaCount)
 	}
-	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	maxReplicas := getHPAMaxReplicas(maxReplicaCount)
 
 	if minReplicas > maxReplicas {
 		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", minReplicas, maxReplicas)
 	}
 
-	if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= minReplicas {
-		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, minReplicas)
+	if idleReplicaCount != nil && *idleReplicaCount >= minReplicas {
+		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *idleReplicaCount, minReplicas)
 	}
 
 	return nil
 }
 
-// CheckFallbackValid checks that the fallback supports scalers with an AverageValue metric target.
-// Consequently, it does not support CPU & memory scalers, or scalers targeting a Value metric type.
+// CheckFallbackValid checks that the fallback supports scalers with an AverageValue or Value metric target.
+// Consequently, it does not support CPU & memory scalers, or scalers targeting a Utilization metric type.
+// Fallbacks defined on triggers are checked the same way, only for the trigger they are defined on.
 func CheckFallbackValid(scaledObject *ScaledObject) error {
-	if scaledObject.Spec.Fallback == nil {
-		return nil
-	}
-
-	if scaledObject.Spec.Fallback.FailureThreshold < 0 || scaledObject.Spec.Fallback.Replicas < 0 {
-		return fmt.Errorf("FailureThreshold=%d & Replicas=%d must both be greater than or equal to 0",
-			scaledObject.Spec.Fallback.FailureThreshold, scaledObject.Spec.Fallback.Replicas)
+	if scaledObject.Spec.Fallback != nil {
+		if err := checkFallbackParameters(scaledObject.Spec.Fallback); err != nil {
+			return err
+		}
 	}
 
 	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Fallback != nil {
+			if err := checkFallbackParameters(trigger.Fallback); err != nil {
+				return fmt.Errorf("trigger %q: %w", trigger.Name, err)
+			}
+		}
+		if scaledObject.Spec.Fallback == nil && trigger.Fallback == nil {
+			continue
+		}
 		if trigger.Type == cpuString || trigger.Type == memoryString {
 			scaledobjecttypeslog.Error(nil, fmt.Sprintf("type is %s , but fallback it is not supported by the CPU & memory scalers", trigger.Type))
 		}
-		if trigger.MetricType != autoscalingv2.AverageValueMetricType {
-			return fmt.Errorf("MetricType=%s, but fallback can only be enabled for triggers with metric of type AverageValue", trigger.MetricType)
+		if trigger.MetricType != autoscalingv2.AverageValueMetricType && trigger.MetricType != autoscalingv2.ValueMetricType {
+			return fmt.Errorf("MetricType=%s, but fallback can only be enabled for triggers with metric of type AverageValue or Value", trigger.MetricType)
 		}
 	}
 	return nil
 }
+
+func checkFallbackParameters(fallback *Fallback) error {
+	if fallback.FailureThreshold < 0 || fallback.Replicas < 0 {
+		return fmt.Errorf("FailureThreshold=%d & Replicas=%d must both be greater than or equal to 0",
+			fallback.FailureThreshold, fallback.Replicas)
+	}
+	if fallback.MaxAge != nil && *fallback.MaxAge < 0 {
+		return fmt.Errorf("MaxAge=%d must be greater than or equal to 0", *fallback.MaxAge)
+	}
+	return nil
+}
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -148,12 +151,45 @@ func isRemovingFinalizer(so *ScaledObject, old runtime.Object) bool {
 func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.Warnings, error) {
 	metricscollector.RecordScaledObjectValidatingTotal(so.Namespace, action)
 
+	if so.Spec.TemplateRef != nil {
+		rendered, err := renderScaledObject(so)
+		if err != nil {
+			return nil, err
+		}
+		so = rendered
+	}
+
+	return validateRenderedWorkload(so, action, dryRun)
+}
+
+// renderScaledObject returns a copy of the ScaledObject with the spec rendered from its TemplateRef
+func renderScaledObject(so *ScaledObject) (*ScaledObject, error) {
+	spec, err := GetScaledObjectTemplateSpec(context.Background(), kc, so)
+	if cacheMissToDirectClient && kerrors.IsNotFound(err) {
+		spec, err = GetScaledObjectTemplateSpec(context.Background(), directClient, so)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s %s: %w", so.Spec.TemplateRef.GetKind(), so.Spec.TemplateRef.Name, err)
+	}
+
+	renderedSpec, err := spec.Render(so)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering %s %s: %w", so.Spec.TemplateRef.GetKind(), so.Spec.TemplateRef.Name, err)
+	}
+	rendered := so.DeepCopy()
+	rendered.Spec = *renderedSpec
+	return rendered, nil
+}
+
+func validateRenderedWorkload(so *ScaledObject, action string, dryRun bool) (admission.Warnings, error) {
 	verifyFunctions := map[string]func(*ScaledObject, string, bool) error{
 		"verifyCPUMemoryScalers": verifyCPUMemoryScalers,
 		"verifyScaledObjects":    verifyScaledObjects,
 		"verifyHpas":             verifyHpas,
 		"verifyReplicaCount":     verifyReplicaCount,
 		"verifyFallback":         verifyFallback,
+		"verifyScaleTargetRefs":  verifyScaleTargetRefs,
+		"verifyScalingMode":      verifyScalingMode,
 	}
 
 	for functionName, function := range verifyFunctions {
@@ -165,7 +201,10 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 	}
 
 	verifyCommonFunctions := map[string]func(interface{}, string, bool) error{
-		"verifyTriggers": verifyTriggers,
+		"verifyTriggers":             verifyTriggers,
+		"verifyAdaptivePolling":      verifyAdaptivePolling,
+		"verifyScaleDecisionWebhook": verifyScaleDecisionWebhook,
+		"verifyPausedUntil":          verifyPausedUntil,
 	}
 
 	for functionName, function := range verifyCommonFunctions {
@@ -199,6 +238,41 @@ func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
 	return err
 }
 
+func verifyScaleTargetRefs(incomingSo *ScaledObject, action string, _ bool) error {
+	err := ValidateScaleTargetRefs(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-scale-target-refs")
+	}
+	return err
+}
+
+func verifyScalingMode(incomingSo *ScaledObject, action string, _ bool) error {
+	err := ValidateScalingMode(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-scaling-mode")
+	}
+	return err
+}
+
+// findSharedScaleTarget returns an additional target of one ScaledObject which is scaled by the other ScaledObject as well
+func findSharedScaleTarget(so, incomingSo *ScaledObject) (string, bool) {
+	if !so.HasScaleTargetRefs() && !incomingSo.HasScaleTargetRefs() {
+		return "", false
+	}
+	targets := map[string]bool{}
+	for _, target := range so.getScaleTargets() {
+		targets[scaleTargetKey(target)] = true
+	}
+	for _, target := range incomingSo.getScaleTargets() {
+		if targets[scaleTargetKey(target)] {
+			return scaleTargetKey(target), true
+		}
+	}
+	return "", false
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -224,21 +298,86 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	return err
 }
 
+func verifyAdaptivePolling(incomingObject interface{}, action string, _ bool) error {
+	withTriggers, err := AsDuckWithTriggers(incomingObject)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateAdaptivePolling(withTriggers.Spec.PollingInterval, withTriggers.Spec.AdaptivePolling)
+	if err != nil {
+		scaledobjectlog.WithValues("name", withTriggers.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(withTriggers.Namespace, action, "incorrect-adaptive-polling")
+	}
+	return err
+}
+
+func verifyScaleDecisionWebhook(incomingObject interface{}, action string, _ bool) error {
+	var webhook *ScaleDecisionWebhook
+	var name, namespace string
+	switch obj := incomingObject.(type) {
+	case *ScaledObject:
+		webhook, name, namespace = obj.GetScaleDecisionWebhook(), obj.Name, obj.Namespace
+	case *ScaledJob:
+		webhook, name, namespace = obj.GetScaleDecisionWebhook(), obj.Name, obj.Namespace
+	}
+
+	err := ValidateScaleDecisionWebhook(webhook)
+	if err != nil {
+		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-scale-decision-webhook")
+	}
+	return err
+}
+
+func verifyPausedUntil(incomingObject interface{}, action string, _ bool) error {
+	var annotations map[string]string
+	var name, namespace string
+	switch obj := incomingObject.(type) {
+	case *ScaledObject:
+		annotations, name, namespace = obj.GetAnnotations(), obj.Name, obj.Namespace
+	case *ScaledJob:
+		annotations, name, namespace = obj.GetAnnotations(), obj.Name, obj.Namespace
+	}
+
+	err := ValidatePausedUntil(annotations)
+	if err != nil {
+		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-paused-until")
+	}
+	return err
+}
+
 func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
+	reason, err := CheckHpaConflicts(context.Background(), kc, restMapper, incomingSo)
+	if err != nil && reason != "" {
+		scaledobjectlog.Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, reason)
+	}
+	return err
+}
+
+// CheckHpaConflicts returns an error if the scale target of the ScaledObject is already managed by an HPA
+// the ScaledObject doesn't own, together with the reason of the conflict. A dry-run ScaledObject never scales
+// its target, so it may be trialed against a workload which is already autoscaled.
+func CheckHpaConflicts(ctx context.Context, c client.Reader, mapper meta.RESTMapper, incomingSo *ScaledObject) (string, error) {
+	if incomingSo.IsDryRun() {
+		return "", nil
+	}
 	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
 	opt := &client.ListOptions{
 		Namespace: incomingSo.Namespace,
 	}
-	err := kc.List(context.Background(), hpaList, opt)
+	err := c.List(ctx, hpaList, opt)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var incomingSoGvkr GroupVersionKindResource
-	incomingSoGvkr, err = ParseGVKR(restMapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
+	incomingSoGvkr, err = ParseGVKR(mapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
 	if err != nil {
 		scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
-		return err
+		return "", err
 	}
 
 	for _, hpa := range hpaList.Items {
@@ -248,10 +387,10 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 		val, _ := json.MarshalIndent(hpa, "", "  ")
 		scaledobjectlog.V(1).Info(fmt.Sprintf("checking hpa %s: %v", hpa.Name, string(val)))
 
-		hpaGvkr, err := ParseGVKR(restMapper, hpa.Spec.ScaleTargetRef.APIVersion, hpa.Spec.ScaleTargetRef.Kind)
+		hpaGvkr, err := ParseGVKR(mapper, hpa.Spec.ScaleTargetRef.APIVersion, hpa.Spec.ScaleTargetRef.Kind)
 		if err != nil {
 			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from HPA", "hpaName", hpa.Name, "apiVersion", hpa.Spec.ScaleTargetRef.APIVersion, "kind", hpa.Spec.ScaleTargetRef.Kind)
-			return err
+			return "", err
 		}
 
 		if hpaGvkr.GVKString() == incomingSoGvkr.GVKString() &&
@@ -271,74 +410,90 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 					incomingSo.Spec.Advanced.HorizontalPodAutoscalerConfig.Name == hpa.Name {
 					scaledobjectlog.Info(fmt.Sprintf("%s hpa ownership being transferred to %s", hpa.Name, incomingSo.Name))
 				} else {
-					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", incomingSo.Spec.ScaleTargetRef.Name, incomingSoGvkr.GVKString(), hpa.Name)
-					scaledobjectlog.Error(err, "validation error")
-					metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-hpa")
-					return err
+					return "other-hpa", fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", incomingSo.Spec.ScaleTargetRef.Name, incomingSoGvkr.GVKString(), hpa.Name)
 				}
 			}
 		}
 	}
-	return nil
+	return "", nil
 }
 
 func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error {
+	reason, err := CheckScaledObjectConflicts(context.Background(), kc, restMapper, incomingSo)
+	if err != nil {
+		if reason != "" {
+			scaledobjectlog.Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, reason)
+		}
+		return err
+	}
+
+	// verify ScalingModifiers structure if defined in ScaledObject
+	if incomingSo.IsUsingModifiers() {
+		_, err = ValidateAndCompileScalingModifiers(incomingSo)
+		if err != nil {
+			scaledobjectlog.Error(err, "error validating ScalingModifiers")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "scaling-modifiers")
+
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckScaledObjectConflicts returns an error if the scale targets or the HPA of the ScaledObject are already managed
+// by another ScaledObject in its namespace, together with the reason of the conflict. Dry-run ScaledObjects neither
+// scale their targets nor create HPAs, so they don't conflict with any other ScaledObject.
+func CheckScaledObjectConflicts(ctx context.Context, c client.Reader, mapper meta.RESTMapper, incomingSo *ScaledObject) (string, error) {
+	if incomingSo.IsDryRun() {
+		return "", nil
+	}
 	soList := &ScaledObjectList{}
 	opt := &client.ListOptions{
 		Namespace: incomingSo.Namespace,
 	}
-	err := kc.List(context.Background(), soList, opt)
+	err := c.List(ctx, soList, opt)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	incomingSoGckr, err := ParseGVKR(restMapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
+	incomingSoGckr, err := ParseGVKR(mapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
 	if err != nil {
 		scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
-		return err
+		return "", err
 	}
 
-	incomingSoHpaName := getHpaName(*incomingSo)
+	incomingSoHpaNames := getHpaNames(*incomingSo)
 	for _, so := range soList.Items {
-		if so.Name == incomingSo.Name {
+		if so.Name == incomingSo.Name || so.IsDryRun() {
 			continue
 		}
+		so.ApplyRenderedSpec()
 		val, _ := json.MarshalIndent(so, "", "  ")
 		scaledobjectlog.V(1).Info(fmt.Sprintf("checking scaledobject %s: %v", so.Name, string(val)))
 
-		soGckr, err := ParseGVKR(restMapper, so.Spec.ScaleTargetRef.APIVersion, so.Spec.ScaleTargetRef.Kind)
+		soGckr, err := ParseGVKR(mapper, so.Spec.ScaleTargetRef.APIVersion, so.Spec.ScaleTargetRef.Kind)
 		if err != nil {
 			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from ScaledObject", "soName", so.Name, "apiVersion", so.Spec.ScaleTargetRef.APIVersion, "kind", so.Spec.ScaleTargetRef.Kind)
-			return err
+			return "", err
 		}
 
 		if soGckr.GVKString() == incomingSoGckr.GVKString() &&
 			so.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
-			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), so.Name)
-			scaledobjectlog.Error(err, "validation error")
-			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object")
-			return err
+			return "other-scaled-object", fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), so.Name)
 		}
 
-		if getHpaName(so) == incomingSoHpaName {
-			err = fmt.Errorf("the HPA '%s' is already managed by the ScaledObject '%s'", so.Spec.Advanced.HorizontalPodAutoscalerConfig.Name, so.Name)
-			scaledobjectlog.Error(err, "validation error")
-			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object-hpa")
-			return err
+		if target, found := findSharedScaleTarget(&so, incomingSo); found {
+			return "other-scaled-object", fmt.Errorf("the workload '%s' is already managed by the ScaledObject '%s'", target, so.Name)
 		}
-	}
-
-	// verify ScalingModifiers structure if defined in ScaledObject
-	if incomingSo.IsUsingModifiers() {
-		_, err = ValidateAndCompileScalingModifiers(incomingSo)
-		if err != nil {
-			scaledobjectlog.Error(err, "error validating ScalingModifiers")
-			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "scaling-modifiers")
 
-			return err
+		for _, hpaName := range getHpaNames(so) {
+			if slices.Contains(incomingSoHpaNames, hpaName) {
+				return "other-scaled-object-hpa", fmt.Errorf("the HPA '%s' is already managed by the ScaledObject '%s'", hpaName, so.Name)
+			}
 		}
 	}
-	return nil
+	return "", nil
 }
 
 // getFromCacheOrDirect is a helper function that tries to get an object from the cache
@@ -426,6 +581,16 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 				metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "scale-to-zero-requirements-not-met")
 				return err
 			}
+
+			// a replicaCountSchedule overrides minReplicaCount while its window is open
+			for _, schedule := range incomingSo.Spec.ReplicaCountSchedules {
+				if scaleToZeroErr && schedule.MinReplicaCount != nil && *schedule.MinReplicaCount == 0 {
+					err := fmt.Errorf("scaledobject has only cpu/memory triggers AND minReplica of replicaCountSchedule %q is 0 (scale to zero doesn't work in this case)", schedule.Name)
+					scaledobjectlog.Error(err, "validation error")
+					metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "scale-to-zero-requirements-not-met")
+					return err
+				}
+			}
 		}
 	}
 	return nil
@@ -433,56 +598,86 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 
 // ValidateAndCompileScalingModifiers validates all combinations of given arguments
 // and their values. Expects the whole structure's path to be defined (like .Advanced).
-// As part of formula validation this function also compiles the formula
+// As part of formula validation this function also compiles the formulas
 // (with dummy values that determine whether all necessary triggers are defined)
-// and returns it to be stored in cache and reused.
-func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
-	sm := so.Spec.Advanced.ScalingModifiers
+// and returns them by the name of their composite metric to be stored in cache and reused.
+func ValidateAndCompileScalingModifiers(so *ScaledObject) (map[string]*vm.Program, error) {
+	sm := &so.Spec.Advanced.ScalingModifiers
 
-	if sm.Formula == "" {
+	if !sm.HasFormula() {
 		return nil, fmt.Errorf("error ScalingModifiers.Formula is mandatory")
 	}
 
 	// cast return value of formula to float if necessary to avoid wrong value return
 	// type (ternary operator doesnt return float)
-	so.Spec.Advanced.ScalingModifiers.Formula = castToFloatIfNecessary(sm.Formula)
+	if sm.Formula != "" {
+		sm.Formula = castToFloatIfNecessary(sm.Formula)
+	}
+	names := map[string]bool{}
+	for i := range sm.CompositeMetrics {
+		composite := &sm.CompositeMetrics[i]
+		if errs := validation.IsDNS1123Label(composite.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("error name %q of ScalingModifiers.CompositeMetrics is invalid: %s", composite.Name, strings.Join(errs, ", "))
+		}
+		if names[composite.Name] {
+			return nil, fmt.Errorf("error composite metric %q is defined multiple times, but it must be unique", composite.Name)
+		}
+		names[composite.Name] = true
+		if composite.Formula == "" {
+			return nil, fmt.Errorf("error formula of composite metric %q is mandatory", composite.Name)
+		}
+		composite.Formula = castToFloatIfNecessary(composite.Formula)
+	}
 
-	// validate formula if not empty
-	compiledFormula, err := validateScalingModifiersFormula(so)
+	env, triggerNames, err := getScalingModifiersFormulaEnv(so)
 	if err != nil {
 		err := errors.Join(fmt.Errorf("error validating formula in ScalingModifiers"), err)
 		return nil, err
 	}
-	// validate target if not empty
-	err = validateScalingModifiersTarget(so)
-	if err != nil {
-		err := errors.Join(fmt.Errorf("error validating target in ScalingModifiers"), err)
-		return nil, err
+
+	compiledFormulas := map[string]*vm.Program{}
+	for _, composite := range sm.GetCompositeMetrics() {
+		// validate formula if not empty
+		compiledFormula, err := validateScalingModifiersFormula(composite, env, triggerNames)
+		if err != nil {
+			err := errors.Join(fmt.Errorf("error validating formula in ScalingModifiers"), err)
+			return nil, err
+		}
+		// validate target if not empty
+		err = validateScalingModifiersTarget(composite)
+		if err != nil {
+			err := errors.Join(fmt.Errorf("error validating target in ScalingModifiers"), err)
+			return nil, err
+		}
+		compiledFormulas[composite.MetricName()] = compiledFormula
 	}
-	return compiledFormula, nil
+	return compiledFormulas, nil
 }
 
-// validateScalingModifiersFormula helps validate the ScalingModifiers struct,
-// specifically the formula.
-func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
+// getScalingModifiersFormulaEnv returns the environment the formulas are compiled with,
+// consisting of dummy values for the named triggers, the runtime context and the history
+// functions, together with the names of all named triggers
+func getScalingModifiersFormulaEnv(so *ScaledObject) (FormulaEnv, []string, error) {
 	sm := so.Spec.Advanced.ScalingModifiers
 
-	// if formula is empty, nothing to validate
-	if sm.Formula == "" {
-		return nil, nil
-	}
-	// formula needs target because it's always transformed to composite-scaler
-	if sm.Target == "" {
-		return nil, fmt.Errorf("formula is given but target is empty")
-	}
-
 	// dummy value for compiled map of triggers
 	dummyValue := -1.0
 
+	if _, err := time.LoadLocation(sm.Timezone); err != nil {
+		return FormulaEnv{}, nil, fmt.Errorf("error loading timezone %q: %w", sm.Timezone, err)
+	}
+
 	// Compile & Run with dummy values to determine if all triggers in formula are
 	// defined (have names)
 	triggersMap := make(map[string]float64)
+	triggerNames := []string{}
 	for _, trig := range so.Spec.Triggers {
+		if trig.Name != "" {
+			if slices.Contains(FormulaContextVariables, trig.Name) || slices.Contains(FormulaHistoryFunctions, trig.Name) {
+				return FormulaEnv{}, nil, fmt.Errorf("trigger name %q is reserved in scalingModifiers formula", trig.Name)
+			}
+			triggerNames = append(triggerNames, trig.Name)
+		}
 		// if resource metrics are given, skip
 		if trig.Type == cpuString || trig.Type == memoryString {
 			continue
@@ -491,31 +686,67 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 			triggersMap[trig.Name] = dummyValue
 		}
 	}
-	compiled, err := expr.Compile(sm.Formula, expr.Env(triggersMap), expr.AsFloat64())
+	// dummy history functions check that the trigger is defined and the window is valid
+	dummyHistoryFunc := FormulaHistoryFunc(func(trigger string, window string) (float64, error) {
+		if _, isTrigger := triggersMap[trigger]; !isTrigger {
+			return 0, fmt.Errorf("trigger %q is not defined", trigger)
+		}
+		if _, err := ParseFormulaHistoryWindow(window); err != nil {
+			return 0, err
+		}
+		return dummyValue, nil
+	})
+	history := map[string]FormulaHistoryFunc{}
+	for _, function := range FormulaHistoryFunctions {
+		history[function] = dummyHistoryFunc
+	}
+	// dummy context with a single replica avoids division by zero
+	return NewFormulaEnv(triggersMap, NewFormulaContext(so, 1, time.Now()), history), triggerNames, nil
+}
+
+// validateScalingModifiersFormula helps validate the ScalingModifiers struct,
+// specifically the formula of the composite metric.
+func validateScalingModifiersFormula(composite CompositeMetric, env FormulaEnv, triggerNames []string) (*vm.Program, error) {
+	// formula needs target because it's always transformed to composite-scaler
+	if composite.Target == "" {
+		return nil, fmt.Errorf("formula is given but target is empty")
+	}
+
+	// only the triggers with a metric value are looked up, cpu and memory triggers remain undefined
+	metricTriggers := make([]string, 0, len(env.Triggers))
+	for trigger := range env.Triggers {
+		metricTriggers = append(metricTriggers, trigger)
+	}
+	contextChecker := &formulaContextChecker{triggers: triggerNames}
+	compiled, err := expr.Compile(composite.Formula, expr.Env(FormulaEnv{}), expr.AsFloat64(), expr.Patch(formulaHistoryPatcher{}),
+		expr.Patch(formulaTriggerPatcher{triggers: metricTriggers}), expr.Patch(contextChecker))
 	if err != nil {
 		return nil, err
 	}
-	_, err = expr.Run(compiled, triggersMap)
+	if len(contextChecker.unknown) > 0 {
+		return nil, fmt.Errorf("formula references undefined triggers: %s", strings.Join(contextChecker.unknown, ", "))
+	}
+	_, err = expr.Run(compiled, env)
 	if err != nil {
 		return nil, err
 	}
 	return compiled, nil
 }
 
-func validateScalingModifiersTarget(so *ScaledObject) error {
-	sm := so.Spec.Advanced.ScalingModifiers
-
-	if sm.Target == "" {
-		return nil
-	}
-
+func validateScalingModifiersTarget(composite CompositeMetric) error {
 	// convert string to float
-	num, err := strconv.ParseFloat(sm.Target, 64)
+	num, err := strconv.ParseFloat(composite.Target, 64)
 	if err != nil || num <= 0.0 {
 		return fmt.Errorf("error converting target for scalingModifiers (string->float) to valid target: %w", err)
 	}
 
-	if so.Spec.Advanced.ScalingModifiers.MetricType == autoscalingv2.UtilizationMetricType {
+	if composite.ActivationTarget != "" {
+		if _, err := strconv.ParseFloat(composite.ActivationTarget, 64); err != nil {
+			return fmt.Errorf("error converting activationTarget for scalingModifiers (string->float): %w", err)
+		}
+	}
+
+	if composite.MetricType == autoscalingv2.UtilizationMetricType {
 		err := fmt.Errorf("error trigger type is Utilization, but it needs to be AverageValue or Value for external metrics")
 		return err
 	}
@@ -591,3 +822,13 @@ func getHpaName(so ScaledObject) string {
 
 	return so.Spec.Advanced.HorizontalPodAutoscalerConfig.Name
 }
+
+// getHpaNames returns the name of the HPA of scaleTargetRef followed by the names of the HPAs of the additional targets
+func getHpaNames(so ScaledObject) []string {
+	hpaName := getHpaName(so)
+	hpaNames := []string{hpaName}
+	for _, target := range so.Spec.ScaleTargetRefs {
+		hpaNames = append(hpaNames, GetScaleTargetHPAName(hpaName, target.ScaleTarget))
+	}
+	return hpaNames
+}
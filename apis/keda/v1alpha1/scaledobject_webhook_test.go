@@ -176,6 +176,46 @@ var _ = It("shouldn't validate the so creation when the fallback is wrong", func
 	}).Should(HaveOccurred())
 })
 
+var _ = It("shouldn't validate the so creation when the trigger fallback is wrong", func() {
+	namespaceName := "wrong-trigger-fallback"
+	namespace := createNamespace(namespaceName)
+
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	so.Spec.Triggers[0].MetricType = "AverageValue"
+	so.Spec.Triggers[0].Fallback = &Fallback{
+		FailureThreshold: 3,
+		Replicas:         -3,
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("should validate the so creation when the fallback is configured and the trigger has a Value metric type", func() {
+	namespaceName := "right-fallback-value"
+	namespace := createNamespace(namespaceName)
+
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	so.Spec.Fallback = &Fallback{
+		FailureThreshold: 3,
+		Replicas:         6,
+	}
+	for index := range so.Spec.Triggers {
+		so.Spec.Triggers[index].MetricType = "Value"
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
 var _ = It("should validate the so creation When the fallback are configured and the scaler is either CPU or memory.", func() {
 	namespaceName := "right-fallback-cpu-memory"
 	namespace := createNamespace(namespaceName)
@@ -257,6 +297,29 @@ var _ = It("shouldn't validate the so creation when there is another so", func()
 	}).Should(HaveOccurred())
 })
 
+var _ = It("should validate the dry-run so creation when there is another so and an unmanaged hpa", func() {
+
+	so2Name := "test-so2"
+	namespaceName := "dry-run-managed-workload"
+	namespace := createNamespace(namespaceName)
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{DryRunAnnotation: "true"}, "")
+	so2 := createScaledObject(so2Name, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	hpa := createHpa("test-unmanaged-hpa", namespaceName, workloadName, "apps/v1", "Deployment", nil)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), so2)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), hpa)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
 var _ = It("shouldn't validate the so creation when there is another hpa with custom apis", func() {
 
 	hpaName := "test-custom-hpa"
@@ -532,6 +595,28 @@ var _ = It("should validate so creation when min replicas is > 0 with only cpu s
 
 })
 
+var _ = It("shouldn't validate so creation when a replicaCountSchedule sets min replicas to 0 with only cpu scaler given", func() {
+	namespaceName := "scale-to-zero-schedule-min-replicas-bad"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, true, false)
+
+	so := createScaledObjectSTZ(soName, namespaceName, workloadName, 1, 5, false)
+	so.Spec.ReplicaCountSchedules = []ReplicaCountSchedule{{
+		Name:            "night",
+		Start:           "0 22 * * *",
+		Duration:        metav1.Duration{Duration: 8 * time.Hour},
+		MinReplicaCount: ptr.To[int32](0),
+	}}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).Should(HaveOccurred())
+})
+
 var _ = It("should not validate ScaledObject creation when deployment only provides cpu resource limits", func() {
 
 	namespaceName := "only-cpu-resource-limits-set"
@@ -0,0 +1,391 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ScaledObjectTemplateKind is the kind of ScaledObjectTemplate
+	ScaledObjectTemplateKind = "ScaledObjectTemplate"
+	// ClusterScaledObjectTemplateKind is the kind of ClusterScaledObjectTemplate
+	ClusterScaledObjectTemplateKind = "ClusterScaledObjectTemplate"
+
+	TemplateParameterTypeString  = "string"
+	TemplateParameterTypeInteger = "integer"
+	TemplateParameterTypeBoolean = "boolean"
+
+	// WorkloadTemplateAnnotation names the template a ScaledObject is created from for an annotated Deployment or StatefulSet
+	WorkloadTemplateAnnotation = "autoscaling.keda.sh/template"
+	// WorkloadTemplateKindAnnotation is the kind of the template of a workload, ScaledObjectTemplate if not set
+	WorkloadTemplateKindAnnotation = "autoscaling.keda.sh/template-kind"
+	// WorkloadTemplateParameterAnnotationPrefix prefixes the annotations of a workload providing the values of the parameters
+	WorkloadTemplateParameterAnnotationPrefix = "autoscaling.keda.sh/param."
+)
+
+var (
+	templateParameterNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	templatePlaceholderRegexp   = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+)
+
+// +kubebuilder:object:root=true
+
+// ScaledObjectTemplate is a parameterized ScaledObject spec the ScaledObjects in its namespace can be rendered from
+// +kubebuilder:resource:path=scaledobjecttemplates,scope=Namespaced,shortName=sot
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ScaledObjectTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledObjectTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaledObjectTemplateList contains a list of ScaledObjectTemplate
+type ScaledObjectTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScaledObjectTemplate `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterScaledObjectTemplate is a parameterized ScaledObject spec the ScaledObjects in all the namespaces can be rendered from
+// +kubebuilder:resource:path=clusterscaledobjecttemplates,scope=Cluster,shortName=csot
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterScaledObjectTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledObjectTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterScaledObjectTemplateList contains a list of ClusterScaledObjectTemplate
+type ClusterScaledObjectTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterScaledObjectTemplate `json:"items"`
+}
+
+// ScaledObjectTemplateSpec defines the parameters and the partial ScaledObject spec of a template
+type ScaledObjectTemplateSpec struct {
+	// +optional
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+	// Template is a partial ScaledObject spec, the ${name} placeholders in its string values are replaced by the
+	// values of the parameters. A string value consisting of a single placeholder of an integer or boolean parameter
+	// is replaced by the typed value. The spec of the referencing ScaledObject is merged over the rendered template:
+	// its objects are merged field by field, its lists and values replace the ones of the template.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template runtime.RawExtension `json:"template"`
+}
+
+// TemplateParameter is a parameter of a ScaledObjectTemplate
+type TemplateParameter struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=string;integer;boolean
+	// +kubebuilder:default=string
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Default is the value of the parameter if the ScaledObject doesn't provide one, the parameter is required if not set
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// ScaledObjectTemplateRef references the ScaledObjectTemplate or ClusterScaledObjectTemplate a ScaledObject is rendered from
+type ScaledObjectTemplateRef struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=ScaledObjectTemplate;ClusterScaledObjectTemplate
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Parameters are the values of the parameters of the template
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaledObjectTemplate{}, &ScaledObjectTemplateList{}, &ClusterScaledObjectTemplate{}, &ClusterScaledObjectTemplateList{})
+}
+
+// GetKind returns the kind of the referenced template, ScaledObjectTemplate if not set
+func (r *ScaledObjectTemplateRef) GetKind() string {
+	if r.Kind == "" {
+		return ScaledObjectTemplateKind
+	}
+	return r.Kind
+}
+
+// References returns true if the ref references the template of the given kind, namespace and name
+// from a ScaledObject in the given namespace
+func (r *ScaledObjectTemplateRef) References(kind, templateNamespace, templateName, namespace string) bool {
+	if r.GetKind() != kind || r.Name != templateName {
+		return false
+	}
+	return kind == ClusterScaledObjectTemplateKind || templateNamespace == namespace
+}
+
+// GetWorkloadTemplateRef returns the template reference of a workload from its annotations, nil if the workload
+// isn't annotated with WorkloadTemplateAnnotation
+func GetWorkloadTemplateRef(annotations map[string]string) (*ScaledObjectTemplateRef, error) {
+	name := annotations[WorkloadTemplateAnnotation]
+	if name == "" {
+		return nil, nil
+	}
+	ref := &ScaledObjectTemplateRef{Name: name, Kind: annotations[WorkloadTemplateKindAnnotation]}
+	if ref.Kind != "" && ref.Kind != ScaledObjectTemplateKind && ref.Kind != ClusterScaledObjectTemplateKind {
+		return nil, fmt.Errorf("%s must be %s or %s, got %q", WorkloadTemplateKindAnnotation, ScaledObjectTemplateKind, ClusterScaledObjectTemplateKind, ref.Kind)
+	}
+	for key, value := range annotations {
+		if parameter, found := strings.CutPrefix(key, WorkloadTemplateParameterAnnotationPrefix); found {
+			if ref.Parameters == nil {
+				ref.Parameters = map[string]string{}
+			}
+			ref.Parameters[parameter] = value
+		}
+	}
+	return ref, nil
+}
+
+// GetScaledObjectTemplateSpec returns the spec of the template referenced by the ScaledObject
+func GetScaledObjectTemplateSpec(ctx context.Context, c client.Reader, so *ScaledObject) (*ScaledObjectTemplateSpec, error) {
+	ref := so.Spec.TemplateRef
+	switch ref.GetKind() {
+	case ClusterScaledObjectTemplateKind:
+		template := &ClusterScaledObjectTemplate{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, template); err != nil {
+			return nil, err
+		}
+		return &template.Spec, nil
+	default:
+		template := &ScaledObjectTemplate{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: so.Namespace}, template); err != nil {
+			return nil, err
+		}
+		return &template.Spec, nil
+	}
+}
+
+// ApplyRenderedSpec replaces the spec of a ScaledObject referencing a template by the spec rendered in its status
+func (so *ScaledObject) ApplyRenderedSpec() {
+	if so.Spec.TemplateRef != nil && so.Status.RenderedSpec != nil {
+		so.Spec = *so.Status.RenderedSpec.DeepCopy()
+	}
+}
+
+// Validate checks the parameters of the template and the placeholders used by the template
+func (s *ScaledObjectTemplateSpec) Validate() error {
+	declared := map[string]bool{}
+	for _, parameter := range s.Parameters {
+		if !templateParameterNameRegexp.MatchString(parameter.Name) {
+			return fmt.Errorf("parameter name %q is invalid, it must consist of letters, digits and underscores", parameter.Name)
+		}
+		if declared[parameter.Name] {
+			return fmt.Errorf("parameter %q is declared more than once", parameter.Name)
+		}
+		declared[parameter.Name] = true
+		switch parameter.getType() {
+		case TemplateParameterTypeString, TemplateParameterTypeInteger, TemplateParameterTypeBoolean:
+		default:
+			return fmt.Errorf("parameter %q has the unknown type %q", parameter.Name, parameter.Type)
+		}
+		if parameter.Default != nil {
+			if _, err := parameter.parse(*parameter.Default); err != nil {
+				return fmt.Errorf("default of parameter %q is invalid: %w", parameter.Name, err)
+			}
+		}
+	}
+
+	template, err := s.decodeTemplate()
+	if err != nil {
+		return err
+	}
+	if _, ok := template["templateRef"]; ok {
+		return fmt.Errorf("template can't reference another template")
+	}
+	for _, placeholder := range templatePlaceholders(template) {
+		if !declared[placeholder] {
+			return fmt.Errorf("placeholder ${%s} references the undeclared parameter %q", placeholder, placeholder)
+		}
+	}
+	return nil
+}
+
+// Render renders the effective spec of a ScaledObject referencing the template
+func (s *ScaledObjectTemplateSpec) Render(so *ScaledObject) (*ScaledObjectSpec, error) {
+	values, err := s.parameterValues(so.Spec.TemplateRef.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.decodeTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	overlayJSON, err := json.Marshal(so.Spec)
+	if err != nil {
+		return nil, err
+	}
+	var overlay map[string]any
+	if err := json.Unmarshal(overlayJSON, &overlay); err != nil {
+		return nil, err
+	}
+	merged := mergeTemplateValue(renderTemplateValue(template, values), overlay)
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(mergedJSON))
+	decoder.DisallowUnknownFields()
+	rendered := &ScaledObjectSpec{}
+	if err := decoder.Decode(rendered); err != nil {
+		return nil, fmt.Errorf("error decoding rendered spec: %w", err)
+	}
+	return rendered, nil
+}
+
+func (s *ScaledObjectTemplateSpec) decodeTemplate() (map[string]any, error) {
+	template := map[string]any{}
+	if len(s.Template.Raw) == 0 {
+		return template, nil
+	}
+	if err := json.Unmarshal(s.Template.Raw, &template); err != nil {
+		return nil, fmt.Errorf("error decoding template: %w", err)
+	}
+	return template, nil
+}
+
+// parameterValues returns the typed values of all the parameters of the template
+func (s *ScaledObjectTemplateSpec) parameterValues(provided map[string]string) (map[string]any, error) {
+	values := map[string]any{}
+	for _, parameter := range s.Parameters {
+		value, ok := provided[parameter.Name]
+		if !ok {
+			if parameter.Default == nil {
+				return nil, fmt.Errorf("required parameter %q isn't provided", parameter.Name)
+			}
+			value = *parameter.Default
+		}
+		parsed, err := parameter.parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("value of parameter %q is invalid: %w", parameter.Name, err)
+		}
+		values[parameter.Name] = parsed
+	}
+	for name := range provided {
+		if _, ok := values[name]; !ok {
+			return nil, fmt.Errorf("parameter %q isn't declared by the template", name)
+		}
+	}
+	return values, nil
+}
+
+func (p *TemplateParameter) getType() string {
+	if p.Type == "" {
+		return TemplateParameterTypeString
+	}
+	return p.Type
+}
+
+func (p *TemplateParameter) parse(value string) (any, error) {
+	switch p.getType() {
+	case TemplateParameterTypeString:
+		return value, nil
+	case TemplateParameterTypeInteger:
+		return strconv.ParseInt(value, 10, 64)
+	case TemplateParameterTypeBoolean:
+		return strconv.ParseBool(value)
+	default:
+		return nil, fmt.Errorf("unknown parameter type %q", p.Type)
+	}
+}
+
+// renderTemplateValue replaces the placeholders in all the string values of a decoded template
+func renderTemplateValue(value any, values map[string]any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = renderTemplateValue(item, values)
+		}
+		return typed
+	case []any:
+		for i, item := range typed {
+			typed[i] = renderTemplateValue(item, values)
+		}
+		return typed
+	case string:
+		if match := templatePlaceholderRegexp.FindStringSubmatch(typed); match != nil && match[0] == typed {
+			return values[match[1]]
+		}
+		return templatePlaceholderRegexp.ReplaceAllStringFunc(typed, func(placeholder string) string {
+			return fmt.Sprint(values[strings.TrimSuffix(strings.TrimPrefix(placeholder, "${"), "}")])
+		})
+	default:
+		return value
+	}
+}
+
+// mergeTemplateValue merges the overlay over the base, objects are merged field by field and null values are ignored
+func mergeTemplateValue(base, overlay any) any {
+	baseMap, baseIsMap := base.(map[string]any)
+	overlayMap, overlayIsMap := overlay.(map[string]any)
+	if !baseIsMap || !overlayIsMap {
+		return overlay
+	}
+	for key, value := range overlayMap {
+		if value == nil {
+			continue
+		}
+		baseMap[key] = mergeTemplateValue(baseMap[key], value)
+	}
+	return baseMap
+}
+
+// templatePlaceholders returns the parameter names of all the placeholders in a decoded template
+func templatePlaceholders(value any) []string {
+	var placeholders []string
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, item := range typed {
+			placeholders = append(placeholders, templatePlaceholders(item)...)
+		}
+	case []any:
+		for _, item := range typed {
+			placeholders = append(placeholders, templatePlaceholders(item)...)
+		}
+	case string:
+		for _, match := range templatePlaceholderRegexp.FindAllStringSubmatch(typed, -1) {
+			placeholders = append(placeholders, match[1])
+		}
+	}
+	return placeholders
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+const testScaledObjectTemplate = `{
+	"minReplicaCount": "${minReplicas}",
+	"maxReplicaCount": 10,
+	"advanced": {"restoreToOriginalReplicaCount": "${restore}"},
+	"triggers": [{
+		"type": "kafka",
+		"metadata": {"topic": "${topic}", "lagThreshold": "${lag}", "consumerGroup": "${topic}-consumers"}
+	}]
+}`
+
+func newTestScaledObjectTemplateSpec(template string) *ScaledObjectTemplateSpec {
+	return &ScaledObjectTemplateSpec{
+		Parameters: []TemplateParameter{
+			{Name: "topic"},
+			{Name: "lag", Default: ptr.To("50")},
+			{Name: "minReplicas", Type: TemplateParameterTypeInteger, Default: ptr.To("1")},
+			{Name: "restore", Type: TemplateParameterTypeBoolean, Default: ptr.To("false")},
+		},
+		Template: runtime.RawExtension{Raw: []byte(template)},
+	}
+}
+
+func TestScaledObjectTemplateRender(t *testing.T) {
+	so := &ScaledObject{
+		Spec: ScaledObjectSpec{
+			ScaleTargetRef:  &ScaleTarget{Name: "consumer"},
+			MaxReplicaCount: ptr.To[int32](20),
+			TemplateRef: &ScaledObjectTemplateRef{
+				Name:       "kafka",
+				Parameters: map[string]string{"topic": "orders", "minReplicas": "2", "restore": "true"},
+			},
+		},
+	}
+
+	rendered, err := newTestScaledObjectTemplateSpec(testScaledObjectTemplate).Render(so)
+	require.NoError(t, err)
+	assert.Equal(t, "consumer", rendered.ScaleTargetRef.Name)
+	assert.Equal(t, ptr.To[int32](2), rendered.MinReplicaCount)
+	// the fields of the ScaledObject take precedence over the template
+	assert.Equal(t, ptr.To[int32](20), rendered.MaxReplicaCount)
+	assert.True(t, rendered.Advanced.RestoreToOriginalReplicaCount)
+	assert.Equal(t, map[string]string{"topic": "orders", "lagThreshold": "50", "consumerGroup": "orders-consumers"}, rendered.Triggers[0].Metadata)
+	assert.Equal(t, so.Spec.TemplateRef, rendered.TemplateRef)
+}
+
+func TestScaledObjectTemplateRenderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   string
+		parameters map[string]string
+	}{
+		{"missing required parameter", testScaledObjectTemplate, map[string]string{}},
+		{"undeclared parameter", testScaledObjectTemplate, map[string]string{"topic": "orders", "partitions": "3"}},
+		{"invalid integer", testScaledObjectTemplate, map[string]string{"topic": "orders", "minReplicas": "two"}},
+		{"unknown field", `{"pollingIntervall": 30}`, map[string]string{"topic": "orders"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					ScaleTargetRef: &ScaleTarget{Name: "consumer"},
+					TemplateRef:    &ScaledObjectTemplateRef{Name: "kafka", Parameters: test.parameters},
+				},
+			}
+			_, err := newTestScaledObjectTemplateSpec(test.template).Render(so)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScaledObjectTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []TemplateParameter
+		template   string
+		isError    bool
+	}{
+		{"valid", nil, testScaledObjectTemplate, false},
+		{"undeclared placeholder", nil, `{"triggers": [{"type": "cron", "metadata": {"start": "${start}"}}]}`, true},
+		{"duplicate parameter", []TemplateParameter{{Name: "topic"}}, testScaledObjectTemplate, true},
+		{"invalid parameter name", []TemplateParameter{{Name: "queue-name"}}, testScaledObjectTemplate, true},
+		{"invalid default", []TemplateParameter{{Name: "replicas", Type: TemplateParameterTypeInteger, Default: ptr.To("many")}}, testScaledObjectTemplate, true},
+		{"nested template", nil, `{"templateRef": {"name": "base"}}`, true},
+		{"not an object", nil, `[]`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := newTestScaledObjectTemplateSpec(test.template)
+			spec.Parameters = append(spec.Parameters, test.parameters...)
+			err := spec.Validate()
+			if test.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScaledObjectTemplateRefReferences(t *testing.T) {
+	ref := &ScaledObjectTemplateRef{Name: "kafka"}
+	assert.True(t, ref.References(ScaledObjectTemplateKind, "default", "kafka", "default"))
+	assert.False(t, ref.References(ScaledObjectTemplateKind, "other", "kafka", "default"))
+	assert.False(t, ref.References(ClusterScaledObjectTemplateKind, "", "kafka", "default"))
+
+	ref.Kind = ClusterScaledObjectTemplateKind
+	assert.True(t, ref.References(ClusterScaledObjectTemplateKind, "", "kafka", "default"))
+}
+
+func TestGetWorkloadTemplateRef(t *testing.T) {
+	ref, err := GetWorkloadTemplateRef(map[string]string{"team": "orders"})
+	require.NoError(t, err)
+	assert.Nil(t, ref)
+
+	ref, err = GetWorkloadTemplateRef(map[string]string{
+		WorkloadTemplateAnnotation:                          "kafka",
+		WorkloadTemplateKindAnnotation:                      ClusterScaledObjectTemplateKind,
+		WorkloadTemplateParameterAnnotationPrefix + "topic": "orders",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &ScaledObjectTemplateRef{Name: "kafka", Kind: ClusterScaledObjectTemplateKind, Parameters: map[string]string{"topic": "orders"}}, ref)
+
+	_, err = GetWorkloadTemplateRef(map[string]string{WorkloadTemplateAnnotation: "kafka", WorkloadTemplateKindAnnotation: "Template"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var scaledobjecttemplatelog = logf.Log.WithName("scaledobjecttemplate-validation-webhook")
+
+func (sot *ScaledObjectTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(sot).
+		Complete()
+}
+
+func (csot *ClusterScaledObjectTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(csot).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-scaledobjecttemplate,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledobjecttemplates,verbs=create;update,versions=v1alpha1,name=vscaledobjecttemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ScaledObjectTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (sot *ScaledObjectTemplate) ValidateCreate() (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(sot, "", "  ")
+	scaledobjecttemplatelog.V(1).Info(fmt.Sprintf("validating scaledobjecttemplate creation for %s", string(val)))
+	return validateScaledObjectTemplate(ScaledObjectTemplateKind, sot.Namespace, sot.Name, &sot.Spec)
+}
+
+func (sot *ScaledObjectTemplate) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(sot, "", "  ")
+	scaledobjecttemplatelog.V(1).Info(fmt.Sprintf("validating scaledobjecttemplate update for %s", string(val)))
+	return validateScaledObjectTemplate(ScaledObjectTemplateKind, sot.Namespace, sot.Name, &sot.Spec)
+}
+
+func (sot *ScaledObjectTemplate) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-clusterscaledobjecttemplate,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=clusterscaledobjecttemplates,verbs=create;update,versions=v1alpha1,name=vclusterscaledobjecttemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterScaledObjectTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (csot *ClusterScaledObjectTemplate) ValidateCreate() (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(csot, "", "  ")
+	scaledobjecttemplatelog.V(1).Info(fmt.Sprintf("validating clusterscaledobjecttemplate creation for %s", string(val)))
+	return validateScaledObjectTemplate(ClusterScaledObjectTemplateKind, "", csot.Name, &csot.Spec)
+}
+
+func (csot *ClusterScaledObjectTemplate) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(csot, "", "  ")
+	scaledobjecttemplatelog.V(1).Info(fmt.Sprintf("validating clusterscaledobjecttemplate update for %s", string(val)))
+	return validateScaledObjectTemplate(ClusterScaledObjectTemplateKind, "", csot.Name, &csot.Spec)
+}
+
+func (csot *ClusterScaledObjectTemplate) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateScaledObjectTemplate validates the template and the specs it renders for the ScaledObjects referencing it,
+// so that an update of the template isn't rolled out when it breaks any of them
+func validateScaledObjectTemplate(kind, namespace, name string, spec *ScaledObjectTemplateSpec) (admission.Warnings, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	soList := &ScaledObjectList{}
+	if err := kc.List(context.Background(), soList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, so := range soList.Items {
+		if so.Spec.TemplateRef == nil || !so.Spec.TemplateRef.References(kind, namespace, name, so.Namespace) {
+			continue
+		}
+		renderedSpec, err := spec.Render(&so)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering ScaledObject %s/%s: %w", so.Namespace, so.Name, err)
+		}
+		rendered := so.DeepCopy()
+		rendered.Spec = *renderedSpec
+		if _, err := validateRenderedWorkload(rendered, "update", false); err != nil {
+			return nil, fmt.Errorf("ScaledObject %s/%s rendered from the template is invalid: %w", so.Namespace, so.Name, err)
+		}
+	}
+	return nil, nil
+}
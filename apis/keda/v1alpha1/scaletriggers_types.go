@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -37,6 +38,141 @@ type ScaleTriggers struct {
 	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// Fallback overrides the ScaledObject fallback for this trigger, it is ignored by ScaledJobs
+	// +optional
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Hysteresis keeps an active trigger active until it drops below a deactivation threshold or stays inactive
+	// for a number of consecutive polls
+	// +optional
+	Hysteresis *TriggerHysteresis `json:"hysteresis,omitempty"`
+	// Transform smooths and transforms the metric values of the trigger before they are used for scaling
+	// +optional
+	Transform *MetricTransform `json:"transform,omitempty"`
+	// Condition enables the trigger only while its expression evaluates to true
+	// +optional
+	Condition *TriggerCondition `json:"condition,omitempty"`
+}
+
+// TriggerHysteresis prevents a trigger hovering around its activation threshold from flapping the target
+// between active and inactive. Once active, the trigger is reported inactive only when all the conditions are met.
+type TriggerHysteresis struct {
+	// DeactivationThreshold is the metric value the trigger has to drop to or below to become inactive,
+	// it is meant to be lower than the activation threshold of the scaler
+	// +optional
+	DeactivationThreshold string `json:"deactivationThreshold,omitempty"`
+	// InactivePolls is the number of consecutive polls the trigger has to be inactive to become inactive
+	// +optional
+	InactivePolls *int32 `json:"inactivePolls,omitempty"`
+}
+
+// GetDeactivationThreshold returns the parsed deactivation threshold and whether it is set
+func (h *TriggerHysteresis) GetDeactivationThreshold() (float64, bool) {
+	if h == nil || h.DeactivationThreshold == "" {
+		return 0, false
+	}
+	threshold, err := strconv.ParseFloat(h.DeactivationThreshold, 64)
+	if err != nil {
+		return 0, false
+	}
+	return threshold, true
+}
+
+// GetInactivePolls returns the number of consecutive inactive polls needed to deactivate the trigger, at least 1
+func (h *TriggerHysteresis) GetInactivePolls() int32 {
+	if h == nil || h.InactivePolls == nil || *h.InactivePolls < 1 {
+		return 1
+	}
+	return *h.InactivePolls
+}
+
+// DefaultMetricTransformWindow is the default number of the last values of a trigger metric
+// considered by the median and the outlier detection
+const DefaultMetricTransformWindow = 5
+
+// MetricTransform defines the smoothing and transformation of the values of a trigger metric, it is applied
+// to every value returned by the scaler in this order: outliers are dropped, the median of the window is taken,
+// the exponential moving average is applied, then the value is scaled, offset and clamped to min and max.
+// The activation of the trigger is evaluated by the scaler on the original value, it is not re-evaluated on the
+// transformed value.
+type MetricTransform struct {
+	// Window is the number of the last values considered by the median and the outlier detection, defaults to 5
+	// +optional
+	Window *int32 `json:"window,omitempty"`
+	// Median replaces the value by the median of the last values of the window
+	// +optional
+	Median bool `json:"median,omitempty"`
+	// OutlierThreshold drops a value which deviates from the median of the window by more than this number of
+	// median absolute deviations, the last value which was not dropped is used instead
+	// +optional
+	OutlierThreshold string `json:"outlierThreshold,omitempty"`
+	// EMA is the smoothing factor of the exponential moving average in (0, 1], a lower factor smooths more
+	// +optional
+	EMA string `json:"ema,omitempty"`
+	// Scale multiplies the value
+	// +optional
+	Scale string `json:"scale,omitempty"`
+	// Offset is added to the scaled value
+	// +optional
+	Offset string `json:"offset,omitempty"`
+	// Min is the lowest value
+	// +optional
+	Min string `json:"min,omitempty"`
+	// Max is the highest value
+	// +optional
+	Max string `json:"max,omitempty"`
+}
+
+// GetWindow returns the number of the last values considered by the median and the outlier detection
+func (t *MetricTransform) GetWindow() int {
+	if t == nil || t.Window == nil || *t.Window < 1 {
+		return DefaultMetricTransformWindow
+	}
+	return int(*t.Window)
+}
+
+// GetOutlierThreshold returns the parsed outlier threshold and whether it is set
+func (t *MetricTransform) GetOutlierThreshold() (float64, bool) {
+	return parseTransformValue(t, func(t *MetricTransform) string { return t.OutlierThreshold })
+}
+
+// GetEMA returns the parsed smoothing factor of the exponential moving average and whether it is set
+func (t *MetricTransform) GetEMA() (float64, bool) {
+	return parseTransformValue(t, func(t *MetricTransform) string { return t.EMA })
+}
+
+// GetScale returns the parsed scale, 1 if not set
+func (t *MetricTransform) GetScale() float64 {
+	if scale, ok := parseTransformValue(t, func(t *MetricTransform) string { return t.Scale }); ok {
+		return scale
+	}
+	return 1
+}
+
+// GetOffset returns the parsed offset, 0 if not set
+func (t *MetricTransform) GetOffset() float64 {
+	offset, _ := parseTransformValue(t, func(t *MetricTransform) string { return t.Offset })
+	return offset
+}
+
+// GetMin returns the parsed lowest value and whether it is set
+func (t *MetricTransform) GetMin() (float64, bool) {
+	return parseTransformValue(t, func(t *MetricTransform) string { return t.Min })
+}
+
+// GetMax returns the parsed highest value and whether it is set
+func (t *MetricTransform) GetMax() (float64, bool) {
+	return parseTransformValue(t, func(t *MetricTransform) string { return t.Max })
+}
+
+func parseTransformValue(t *MetricTransform, field func(*MetricTransform) string) (float64, bool) {
+	if t == nil || field(t) == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(field(t), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
 }
 
 // AuthenticationRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
@@ -51,6 +187,8 @@ type AuthenticationRef struct {
 // ValidateTriggers checks that general trigger metadata are valid, it checks:
 // - triggerNames in ScaledObject are unique
 // - useCachedMetrics is defined only for a supported triggers
+// - hysteresis is valid
+// - transform is valid
 func ValidateTriggers(triggers []ScaleTriggers) error {
 	triggersCount := len(triggers)
 
@@ -69,6 +207,14 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 			}
 
+			if err := validateTriggerHysteresis(trigger); err != nil {
+				return err
+			}
+
+			if err := validateTriggerTransform(trigger); err != nil {
+				return err
+			}
+
 			name := trigger.Name
 			if name != "" {
 				if _, found := triggerNames[name]; found {
@@ -80,6 +226,68 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 		}
 	}
 
+	if _, err := CompileTriggerConditions(triggers); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateTriggerHysteresis(trigger ScaleTriggers) error {
+	if trigger.Hysteresis == nil {
+		return nil
+	}
+	if trigger.Type == "cpu" || trigger.Type == "memory" {
+		return fmt.Errorf("property \"hysteresis\" is not supported for %q scaler", trigger.Type)
+	}
+	if trigger.Hysteresis.DeactivationThreshold != "" {
+		if _, err := strconv.ParseFloat(trigger.Hysteresis.DeactivationThreshold, 64); err != nil {
+			return fmt.Errorf("error parsing hysteresis.deactivationThreshold of trigger %q: %w", trigger.Type, err)
+		}
+	}
+	if trigger.Hysteresis.InactivePolls != nil && *trigger.Hysteresis.InactivePolls < 1 {
+		return fmt.Errorf("hysteresis.inactivePolls of trigger %q must be at least 1", trigger.Type)
+	}
+	return nil
+}
+
+func validateTriggerTransform(trigger ScaleTriggers) error {
+	transform := trigger.Transform
+	if transform == nil {
+		return nil
+	}
+	if trigger.Type == "cpu" || trigger.Type == "memory" {
+		return fmt.Errorf("property \"transform\" is not supported for %q scaler", trigger.Type)
+	}
+	if transform.Window != nil && *transform.Window < 1 {
+		return fmt.Errorf("transform.window of trigger %q must be at least 1", trigger.Type)
+	}
+	for _, field := range []struct{ name, value string }{
+		{"outlierThreshold", transform.OutlierThreshold},
+		{"ema", transform.EMA},
+		{"scale", transform.Scale},
+		{"offset", transform.Offset},
+		{"min", transform.Min},
+		{"max", transform.Max},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(field.value, 64); err != nil {
+			return fmt.Errorf("error parsing transform.%s of trigger %q: %w", field.name, trigger.Type, err)
+		}
+	}
+	if threshold, ok := transform.GetOutlierThreshold(); ok && threshold <= 0 {
+		return fmt.Errorf("transform.outlierThreshold of trigger %q must be greater than 0", trigger.Type)
+	}
+	if ema, ok := transform.GetEMA(); ok && (ema <= 0 || ema > 1) {
+		return fmt.Errorf("transform.ema of trigger %q must be in (0, 1]", trigger.Type)
+	}
+	minValue, minOk := transform.GetMin()
+	maxValue, maxOk := transform.GetMax()
+	if minOk && maxOk && minValue > maxValue {
+		return fmt.Errorf("transform.min of trigger %q must not be greater than transform.max", trigger.Type)
+	}
 	return nil
 }
 
@@ -26,6 +26,106 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "valid hysteresis",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "prometheus",
+					Hysteresis: &TriggerHysteresis{DeactivationThreshold: "2.5", InactivePolls: int32Ptr(3)},
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "invalid hysteresis deactivationThreshold",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "prometheus",
+					Hysteresis: &TriggerHysteresis{DeactivationThreshold: "two"},
+				},
+			},
+			expectedErrMsg: "error parsing hysteresis.deactivationThreshold of trigger \"prometheus\": strconv.ParseFloat: parsing \"two\": invalid syntax",
+		},
+		{
+			name: "invalid hysteresis inactivePolls",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "prometheus",
+					Hysteresis: &TriggerHysteresis{InactivePolls: int32Ptr(0)},
+				},
+			},
+			expectedErrMsg: "hysteresis.inactivePolls of trigger \"prometheus\" must be at least 1",
+		},
+		{
+			name: "unsupported hysteresis for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "cpu",
+					Hysteresis: &TriggerHysteresis{InactivePolls: int32Ptr(3)},
+				},
+			},
+			expectedErrMsg: "property \"hysteresis\" is not supported for \"cpu\" scaler",
+		},
+		{
+			name: "valid transform",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "prometheus",
+					Transform: &MetricTransform{Window: int32Ptr(10), Median: true, OutlierThreshold: "3", EMA: "0.3", Scale: "0.5", Offset: "-1", Min: "0", Max: "100"},
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "invalid transform scale",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "prometheus",
+					Transform: &MetricTransform{Scale: "half"},
+				},
+			},
+			expectedErrMsg: "error parsing transform.scale of trigger \"prometheus\": strconv.ParseFloat: parsing \"half\": invalid syntax",
+		},
+		{
+			name: "invalid transform ema",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "prometheus",
+					Transform: &MetricTransform{EMA: "1.5"},
+				},
+			},
+			expectedErrMsg: "transform.ema of trigger \"prometheus\" must be in (0, 1]",
+		},
+		{
+			name: "invalid transform window",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "prometheus",
+					Transform: &MetricTransform{Window: int32Ptr(0)},
+				},
+			},
+			expectedErrMsg: "transform.window of trigger \"prometheus\" must be at least 1",
+		},
+		{
+			name: "invalid transform min greater than max",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "prometheus",
+					Transform: &MetricTransform{Min: "10", Max: "5"},
+				},
+			},
+			expectedErrMsg: "transform.min of trigger \"prometheus\" must not be greater than transform.max",
+		},
+		{
+			name: "unsupported transform for memory scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:      "memory",
+					Transform: &MetricTransform{Median: true},
+				},
+			},
+			expectedErrMsg: "property \"transform\" is not supported for \"memory\" scaler",
+		},
 		{
 			name: "duplicate trigger names",
 			triggers: []ScaleTriggers{
@@ -0,0 +1,412 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ScalingBudgetPriorityAnnotation sets the priority of a ScaledObject within its ScalingBudgets, ScaledObjects
+	// with a lower priority are clamped first when the budget runs out. The default priority is 0.
+	ScalingBudgetPriorityAnnotation = "autoscaling.keda.sh/scaling-budget-priority"
+
+	defaultScalingBudgetPollingInterval = 30
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ScalingBudget caps the sum of replicas, or of the pod resource requests, of the selected ScaledObjects in its namespace
+// +kubebuilder:resource:path=scalingbudgets,scope=Namespaced,shortName=sb
+// +kubebuilder:printcolumn:name="MaxReplicas",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ScalingBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingBudgetSpec `json:"spec"`
+	// +optional
+	Status ScalingBudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScalingBudgetList contains a list of ScalingBudget
+type ScalingBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScalingBudget `json:"items"`
+}
+
+// ScalingBudgetSpec defines which ScaledObjects share the budget and how large it is
+type ScalingBudgetSpec struct {
+	// Selector selects the ScaledObjects sharing the budget by their labels, all of them are selected if not set
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// MaxReplicas caps the sum of replicas of the scale targets of the selected ScaledObjects
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// MaxCPU caps the sum of CPU requests of the pods of the scale targets of the selected ScaledObjects
+	// +optional
+	MaxCPU *resource.Quantity `json:"maxCPU,omitempty"`
+	// MaxMemory caps the sum of memory requests of the pods of the scale targets of the selected ScaledObjects
+	// +optional
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+	// PollingInterval is the interval in seconds the budget is allocated in, 30 by default
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+}
+
+// ScalingBudgetStatus is the last allocation of the budget
+type ScalingBudgetStatus struct {
+	// Replicas is the sum of replicas of the scale targets of the members, including the additional targets of scaleTargetRefs
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// CPU is the sum of CPU requests of the pods of the members
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// Memory is the sum of memory requests of the pods of the members
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// Members are the ScaledObjects sharing the budget, ordered by priority
+	// +optional
+	Members []ScalingBudgetMember `json:"members,omitempty"`
+}
+
+// ScalingBudgetMember is the allocation of a ScaledObject sharing a budget
+type ScalingBudgetMember struct {
+	Name string `json:"name"`
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Replicas is the current replica count of scaleTargetRef
+	Replicas int32 `json:"replicas"`
+	// MaxReplicas is the replica count scaleTargetRef may scale up to within the budget, the additional targets
+	// of scaleTargetRefs are scaled up to their share of it by their weight
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+// ScalingBudgetAllocation identifies the ScalingBudget limiting the replicas of a ScaledObject
+type ScalingBudgetAllocation struct {
+	Name        string `json:"name"`
+	MaxReplicas int32  `json:"maxReplicas"`
+}
+
+// ScalingBudgetUsage is the usage of a ScaledObject the budget is allocated from
+type ScalingBudgetUsage struct {
+	Name        string
+	Priority    int32
+	Replicas    int32
+	MinReplicas int32
+	MaxReplicas int32
+	// PodRequests are the resource requests of a single pod of the scale target
+	PodRequests corev1.ResourceList
+	// Targets are the usages of the additional targets of scaleTargetRefs
+	Targets []ScalingBudgetTargetUsage
+}
+
+// ScalingBudgetTargetUsage is the usage of an additional target of scaleTargetRefs, which is scaled with
+// the replicas of scaleTargetRef adjusted by its weight
+type ScalingBudgetTargetUsage struct {
+	Weight   float64
+	Replicas int32
+	// PodRequests are the resource requests of a single pod of the target
+	PodRequests corev1.ResourceList
+}
+
+// NewScalingBudgetUsage returns the usage of the ScaledObject with the given replicas and pod resource requests,
+// it is bounded by the replica counts of the ScaledObject (including the active replicaCountSchedule)
+func NewScalingBudgetUsage(so *ScaledObject, replicas int32, podRequests corev1.ResourceList, targets []ScalingBudgetTargetUsage) ScalingBudgetUsage {
+	return ScalingBudgetUsage{
+		Name:        so.Name,
+		Priority:    so.GetScalingBudgetPriority(),
+		Replicas:    replicas,
+		MinReplicas: *getHPAMinReplicas(so.GetMinReplicaCount()),
+		MaxReplicas: getHPAMaxReplicas(so.GetMaxReplicaCount()),
+		PodRequests: podRequests,
+		Targets:     targets,
+	}
+}
+
+// GetScalingBudgetPriority returns the priority of the ScaledObject within its ScalingBudgets set by
+// ScalingBudgetPriorityAnnotation, an invalid priority is ignored
+func (so *ScaledObject) GetScalingBudgetPriority() int32 {
+	priority, err := strconv.ParseInt(so.GetAnnotations()[ScalingBudgetPriorityAnnotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(priority)
+}
+
+// GetPollingInterval returns the interval the budget is allocated in
+func (s *ScalingBudgetSpec) GetPollingInterval() int32 {
+	if s.PollingInterval != nil {
+		return *s.PollingInterval
+	}
+	return defaultScalingBudgetPollingInterval
+}
+
+// Validate checks that the budget can be allocated
+func (s *ScalingBudgetSpec) Validate() error {
+	if s.MaxReplicas == nil && s.MaxCPU == nil && s.MaxMemory == nil {
+		return fmt.Errorf("at least one of maxReplicas, maxCPU or maxMemory of scaling budget must be set")
+	}
+	if s.MaxReplicas != nil && *s.MaxReplicas < 0 {
+		return fmt.Errorf("maxReplicas of scaling budget must not be negative")
+	}
+	if s.MaxCPU != nil && s.MaxCPU.Sign() < 0 {
+		return fmt.Errorf("maxCPU of scaling budget must not be negative")
+	}
+	if s.MaxMemory != nil && s.MaxMemory.Sign() < 0 {
+		return fmt.Errorf("maxMemory of scaling budget must not be negative")
+	}
+	if s.PollingInterval != nil && *s.PollingInterval <= 0 {
+		return fmt.Errorf("pollingInterval of scaling budget must be greater than 0")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s.Selector); err != nil {
+		return fmt.Errorf("error parsing selector of scaling budget: %w", err)
+	}
+	return nil
+}
+
+// scalingBudgetLimit is a limit of the budget in the unit of its resource, together with the
+// amount of the resource a pod with the given requests needs
+type scalingBudgetLimit struct {
+	limit  int64
+	perPod func(podRequests corev1.ResourceList) int64
+}
+
+// limits returns the limits set in the budget
+func (s *ScalingBudgetSpec) limits() []scalingBudgetLimit {
+	var limits []scalingBudgetLimit
+	if s.MaxReplicas != nil {
+		limits = append(limits, scalingBudgetLimit{
+			limit:  int64(*s.MaxReplicas),
+			perPod: func(corev1.ResourceList) int64 { return 1 },
+		})
+	}
+	if s.MaxCPU != nil {
+		limits = append(limits, scalingBudgetLimit{
+			limit:  s.MaxCPU.MilliValue(),
+			perPod: func(podRequests corev1.ResourceList) int64 { return podRequests.Cpu().MilliValue() },
+		})
+	}
+	if s.MaxMemory != nil {
+		limits = append(limits, scalingBudgetLimit{
+			limit:  s.MaxMemory.Value(),
+			perPod: func(podRequests corev1.ResourceList) int64 { return podRequests.Memory().Value() },
+		})
+	}
+	return limits
+}
+
+// current returns the amount of the resource of the limit the member uses now
+func (l *scalingBudgetLimit) current(usage *ScalingBudgetUsage) int64 {
+	amount := int64(usage.Replicas) * l.perPod(usage.PodRequests)
+	for _, target := range usage.Targets {
+		amount += int64(target.Replicas) * l.perPod(target.PodRequests)
+	}
+	return amount
+}
+
+// at returns the amount of the resource of the limit the member uses with the given replicas of scaleTargetRef
+func (l *scalingBudgetLimit) at(usage *ScalingBudgetUsage, replicas int32) int64 {
+	amount := int64(replicas) * l.perPod(usage.PodRequests)
+	for _, target := range usage.Targets {
+		amount += int64(WeightedReplicaCount(replicas, target.Weight)) * l.perPod(target.PodRequests)
+	}
+	return amount
+}
+
+// maxReplicasWithin returns the most replicas of scaleTargetRef, up to its MaxReplicas, the member can
+// run with the given amount of the resource of the limit
+func (l *scalingBudgetLimit) maxReplicasWithin(usage *ScalingBudgetUsage, amount int64) int32 {
+	// the amount used grows with the replicas, so the most replicas within it are searched for
+	return int32(sort.Search(int(usage.MaxReplicas)+1, func(replicas int) bool {
+		return l.at(usage, int32(replicas)) > amount
+	})) - 1
+}
+
+// Allocate returns the status of the budget shared by the members with the given usages. The members with the same priority
+// share what is left of every limit after the current usage of the members with the same or a higher priority, members with
+// a lower priority are counted only with their minimum replicas as they are clamped to make room. A member that needs less
+// than its share to reach its MaxReplicas leaves the rest to the others. A member is never clamped below its minimum replicas,
+// so the budget is exceeded if the minimums don't fit in it.
+func (s *ScalingBudgetSpec) Allocate(usages []ScalingBudgetUsage) ScalingBudgetStatus {
+	sorted := make([]ScalingBudgetUsage, len(usages))
+	copy(sorted, usages)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	maxReplicas := make([]int32, len(sorted))
+	for i := range sorted {
+		maxReplicas[i] = sorted[i].MaxReplicas
+	}
+	for _, limit := range s.limits() {
+		for start := 0; start < len(sorted); {
+			end := start
+			for end < len(sorted) && sorted[end].Priority == sorted[start].Priority {
+				end++
+			}
+			headroom := limit.limit
+			for j := range sorted {
+				used := limit.current(&sorted[j])
+				if j >= end {
+					used = min(used, limit.at(&sorted[j], sorted[j].MinReplicas))
+				}
+				headroom -= used
+			}
+			for i, share := range shareHeadroom(&limit, sorted[start:end], headroom) {
+				member := &sorted[start+i]
+				if limit.at(member, member.MaxReplicas) <= 0 {
+					// the member doesn't use the resource of the limit
+					continue
+				}
+				maxReplicas[start+i] = min(maxReplicas[start+i], limit.maxReplicasWithin(member, limit.current(member)+share))
+			}
+			start = end
+		}
+	}
+
+	status := ScalingBudgetStatus{Members: make([]ScalingBudgetMember, 0, len(sorted))}
+	cpu, memory := resource.NewMilliQuantity(0, resource.DecimalSI), resource.NewQuantity(0, resource.BinarySI)
+	addUsage := func(replicas int32, podRequests corev1.ResourceList) {
+		status.Replicas += replicas
+		cpu.Add(*resource.NewMilliQuantity(int64(replicas)*podRequests.Cpu().MilliValue(), resource.DecimalSI))
+		memory.Add(*resource.NewQuantity(int64(replicas)*podRequests.Memory().Value(), resource.BinarySI))
+	}
+	for i := range sorted {
+		member := &sorted[i]
+		addUsage(member.Replicas, member.PodRequests)
+		for _, target := range member.Targets {
+			addUsage(target.Replicas, target.PodRequests)
+		}
+		status.Members = append(status.Members, ScalingBudgetMember{
+			Name:        member.Name,
+			Priority:    member.Priority,
+			Replicas:    member.Replicas,
+			MaxReplicas: max(maxReplicas[i], member.MinReplicas),
+		})
+	}
+	if s.MaxCPU != nil {
+		status.CPU = cpu
+	}
+	if s.MaxMemory != nil {
+		status.Memory = memory
+	}
+	return status
+}
+
+// shareHeadroom splits the headroom of the limit among the members with the same priority, so together they don't take
+// more than is left. A member gets at most what it needs to reach its MaxReplicas, the rest is split among the others,
+// an exceeded limit is taken back from all of them evenly.
+func shareHeadroom(limit *scalingBudgetLimit, members []ScalingBudgetUsage, headroom int64) []int64 {
+	shares := make([]int64, len(members))
+	if headroom <= 0 {
+		for i := range members {
+			shares[i] = floorDiv(headroom, int64(len(members)))
+		}
+		return shares
+	}
+
+	needs := make([]int64, len(members))
+	order := make([]int, len(members))
+	for i := range members {
+		needs[i] = max(0, limit.at(&members[i], members[i].MaxReplicas)-limit.current(&members[i]))
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return needs[order[a]] < needs[order[b]] })
+	for n, i := range order {
+		shares[i] = min(needs[i], headroom/int64(len(members)-n))
+		headroom -= shares[i]
+	}
+	return shares
+}
+
+// floorDiv divides rounding towards negative infinity, so an exceeded limit never allows a replica
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// GetMemberMaxReplicas returns the replica count the member with the given name may scale up to,
+// the second return value is false if the ScaledObject isn't a member of the budget
+func (s *ScalingBudgetStatus) GetMemberMaxReplicas(name string) (int32, bool) {
+	for _, member := range s.Members {
+		if member.Name == name {
+			return member.MaxReplicas, true
+		}
+	}
+	return 0, false
+}
+
+// GetScalingBudgetAllocation returns the allocation of the ScalingBudget which limits the replicas of the ScaledObject
+// the most, ScalingBudgets with the same limit are ordered by name
+func GetScalingBudgetAllocation(ctx context.Context, c client.Reader, so *ScaledObject) (*ScalingBudgetAllocation, error) {
+	budgets := &ScalingBudgetList{}
+	if err := c.List(ctx, budgets, client.InNamespace(so.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing ScalingBudgets: %w", err)
+	}
+	sort.Slice(budgets.Items, func(i, j int) bool { return budgets.Items[i].Name < budgets.Items[j].Name })
+
+	var allocation *ScalingBudgetAllocation
+	for i := range budgets.Items {
+		budget := &budgets.Items[i]
+		// the status could list the ScaledObject before the budget is allocated again after its labels changed
+		if !budget.Spec.Selects(so.Labels) {
+			continue
+		}
+		maxReplicas, member := budget.Status.GetMemberMaxReplicas(so.Name)
+		if member && (allocation == nil || maxReplicas < allocation.MaxReplicas) {
+			allocation = &ScalingBudgetAllocation{Name: budget.Name, MaxReplicas: maxReplicas}
+		}
+	}
+	return allocation, nil
+}
+
+// Selects returns true if the selector of the budget matches the labels of a ScaledObject
+func (s *ScalingBudgetSpec) Selects(objectLabels map[string]string) bool {
+	if s.Selector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objectLabels))
+}
+
+func init() {
+	SchemeBuilder.Register(&ScalingBudget{}, &ScalingBudgetList{})
+}
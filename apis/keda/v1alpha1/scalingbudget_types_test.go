@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestScalingBudgetAllocate(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+
+	tests := []struct {
+		name            string
+		spec            ScalingBudgetSpec
+		usages          []ScalingBudgetUsage
+		expectedMembers []ScalingBudgetMember
+	}{
+		{
+			name: "lower priority is clamped first",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10)},
+			usages: []ScalingBudgetUsage{
+				{Name: "batch", Priority: 0, Replicas: 5, MinReplicas: 1, MaxReplicas: 20},
+				{Name: "api", Priority: 10, Replicas: 5, MinReplicas: 1, MaxReplicas: 20},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "api", Priority: 10, Replicas: 5, MaxReplicas: 9},
+				{Name: "batch", Priority: 0, Replicas: 5, MaxReplicas: 5},
+			},
+		},
+		{
+			name: "same priority shares what is left",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](6)},
+			usages: []ScalingBudgetUsage{
+				{Name: "a", Replicas: 3, MinReplicas: 1, MaxReplicas: 5},
+				{Name: "b", Replicas: 4, MinReplicas: 2, MaxReplicas: 20},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "a", Replicas: 3, MaxReplicas: 2},
+				{Name: "b", Replicas: 4, MaxReplicas: 3},
+			},
+		},
+		{
+			name: "same priority splits the headroom",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10)},
+			usages: []ScalingBudgetUsage{
+				{Name: "a", Replicas: 4, MinReplicas: 1, MaxReplicas: 20},
+				{Name: "b", Replicas: 4, MinReplicas: 1, MaxReplicas: 20},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "a", Replicas: 4, MaxReplicas: 5},
+				{Name: "b", Replicas: 4, MaxReplicas: 5},
+			},
+		},
+		{
+			name: "headroom not needed by a member is left to the others",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10)},
+			usages: []ScalingBudgetUsage{
+				{Name: "a", Replicas: 2, MinReplicas: 1, MaxReplicas: 3},
+				{Name: "b", Replicas: 2, MinReplicas: 1, MaxReplicas: 20},
+				{Name: "c", Replicas: 2, MinReplicas: 1, MaxReplicas: 20},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "a", Replicas: 2, MaxReplicas: 3},
+				{Name: "b", Replicas: 2, MaxReplicas: 3},
+				{Name: "c", Replicas: 2, MaxReplicas: 4},
+			},
+		},
+		{
+			name: "additional targets of scaleTargetRefs",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10)},
+			usages: []ScalingBudgetUsage{
+				{Name: "api", Replicas: 2, MinReplicas: 1, MaxReplicas: 20, Targets: []ScalingBudgetTargetUsage{{Weight: 0.5, Replicas: 1}}},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				// 6 replicas of scaleTargetRef and 3 of the additional target fit in the budget
+				{Name: "api", Replicas: 2, MaxReplicas: 6},
+			},
+		},
+		{
+			name: "bounded by replica counts of members",
+			spec: ScalingBudgetSpec{MaxReplicas: ptr.To[int32](2)},
+			usages: []ScalingBudgetUsage{
+				{Name: "a", Replicas: 3, MinReplicas: 3, MaxReplicas: 5},
+				{Name: "b", Replicas: 0, MinReplicas: 1, MaxReplicas: 1},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "a", Replicas: 3, MaxReplicas: 3},
+				{Name: "b", Replicas: 0, MaxReplicas: 1},
+			},
+		},
+		{
+			name: "cpu requests",
+			spec: ScalingBudgetSpec{MaxCPU: ptr.To(resource.MustParse("2"))},
+			usages: []ScalingBudgetUsage{
+				{Name: "api", Priority: 1, Replicas: 2, MinReplicas: 1, MaxReplicas: 10, PodRequests: cpu("500m")},
+				{Name: "batch", Replicas: 2, MinReplicas: 1, MaxReplicas: 10, PodRequests: cpu("250m")},
+				{Name: "sidecar", Replicas: 2, MinReplicas: 1, MaxReplicas: 10},
+			},
+			expectedMembers: []ScalingBudgetMember{
+				{Name: "api", Priority: 1, Replicas: 2, MaxReplicas: 3},
+				{Name: "batch", Replicas: 2, MaxReplicas: 4},
+				{Name: "sidecar", Replicas: 2, MaxReplicas: 10},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := test.spec.Allocate(test.usages)
+			assert.Equal(t, test.expectedMembers, status.Members)
+		})
+	}
+}
+
+func TestScalingBudgetAllocateUsage(t *testing.T) {
+	spec := ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10), MaxMemory: ptr.To(resource.MustParse("4Gi"))}
+	status := spec.Allocate([]ScalingBudgetUsage{
+		{Name: "a", Replicas: 2, MinReplicas: 1, MaxReplicas: 10, PodRequests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}},
+		{Name: "b", Replicas: 3, MinReplicas: 1, MaxReplicas: 10, PodRequests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+	})
+
+	assert.Equal(t, int32(5), status.Replicas)
+	assert.Nil(t, status.CPU)
+	assert.Equal(t, int64(4*1024*1024*1024), status.Memory.Value())
+	maxReplicas, member := status.GetMemberMaxReplicas("b")
+	assert.True(t, member)
+	// 4Gi - 2 * 512Mi leaves 3Gi for b
+	assert.Equal(t, int32(3), maxReplicas)
+	_, member = status.GetMemberMaxReplicas("c")
+	assert.False(t, member)
+}
+
+func TestScalingBudgetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ScalingBudgetSpec
+		isError bool
+	}{
+		{"max replicas", ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10)}, false},
+		{"resources", ScalingBudgetSpec{MaxCPU: ptr.To(resource.MustParse("4")), MaxMemory: ptr.To(resource.MustParse("8Gi"))}, false},
+		{"no limit", ScalingBudgetSpec{}, true},
+		{"negative max replicas", ScalingBudgetSpec{MaxReplicas: ptr.To[int32](-1)}, true},
+		{"invalid polling interval", ScalingBudgetSpec{MaxReplicas: ptr.To[int32](10), PollingInterval: ptr.To[int32](0)}, true},
+		{"invalid selector", ScalingBudgetSpec{
+			MaxReplicas: ptr.To[int32](10),
+			Selector:    &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}},
+		}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.spec.Validate()
+			if test.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetHPAMaxReplicasWithScalingBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      *ScalingBudgetAllocation
+		minReplicas *int32
+		expected    int32
+	}{
+		{"without budget", nil, nil, 10},
+		{"limited by budget", &ScalingBudgetAllocation{Name: "shared", MaxReplicas: 4}, nil, 4},
+		{"budget above max replica count", &ScalingBudgetAllocation{Name: "shared", MaxReplicas: 20}, nil, 10},
+		{"budget below min replica count", &ScalingBudgetAllocation{Name: "shared", MaxReplicas: 0}, ptr.To[int32](2), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec:   ScaledObjectSpec{MinReplicaCount: test.minReplicas, MaxReplicaCount: ptr.To[int32](10)},
+				Status: ScaledObjectStatus{ScalingBudget: test.budget},
+			}
+			assert.Equal(t, test.expected, so.GetHPAMaxReplicas())
+		})
+	}
+}
+
+func TestGetScalingBudgetPriority(t *testing.T) {
+	so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ScalingBudgetPriorityAnnotation: "100"}}}
+	assert.Equal(t, int32(100), so.GetScalingBudgetPriority())
+	so.Annotations[ScalingBudgetPriorityAnnotation] = "high"
+	assert.Equal(t, int32(0), so.GetScalingBudgetPriority())
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ScalingFreezeKind is the kind of ScalingFreeze
+	ScalingFreezeKind = "ScalingFreeze"
+	// ClusterScalingFreezeKind is the kind of ClusterScalingFreeze
+	ClusterScalingFreezeKind = "ClusterScalingFreeze"
+)
+
+// +kubebuilder:object:root=true
+
+// ScalingFreeze holds the replicas of the selected ScaledObjects and ScaledJobs in its namespace while it is active
+// +kubebuilder:resource:path=scalingfreezes,scope=Namespaced,shortName=sf
+// +kubebuilder:printcolumn:name="ReplicaCount",type="integer",JSONPath=".spec.replicaCount"
+// +kubebuilder:printcolumn:name="Start",type="string",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".spec.duration"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ScalingFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingFreezeSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScalingFreezeList contains a list of ScalingFreeze
+type ScalingFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScalingFreeze `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterScalingFreeze holds the replicas of the selected ScaledObjects and ScaledJobs in all the selected namespaces while it is active
+// +kubebuilder:resource:path=clusterscalingfreezes,scope=Cluster,shortName=csf
+// +kubebuilder:printcolumn:name="ReplicaCount",type="integer",JSONPath=".spec.replicaCount"
+// +kubebuilder:printcolumn:name="Start",type="string",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".spec.duration"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterScalingFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingFreezeSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterScalingFreezeList contains a list of ClusterScalingFreeze
+type ClusterScalingFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterScalingFreeze `json:"items"`
+}
+
+// ScalingFreezeSpec defines which ScaledObjects and ScaledJobs are frozen and when
+type ScalingFreezeSpec struct {
+	// Selector selects the frozen ScaledObjects and ScaledJobs by their labels, all of them are selected if not set
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector selects the namespaces of the frozen ScaledObjects and ScaledJobs, all the namespaces
+	// are selected if not set. It is used by ClusterScalingFreeze only.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Start is a cron expression which starts the freeze, the freeze is active as long as it exists if not set
+	// +optional
+	Start string `json:"start,omitempty"`
+	// Duration is how long the freeze stays active after each Start
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// ReplicaCount is the replica count the frozen ScaledObjects are scaled to, they hold their current replicas if not set.
+	// It is ignored by ScaledJobs, which don't create any Job while they are frozen.
+	// +optional
+	ReplicaCount *int32 `json:"replicaCount,omitempty"`
+}
+
+// ScalingFreezeStatus identifies the active ScalingFreeze or ClusterScalingFreeze of a ScaledObject or ScaledJob
+type ScalingFreezeStatus struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// +optional
+	ReplicaCount *int32 `json:"replicaCount,omitempty"`
+}
+
+// String returns the freeze in the form <kind>/<name>
+func (s *ScalingFreezeStatus) String() string {
+	return s.Kind + "/" + s.Name
+}
+
+// parse returns the parsed cron schedule and the location the schedule is evaluated in
+func (s *ScalingFreezeSpec) parse() (cron.Schedule, *time.Location, error) {
+	schedule, err := cronWindowParser.Parse(s.Start)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing start of scaling freeze: %w", err)
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading timezone of scaling freeze: %w", err)
+	}
+	return schedule, location, nil
+}
+
+// IsActive returns whether the freeze is active at the given time
+func (s *ScalingFreezeSpec) IsActive(now time.Time) (bool, error) {
+	if s.Start == "" {
+		return true, nil
+	}
+	schedule, location, err := s.parse()
+	if err != nil {
+		return false, err
+	}
+	return isCronWindowActive(schedule, location, s.Duration.Duration, now), nil
+}
+
+// NextTransition returns the time when the freeze starts or ends next, the second return value
+// is false if the freeze has no schedule
+func (s *ScalingFreezeSpec) NextTransition(now time.Time) (time.Time, bool, error) {
+	if s.Start == "" {
+		return time.Time{}, false, nil
+	}
+	schedule, location, err := s.parse()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return nextCronWindowTransition(schedule, location, s.Duration.Duration, now), true, nil
+}
+
+// Validate checks that the freeze can be evaluated
+func (s *ScalingFreezeSpec) Validate() error {
+	if s.Start == "" {
+		if s.Duration.Duration != 0 || s.Timezone != "" {
+			return fmt.Errorf("duration and timezone of scaling freeze can be set only together with start")
+		}
+	} else {
+		if s.Duration.Duration <= 0 {
+			return fmt.Errorf("duration of scaling freeze must be greater than 0")
+		}
+		if _, _, err := s.parse(); err != nil {
+			return err
+		}
+	}
+	if s.ReplicaCount != nil && *s.ReplicaCount < 0 {
+		return fmt.Errorf("replicaCount of scaling freeze must not be negative")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s.Selector); err != nil {
+		return fmt.Errorf("error parsing selector of scaling freeze: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector); err != nil {
+		return fmt.Errorf("error parsing namespaceSelector of scaling freeze: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&ScalingFreeze{}, &ScalingFreezeList{}, &ClusterScalingFreeze{}, &ClusterScalingFreezeList{})
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScalingFreezeIsActive(t *testing.T) {
+	// release freeze on Fridays 16:00 - Monday 08:00 in Berlin
+	spec := ScalingFreezeSpec{
+		Start:    "0 16 * * 5",
+		Duration: metav1.Duration{Duration: 64 * time.Hour},
+		Timezone: "Europe/Berlin",
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		now                time.Time
+		expectedActive     bool
+		expectedTransition time.Time
+	}{
+		{
+			name:               "before freeze",
+			now:                time.Date(2025, time.March, 7, 15, 0, 0, 0, berlin),
+			expectedActive:     false,
+			expectedTransition: time.Date(2025, time.March, 7, 16, 0, 0, 0, berlin),
+		},
+		{
+			name:               "during freeze",
+			now:                time.Date(2025, time.March, 8, 12, 0, 0, 0, berlin),
+			expectedActive:     true,
+			expectedTransition: time.Date(2025, time.March, 10, 8, 0, 0, 0, berlin),
+		},
+		{
+			name:               "after freeze",
+			now:                time.Date(2025, time.March, 10, 9, 0, 0, 0, berlin),
+			expectedActive:     false,
+			expectedTransition: time.Date(2025, time.March, 14, 16, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active, err := spec.IsActive(test.now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedActive, active)
+
+			transition, found, err := spec.NextTransition(test.now)
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.True(t, test.expectedTransition.Equal(transition), "expected %s, got %s", test.expectedTransition, transition)
+		})
+	}
+}
+
+func TestScalingFreezeWithoutStartIsAlwaysActive(t *testing.T) {
+	spec := ScalingFreezeSpec{}
+
+	active, err := spec.IsActive(time.Now())
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	_, found, err := spec.NextTransition(time.Now())
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestValidateScalingFreeze(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           ScalingFreezeSpec
+		expectedErrMsg string
+	}{
+		{
+			name: "valid freeze",
+			spec: ScalingFreezeSpec{Start: "0 8 * * *", Duration: metav1.Duration{Duration: time.Hour}, ReplicaCount: int32Ptr(2)},
+		},
+		{
+			name: "valid freeze without start",
+			spec: ScalingFreezeSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}},
+		},
+		{
+			name:           "duration without start",
+			spec:           ScalingFreezeSpec{Duration: metav1.Duration{Duration: time.Hour}},
+			expectedErrMsg: "duration and timezone of scaling freeze can be set only together with start",
+		},
+		{
+			name:           "missing duration",
+			spec:           ScalingFreezeSpec{Start: "0 8 * * *"},
+			expectedErrMsg: "duration of scaling freeze must be greater than 0",
+		},
+		{
+			name:           "invalid cron expression",
+			spec:           ScalingFreezeSpec{Start: "0 8 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			expectedErrMsg: "error parsing start of scaling freeze",
+		},
+		{
+			name:           "negative replica count",
+			spec:           ScalingFreezeSpec{ReplicaCount: int32Ptr(-1)},
+			expectedErrMsg: "replicaCount of scaling freeze must not be negative",
+		},
+		{
+			name: "invalid selector",
+			spec: ScalingFreezeSpec{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Unknown"},
+			}}},
+			expectedErrMsg: "error parsing selector of scaling freeze",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.spec.Validate()
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
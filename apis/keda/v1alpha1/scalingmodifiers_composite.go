@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// MetricName returns the name of the external metric the composite metric is exposed as,
+// the formula of ScalingModifiers itself keeps CompositeMetricName
+func (cm CompositeMetric) MetricName() string {
+	if cm.Name == "" {
+		return CompositeMetricName
+	}
+	return CompositeMetricName + "-" + cm.Name
+}
+
+// GetMetricType returns the metric type of the composite metric, AverageValue by default
+func (cm CompositeMetric) GetMetricType() autoscalingv2.MetricTargetType {
+	if cm.MetricType == "" {
+		return autoscalingv2.AverageValueMetricType
+	}
+	return cm.MetricType
+}
+
+// HasFormula returns whether ScalingModifiers define at least one formula
+func (sm ScalingModifiers) HasFormula() bool {
+	return sm.Formula != "" || len(sm.CompositeMetrics) > 0
+}
+
+// GetCompositeMetrics returns all composite metrics of ScalingModifiers, the one defined
+// by Formula (without a name) comes first followed by CompositeMetrics
+func (sm ScalingModifiers) GetCompositeMetrics() []CompositeMetric {
+	var composites []CompositeMetric
+	if sm.Formula != "" {
+		composites = append(composites, CompositeMetric{
+			Formula:          sm.Formula,
+			Target:           sm.Target,
+			ActivationTarget: sm.ActivationTarget,
+			MetricType:       sm.MetricType,
+		})
+	}
+	return append(composites, sm.CompositeMetrics...)
+}
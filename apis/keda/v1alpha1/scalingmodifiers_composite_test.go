@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestGetCompositeMetrics(t *testing.T) {
+	sm := ScalingModifiers{
+		Formula: "queue",
+		Target:  "2",
+		CompositeMetrics: []CompositeMetric{
+			{Name: "latency", Formula: "latency", Target: "5", MetricType: autoscalingv2.ValueMetricType},
+		},
+	}
+
+	composites := sm.GetCompositeMetrics()
+	assert.Len(t, composites, 2)
+	assert.Equal(t, CompositeMetricName, composites[0].MetricName())
+	assert.Equal(t, autoscalingv2.AverageValueMetricType, composites[0].GetMetricType())
+	assert.Equal(t, "composite-metric-latency", composites[1].MetricName())
+	assert.Equal(t, autoscalingv2.ValueMetricType, composites[1].GetMetricType())
+
+	sm.Formula = ""
+	assert.True(t, sm.HasFormula())
+	assert.Len(t, sm.GetCompositeMetrics(), 1)
+}
+
+func TestValidateScalingModifiersCompositeMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		composites     []CompositeMetric
+		expectedErrMsg string
+	}{
+		{
+			name: "valid composite metrics",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue / 10", Target: "5"},
+				{Name: "latency", Formula: "latency > 200 ? latency : 0", Target: "100", ActivationTarget: "50", MetricType: autoscalingv2.ValueMetricType},
+			},
+		},
+		{
+			name: "invalid name",
+			composites: []CompositeMetric{
+				{Name: "Backlog_Per_Worker", Formula: "queue", Target: "5"},
+			},
+			expectedErrMsg: "error name \"Backlog_Per_Worker\" of ScalingModifiers.CompositeMetrics is invalid",
+		},
+		{
+			name: "duplicate names",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue", Target: "5"},
+				{Name: "backlog", Formula: "latency", Target: "5"},
+			},
+			expectedErrMsg: "error composite metric \"backlog\" is defined multiple times, but it must be unique",
+		},
+		{
+			name: "missing formula",
+			composites: []CompositeMetric{
+				{Name: "backlog", Target: "5"},
+			},
+			expectedErrMsg: "error formula of composite metric \"backlog\" is mandatory",
+		},
+		{
+			name: "missing target",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue"},
+			},
+			expectedErrMsg: "formula is given but target is empty",
+		},
+		{
+			name: "undefined trigger",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue + requests", Target: "5"},
+			},
+			expectedErrMsg: "error validating formula in ScalingModifiers",
+		},
+		{
+			name: "invalid activation target",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue", Target: "5", ActivationTarget: "some"},
+			},
+			expectedErrMsg: "error converting activationTarget for scalingModifiers",
+		},
+		{
+			name: "utilization metric type",
+			composites: []CompositeMetric{
+				{Name: "backlog", Formula: "queue", Target: "5", MetricType: autoscalingv2.UtilizationMetricType},
+			},
+			expectedErrMsg: "error trigger type is Utilization",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{
+						ScalingModifiers: ScalingModifiers{
+							Formula:          "queue",
+							Target:           "10",
+							CompositeMetrics: test.composites,
+						},
+					},
+					Triggers: []ScaleTriggers{
+						{Name: "queue", Type: "kafka"},
+						{Name: "latency", Type: "prometheus"},
+					},
+				},
+			}
+			programs, err := ValidateAndCompileScalingModifiers(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				assert.Len(t, programs, len(test.composites)+1)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	// FormulaVariableCurrentReplicas is the current replica count of the scale target
+	FormulaVariableCurrentReplicas = "currentReplicas"
+	// FormulaVariableMinReplicas is the MinReplicaCount in effect
+	FormulaVariableMinReplicas = "minReplicas"
+	// FormulaVariableMaxReplicas is the MaxReplicaCount in effect
+	FormulaVariableMaxReplicas = "maxReplicas"
+	// FormulaVariableActive maps every trigger name to whether the trigger is active, e.g. active.queue
+	FormulaVariableActive = "active"
+	// FormulaVariableFailed maps every trigger name to whether the trigger failed to return metrics, e.g. failed.queue
+	FormulaVariableFailed = "failed"
+	// FormulaVariableHour is the hour of the day (0-23)
+	FormulaVariableHour = "hour"
+	// FormulaVariableMinute is the minute of the hour (0-59)
+	FormulaVariableMinute = "minute"
+	// FormulaVariableWeekday is the day of the week (0 is Sunday)
+	FormulaVariableWeekday = "weekday"
+
+	// formulaVariableTriggers is the field of FormulaEnv the trigger values are looked up in,
+	// it can't be written in a formula
+	formulaVariableTriggers = "$triggers"
+)
+
+// FormulaContextVariables are the variables of scalingModifiers formula which describe the runtime context
+// of the ScaledObject, triggers can't use these names
+var FormulaContextVariables = []string{
+	FormulaVariableCurrentReplicas, FormulaVariableMinReplicas, FormulaVariableMaxReplicas,
+	FormulaVariableActive, FormulaVariableFailed,
+	FormulaVariableHour, FormulaVariableMinute, FormulaVariableWeekday,
+}
+
+// FormulaContext is the runtime context of the ScaledObject exposed to scalingModifiers formula
+// next to the trigger values
+// +kubebuilder:object:generate=false
+type FormulaContext struct {
+	CurrentReplicas int32
+	MinReplicas     int32
+	MaxReplicas     int32
+	// Active and Failed contain every trigger name
+	Active map[string]bool
+	Failed map[string]bool
+	// Now is the time of the evaluation in the timezone of scalingModifiers
+	Now time.Time
+}
+
+// NewFormulaContext returns FormulaContext of the ScaledObject with all triggers inactive and not failed
+func NewFormulaContext(so *ScaledObject, currentReplicas int32, now time.Time) FormulaContext {
+	var minReplicas int32
+	if minReplicaCount := so.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+	formulaContext := FormulaContext{
+		CurrentReplicas: currentReplicas,
+		MinReplicas:     minReplicas,
+		MaxReplicas:     so.GetHPAMaxReplicas(),
+		Active:          map[string]bool{},
+		Failed:          map[string]bool{},
+		Now:             now.UTC(),
+	}
+	if so.Spec.Advanced != nil {
+		if location, err := time.LoadLocation(so.Spec.Advanced.ScalingModifiers.Timezone); err == nil {
+			formulaContext.Now = now.In(location)
+		}
+	}
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Name != "" {
+			formulaContext.Active[trigger.Name] = false
+			formulaContext.Failed[trigger.Name] = false
+		}
+	}
+	return formulaContext
+}
+
+// FormulaEnv is the environment scalingModifiers formula is compiled against and evaluated in,
+// the triggers referenced by the formula are rewritten to look up their values in Triggers
+// +kubebuilder:object:generate=false
+type FormulaEnv struct {
+	Triggers        map[string]float64 `expr:"$triggers"`
+	CurrentReplicas int                `expr:"currentReplicas"`
+	MinReplicas     int                `expr:"minReplicas"`
+	MaxReplicas     int                `expr:"maxReplicas"`
+	Active          map[string]bool    `expr:"active"`
+	Failed          map[string]bool    `expr:"failed"`
+	Hour            int                `expr:"hour"`
+	Minute          int                `expr:"minute"`
+	Weekday         int                `expr:"weekday"`
+	AvgOver         FormulaHistoryFunc `expr:"avg_over"`
+	MaxOver         FormulaHistoryFunc `expr:"max_over"`
+	Rate            FormulaHistoryFunc `expr:"rate"`
+	Delta           FormulaHistoryFunc `expr:"delta"`
+}
+
+// NewFormulaEnv returns FormulaEnv with the values of the triggers by their names, the variables of the context
+// and the history functions by their names
+func NewFormulaEnv(triggers map[string]float64, formulaContext FormulaContext, history map[string]FormulaHistoryFunc) FormulaEnv {
+	return FormulaEnv{
+		Triggers:        triggers,
+		CurrentReplicas: int(formulaContext.CurrentReplicas),
+		MinReplicas:     int(formulaContext.MinReplicas),
+		MaxReplicas:     int(formulaContext.MaxReplicas),
+		Active:          formulaContext.Active,
+		Failed:          formulaContext.Failed,
+		Hour:            formulaContext.Now.Hour(),
+		Minute:          formulaContext.Now.Minute(),
+		Weekday:         int(formulaContext.Now.Weekday()),
+		AvgOver:         history[FormulaFunctionAvgOver],
+		MaxOver:         history[FormulaFunctionMaxOver],
+		Rate:            history[FormulaFunctionRate],
+		Delta:           history[FormulaFunctionDelta],
+	}
+}
+
+// FormulaReferencesVariable returns whether the compiled formula references the variable of the context
+func FormulaReferencesVariable(program *vm.Program, variable string) bool {
+	finder := &formulaVariableFinder{variable: variable}
+	node := program.Node()
+	ast.Walk(&node, finder)
+	return finder.found
+}
+
+// formulaVariableFinder looks for the identifier of a variable in the formula
+type formulaVariableFinder struct {
+	variable string
+	found    bool
+}
+
+func (f *formulaVariableFinder) Visit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok && identifier.Value == f.variable {
+		f.found = true
+	}
+}
+
+// formulaTriggerPatcher rewrites a trigger referenced by the formula into the lookup of its value in the triggers
+// of FormulaEnv, the triggers passed as the first argument of the history functions are patched to their names before
+type formulaTriggerPatcher struct {
+	triggers []string
+}
+
+func (p formulaTriggerPatcher) Visit(node *ast.Node) {
+	identifier, ok := (*node).(*ast.IdentifierNode)
+	if !ok || !slices.Contains(p.triggers, identifier.Value) {
+		return
+	}
+	ast.Patch(node, &ast.MemberNode{
+		Node:     &ast.IdentifierNode{Value: formulaVariableTriggers},
+		Property: &ast.StringNode{Value: identifier.Value},
+	})
+}
+
+// formulaContextChecker collects the triggers referenced through active or failed
+// and those of them which are not defined in the ScaledObject
+type formulaContextChecker struct {
+	triggers   []string
+	referenced []string
+	unknown    []string
+	// referencesTriggers is set if active or failed is used at all
+	referencesTriggers bool
+}
+
+func (c *formulaContextChecker) Visit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok && (identifier.Value == FormulaVariableActive || identifier.Value == FormulaVariableFailed) {
+		c.referencesTriggers = true
+	}
+	member, ok := (*node).(*ast.MemberNode)
+	if !ok {
+		return
+	}
+	variable, ok := member.Node.(*ast.IdentifierNode)
+	if !ok || (variable.Value != FormulaVariableActive && variable.Value != FormulaVariableFailed) {
+		return
+	}
+	trigger, ok := member.Property.(*ast.StringNode)
+	if !ok {
+		return
+	}
+	if !slices.Contains(c.triggers, trigger.Value) {
+		c.unknown = append(c.unknown, fmt.Sprintf("%s.%s", variable.Value, trigger.Value))
+	} else if !slices.Contains(c.referenced, trigger.Value) {
+		c.referenced = append(c.referenced, trigger.Value)
+	}
+}
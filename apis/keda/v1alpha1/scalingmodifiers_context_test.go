@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScalingModifiersFormulaContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		triggerName    string
+		timezone       string
+		expectedErrMsg string
+	}{
+		{
+			name:        "valid timezone",
+			triggerName: "queue",
+			timezone:    "America/New_York",
+		},
+		{
+			name:           "invalid timezone",
+			triggerName:    "queue",
+			timezone:       "Mars/Olympus",
+			expectedErrMsg: "error loading timezone \"Mars/Olympus\"",
+		},
+		{
+			name:           "trigger named as context variable",
+			triggerName:    "currentReplicas",
+			expectedErrMsg: "trigger name \"currentReplicas\" is reserved in scalingModifiers formula",
+		},
+		{
+			name:           "trigger named as history function",
+			triggerName:    "rate",
+			expectedErrMsg: "trigger name \"rate\" is reserved in scalingModifiers formula",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{
+						ScalingModifiers: ScalingModifiers{
+							Formula:  "1",
+							Target:   "10",
+							Timezone: test.timezone,
+						},
+					},
+					Triggers: []ScaleTriggers{{Name: test.triggerName, Type: "kafka"}},
+				},
+			}
+			_, err := ValidateAndCompileScalingModifiers(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestFormulaReferencesVariable(t *testing.T) {
+	tests := []struct {
+		name     string
+		formula  string
+		expected bool
+	}{
+		{
+			name:     "current replicas",
+			formula:  "queue / currentReplicas",
+			expected: true,
+		},
+		{
+			name:     "trigger starting with the name of the variable",
+			formula:  "currentReplicasTotal + queue",
+			expected: false,
+		},
+		{
+			name:     "without current replicas",
+			formula:  "max(queue, minReplicas)",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{
+						ScalingModifiers: ScalingModifiers{
+							Formula: test.formula,
+							Target:  "10",
+						},
+					},
+					Triggers: []ScaleTriggers{
+						{Name: "queue", Type: "kafka"},
+						{Name: "currentReplicasTotal", Type: "prometheus"},
+					},
+				},
+			}
+			programs, err := ValidateAndCompileScalingModifiers(so)
+			assert.NoError(t, err)
+			for _, program := range programs {
+				assert.Equal(t, test.expected, FormulaReferencesVariable(program, FormulaVariableCurrentReplicas))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr/ast"
+)
+
+const (
+	// FormulaFunctionAvgOver returns the average of the trigger values within the window
+	FormulaFunctionAvgOver = "avg_over"
+	// FormulaFunctionMaxOver returns the maximum of the trigger values within the window
+	FormulaFunctionMaxOver = "max_over"
+	// FormulaFunctionRate returns the per-second change of the trigger value within the window
+	FormulaFunctionRate = "rate"
+	// FormulaFunctionDelta returns the change of the trigger value within the window
+	FormulaFunctionDelta = "delta"
+
+	// MaxFormulaHistoryWindow is the longest window the history functions can look back
+	MaxFormulaHistoryWindow = time.Hour
+)
+
+// FormulaHistoryFunctions are the functions of scalingModifiers formula which operate
+// on the past values of a trigger, e.g. avg_over(trigger, "5m")
+var FormulaHistoryFunctions = []string{FormulaFunctionAvgOver, FormulaFunctionMaxOver, FormulaFunctionRate, FormulaFunctionDelta}
+
+// FormulaHistoryFunc is the signature of the history functions in scalingModifiers formula
+// +kubebuilder:object:generate=false
+type FormulaHistoryFunc func(trigger string, window string) (float64, error)
+
+// ParseFormulaHistoryWindow parses the window argument of the history functions
+func ParseFormulaHistoryWindow(window string) (time.Duration, error) {
+	duration, err := time.ParseDuration(window)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing window %q: %w", window, err)
+	}
+	if duration <= 0 || duration > MaxFormulaHistoryWindow {
+		return 0, fmt.Errorf("window %q must be greater than 0 and at most %s", window, MaxFormulaHistoryWindow)
+	}
+	return duration, nil
+}
+
+// formulaHistoryPatcher rewrites a trigger passed as the first argument of the history functions
+// into its name, so the functions get the trigger name instead of its current value
+type formulaHistoryPatcher struct{}
+
+func (formulaHistoryPatcher) Visit(node *ast.Node) {
+	call, ok := (*node).(*ast.CallNode)
+	if !ok || len(call.Arguments) == 0 {
+		return
+	}
+	callee, ok := call.Callee.(*ast.IdentifierNode)
+	if !ok || !slices.Contains(FormulaHistoryFunctions, callee.Value) {
+		return
+	}
+	if trigger, ok := call.Arguments[0].(*ast.IdentifierNode); ok {
+		ast.Patch(&call.Arguments[0], &ast.StringNode{Value: trigger.Value})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScalingModifiersFormulaWithHistoryFunctions(t *testing.T) {
+	tests := []struct {
+		name           string
+		formula        string
+		expectedErrMsg string
+	}{
+		{
+			name:    "history functions with trigger identifiers",
+			formula: `avg_over(queue, "5m") + max_over(queue, "1m") + rate(requests, "1m") + delta(requests, "30s")`,
+		},
+		{
+			name:    "history functions with trigger names",
+			formula: `avg_over("queue", "5m") * requests`,
+		},
+		{
+			name:           "unknown trigger",
+			formula:        `avg_over(unknown, "5m")`,
+			expectedErrMsg: "trigger \"unknown\" is not defined",
+		},
+		{
+			name:           "invalid window",
+			formula:        `max_over(queue, "five minutes")`,
+			expectedErrMsg: "error parsing window \"five minutes\"",
+		},
+		{
+			name:    "context variables",
+			formula: `weekday >= 1 && weekday <= 5 && !failed.queue ? max(queue / currentReplicas, minReplicas) : (active.requests ? hour : maxReplicas)`,
+		},
+		{
+			name:           "unknown trigger in context variable",
+			formula:        `active.unknown ? queue : requests`,
+			expectedErrMsg: "formula references undefined triggers: active.unknown",
+		},
+		{
+			name:           "history of context variable",
+			formula:        `avg_over(hour, "5m")`,
+			expectedErrMsg: "trigger \"hour\" is not defined",
+		},
+		{
+			name:           "too long window",
+			formula:        `max_over(queue, "2h")`,
+			expectedErrMsg: "window \"2h\" must be greater than 0 and at most 1h0m0s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{
+						ScalingModifiers: ScalingModifiers{
+							Formula: test.formula,
+							Target:  "10",
+						},
+					},
+					Triggers: []ScaleTriggers{
+						{Name: "queue", Type: "kafka"},
+						{Name: "requests", Type: "prometheus"},
+					},
+				},
+			}
+			_, err := ValidateAndCompileScalingModifiers(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	// ConditionVariableAnnotations maps the annotations of the ScaledObject/ScaledJob to their values
+	ConditionVariableAnnotations = "annotations"
+	// ConditionVariableLabels maps the labels of the ScaledObject/ScaledJob to their values
+	ConditionVariableLabels = "labels"
+)
+
+// TriggerCondition enables a trigger only while its expression evaluates to true, a disabled trigger
+// is left out of the activation and reports a zero metric value
+type TriggerCondition struct {
+	// Expression is a boolean expression over the time (hour, minute, weekday), the annotations and labels of
+	// the ScaledObject/ScaledJob, and the activity of the other named triggers (active.<name>, failed.<name>),
+	// e.g. `weekday >= 1 && weekday <= 5 && hour >= 8 && hour < 18`
+	Expression string `json:"expression"`
+	// Timezone is the IANA timezone of hour, minute and weekday, UTC by default
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// CompiledTriggerCondition is the compiled expression of a trigger condition
+// +kubebuilder:object:generate=false
+type CompiledTriggerCondition struct {
+	Program  *vm.Program
+	Location *time.Location
+	// DependsOnTriggers is set if the expression references the activity of other triggers, the condition
+	// is then evaluated once the other triggers were queried
+	DependsOnTriggers bool
+}
+
+// TriggerConditionContext is the runtime context a trigger condition is evaluated in
+// +kubebuilder:object:generate=false
+type TriggerConditionContext struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	// Active and Failed contain the named triggers queried so far
+	Active map[string]bool
+	Failed map[string]bool
+	Now    time.Time
+}
+
+// Evaluate returns whether the trigger is enabled in the context
+func (c *CompiledTriggerCondition) Evaluate(context TriggerConditionContext) (bool, error) {
+	now := context.Now.In(c.Location)
+	env := map[string]any{
+		ConditionVariableAnnotations: nonNilMap(context.Annotations),
+		ConditionVariableLabels:      nonNilMap(context.Labels),
+		FormulaVariableActive:        nonNilMap(context.Active),
+		FormulaVariableFailed:        nonNilMap(context.Failed),
+		FormulaVariableHour:          now.Hour(),
+		FormulaVariableMinute:        now.Minute(),
+		FormulaVariableWeekday:       int(now.Weekday()),
+	}
+	result, err := expr.Run(c.Program, env)
+	if err != nil {
+		return false, err
+	}
+	enabled, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition returned %T instead of bool", result)
+	}
+	return enabled, nil
+}
+
+func nonNilMap[V any](values map[string]V) map[string]V {
+	if values == nil {
+		return map[string]V{}
+	}
+	return values
+}
+
+// CompileTriggerConditions validates and compiles the conditions of the triggers by the index of the trigger,
+// a condition may reference the activity of a trigger only if the condition of that trigger doesn't
+func CompileTriggerConditions(triggers []ScaleTriggers) (map[int]*CompiledTriggerCondition, error) {
+	triggerNames := []string{}
+	for _, trigger := range triggers {
+		if trigger.Name != "" {
+			triggerNames = append(triggerNames, trigger.Name)
+		}
+	}
+	env := map[string]any{
+		ConditionVariableAnnotations: map[string]string{},
+		ConditionVariableLabels:      map[string]string{},
+		FormulaVariableActive:        map[string]bool{},
+		FormulaVariableFailed:        map[string]bool{},
+		FormulaVariableHour:          0,
+		FormulaVariableMinute:        0,
+		FormulaVariableWeekday:       0,
+	}
+	for _, name := range triggerNames {
+		env[FormulaVariableActive].(map[string]bool)[name] = false
+		env[FormulaVariableFailed].(map[string]bool)[name] = false
+	}
+
+	conditions := map[int]*CompiledTriggerCondition{}
+	references := map[int][]string{}
+	for i, trigger := range triggers {
+		condition := trigger.Condition
+		if condition == nil {
+			continue
+		}
+		if trigger.Type == cpuString || trigger.Type == memoryString {
+			return nil, fmt.Errorf("property \"condition\" is not supported for %q scaler", trigger.Type)
+		}
+		if condition.Expression == "" {
+			return nil, fmt.Errorf("condition.expression of trigger %q is mandatory", trigger.Type)
+		}
+		location, err := time.LoadLocation(condition.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("error loading timezone %q of the condition of trigger %q: %w", condition.Timezone, trigger.Type, err)
+		}
+
+		contextChecker := &formulaContextChecker{triggers: triggerNames}
+		program, err := expr.Compile(condition.Expression, expr.Env(env), expr.AsBool(), expr.Patch(contextChecker))
+		if err != nil {
+			return nil, fmt.Errorf("error compiling the condition of trigger %q: %w", trigger.Type, err)
+		}
+		if len(contextChecker.unknown) > 0 {
+			return nil, fmt.Errorf("condition of trigger %q references undefined triggers: %s", trigger.Type, strings.Join(contextChecker.unknown, ", "))
+		}
+		if trigger.Name != "" && slices.Contains(contextChecker.referenced, trigger.Name) {
+			return nil, fmt.Errorf("condition of trigger %q can't reference the activity of the trigger itself", trigger.Name)
+		}
+		if _, err := expr.Run(program, env); err != nil {
+			return nil, fmt.Errorf("error evaluating the condition of trigger %q: %w", trigger.Type, err)
+		}
+		conditions[i] = &CompiledTriggerCondition{Program: program, Location: location, DependsOnTriggers: contextChecker.referencesTriggers}
+		references[i] = contextChecker.referenced
+	}
+
+	for i, referenced := range references {
+		for j, trigger := range triggers {
+			if slices.Contains(referenced, trigger.Name) && conditions[j] != nil && conditions[j].DependsOnTriggers {
+				return nil, fmt.Errorf("condition of trigger %q can't reference trigger %q whose condition depends on other triggers", triggers[i].Type, trigger.Name)
+			}
+		}
+	}
+	return conditions, nil
+}
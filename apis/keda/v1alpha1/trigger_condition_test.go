@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileTriggerConditions(t *testing.T) {
+	condition := func(expression string) *TriggerCondition {
+		return &TriggerCondition{Expression: expression}
+	}
+
+	tests := []struct {
+		name     string
+		triggers []ScaleTriggers
+		isError  bool
+	}{
+		{"without conditions", []ScaleTriggers{{Type: "prometheus"}}, false},
+		{"business hours", []ScaleTriggers{{Type: "prometheus", Condition: &TriggerCondition{Expression: "weekday >= 1 && weekday <= 5 && hour >= 8 && hour < 18", Timezone: "Europe/Berlin"}}}, false},
+		{"annotation", []ScaleTriggers{{Type: "prometheus", Condition: condition(`annotations["feature"] == "on"`)}}, false},
+		{"other trigger", []ScaleTriggers{{Type: "prometheus", Condition: condition("active.queue")}, {Type: "rabbitmq", Name: "queue"}}, false},
+		{"empty expression", []ScaleTriggers{{Type: "prometheus", Condition: condition("")}}, true},
+		{"not a bool", []ScaleTriggers{{Type: "prometheus", Condition: condition("hour + 1")}}, true},
+		{"unknown variable", []ScaleTriggers{{Type: "prometheus", Condition: condition("day == 1")}}, true},
+		{"unknown timezone", []ScaleTriggers{{Type: "prometheus", Condition: &TriggerCondition{Expression: "hour > 8", Timezone: "Mars/Olympus"}}}, true},
+		{"undefined trigger", []ScaleTriggers{{Type: "prometheus", Condition: condition("active.queue")}}, true},
+		{"itself", []ScaleTriggers{{Type: "prometheus", Name: "latency", Condition: condition("active.latency")}}, true},
+		{"chained conditions", []ScaleTriggers{
+			{Type: "prometheus", Name: "latency", Condition: condition("active.queue")},
+			{Type: "rabbitmq", Name: "queue", Condition: condition("failed.backlog")},
+			{Type: "kafka", Name: "backlog"},
+		}, true},
+		{"resource trigger", []ScaleTriggers{{Type: "cpu", Condition: condition("hour > 8")}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := CompileTriggerConditions(test.triggers)
+			assert.Equal(t, test.isError, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func TestEvaluateTriggerCondition(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Type: "prometheus", Name: "latency", Condition: &TriggerCondition{Expression: "hour >= 8 && hour < 18 && labels.tier == 'web'", Timezone: "Europe/Berlin"}},
+		{Type: "kafka", Name: "backlog", Condition: &TriggerCondition{Expression: `annotations["feature"] == "on" || active.latency`}},
+	}
+	conditions, err := CompileTriggerConditions(triggers)
+	require.NoError(t, err)
+	assert.False(t, conditions[0].DependsOnTriggers)
+	assert.True(t, conditions[1].DependsOnTriggers)
+
+	// 07:30 UTC is 09:30 in Berlin in summer
+	now := time.Date(2025, 7, 1, 7, 30, 0, 0, time.UTC)
+	enabled, err := conditions[0].Evaluate(TriggerConditionContext{Labels: map[string]string{"tier": "web"}, Now: now})
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	enabled, err = conditions[0].Evaluate(TriggerConditionContext{Labels: map[string]string{"tier": "web"}, Now: now.Add(10 * time.Hour)})
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	enabled, err = conditions[1].Evaluate(TriggerConditionContext{Active: map[string]bool{"latency": false}, Now: now})
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	enabled, err = conditions[1].Evaluate(TriggerConditionContext{Annotations: map[string]string{"feature": "on"}, Now: now})
+	require.NoError(t, err)
+	assert.True(t, enabled)
+}
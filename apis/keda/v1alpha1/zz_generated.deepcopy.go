@@ -22,10 +22,32 @@ package v1alpha1
 
 import (
 	"k8s.io/api/autoscaling/v2"
-	"k8s.io/api/batch/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptivePolling) DeepCopyInto(out *AdaptivePolling) {
+	*out = *in
+	if in.IdlePollingInterval != nil {
+		in, out := &in.IdlePollingInterval, &out.IdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptivePolling.
+func (in *AdaptivePolling) DeepCopy() *AdaptivePolling {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptivePolling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 	*out = *in
@@ -34,7 +56,12 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		*out = new(HorizontalPodAutoscalerConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	out.ScalingModifiers = in.ScalingModifiers
+	in.ScalingModifiers.DeepCopyInto(&out.ScalingModifiers)
+	if in.ScaleDecisionWebhook != nil {
+		in, out := &in.ScaleDecisionWebhook, &out.ScaleDecisionWebhook
+		*out = new(ScaleDecisionWebhook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -354,6 +381,122 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScaledObjectTemplate) DeepCopyInto(out *ClusterScaledObjectTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScaledObjectTemplate.
+func (in *ClusterScaledObjectTemplate) DeepCopy() *ClusterScaledObjectTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScaledObjectTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScaledObjectTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScaledObjectTemplateList) DeepCopyInto(out *ClusterScaledObjectTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScaledObjectTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScaledObjectTemplateList.
+func (in *ClusterScaledObjectTemplateList) DeepCopy() *ClusterScaledObjectTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScaledObjectTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScaledObjectTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScalingFreeze) DeepCopyInto(out *ClusterScalingFreeze) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScalingFreeze.
+func (in *ClusterScalingFreeze) DeepCopy() *ClusterScalingFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScalingFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScalingFreeze) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScalingFreezeList) DeepCopyInto(out *ClusterScalingFreezeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScalingFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScalingFreezeList.
+func (in *ClusterScalingFreezeList) DeepCopy() *ClusterScalingFreezeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScalingFreezeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScalingFreezeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
@@ -413,6 +556,21 @@ func (in *ClusterTriggerAuthenticationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeMetric) DeepCopyInto(out *CompositeMetric) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeMetric.
+func (in *CompositeMetric) DeepCopy() *CompositeMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -465,6 +623,11 @@ func (in *Credential) DeepCopy() *Credential {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fallback.
@@ -634,6 +797,57 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricTransform) DeepCopyInto(out *MetricTransform) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricTransform.
+func (in *MetricTransform) DeepCopy() *MetricTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountSchedule) DeepCopyInto(out *ReplicaCountSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.IdleReplicaCount != nil {
+		in, out := &in.IdleReplicaCount, &out.IdleReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountSchedule.
+func (in *ReplicaCountSchedule) DeepCopy() *ReplicaCountSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -649,6 +863,31 @@ func (in *Rollout) DeepCopy() *Rollout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDecisionWebhook) DeepCopyInto(out *ScaleDecisionWebhook) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(AuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDecisionWebhook.
+func (in *ScaleDecisionWebhook) DeepCopy() *ScaleDecisionWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDecisionWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTarget) DeepCopyInto(out *ScaleTarget) {
 	*out = *in
@@ -664,6 +903,26 @@ func (in *ScaleTarget) DeepCopy() *ScaleTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleTargetStatus) DeepCopyInto(out *ScaleTargetStatus) {
+	*out = *in
+	if in.ScaleTargetGVKR != nil {
+		in, out := &in.ScaleTargetGVKR, &out.ScaleTargetGVKR
+		*out = new(GroupVersionKindResource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTargetStatus.
+func (in *ScaleTargetStatus) DeepCopy() *ScaleTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	*out = *in
@@ -679,6 +938,26 @@ func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 		*out = new(AuthenticationRef)
 		**out = **in
 	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(Fallback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hysteresis != nil {
+		in, out := &in.Hysteresis, &out.Hysteresis
+		*out = new(TriggerHysteresis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(MetricTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(TriggerCondition)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTriggers.
@@ -718,6 +997,26 @@ func (in *ScaledJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledJobAdvancedConfig) DeepCopyInto(out *ScaledJobAdvancedConfig) {
+	*out = *in
+	if in.ScaleDecisionWebhook != nil {
+		in, out := &in.ScaleDecisionWebhook, &out.ScaleDecisionWebhook
+		*out = new(ScaleDecisionWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobAdvancedConfig.
+func (in *ScaledJobAdvancedConfig) DeepCopy() *ScaledJobAdvancedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledJobAdvancedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledJobList) DeepCopyInto(out *ScaledJobList) {
 	*out = *in
@@ -755,7 +1054,7 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 	*out = *in
 	if in.JobTargetRef != nil {
 		in, out := &in.JobTargetRef, &out.JobTargetRef
-		*out = new(v1.JobSpec)
+		*out = new(batchv1.JobSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PollingInterval != nil {
@@ -763,6 +1062,11 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePolling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SuccessfulJobsHistoryLimit != nil {
 		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
 		*out = new(int32)
@@ -785,6 +1089,11 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		**out = **in
 	}
 	in.ScalingStrategy.DeepCopyInto(&out.ScalingStrategy)
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(ScaledJobAdvancedConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -826,6 +1135,11 @@ func (in *ScaledJobStatus) DeepCopyInto(out *ScaledJobStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScalingFreeze != nil {
+		in, out := &in.ScalingFreeze, &out.ScalingFreeze
+		*out = new(ScalingFreezeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobStatus.
@@ -920,11 +1234,26 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(ScaleTarget)
 		**out = **in
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ScaledObjectTemplateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleTargetRefs != nil {
+		in, out := &in.ScaleTargetRefs, &out.ScaleTargetRefs
+		*out = make([]WeightedScaleTarget, len(*in))
+		copy(*out, *in)
+	}
 	if in.PollingInterval != nil {
 		in, out := &in.PollingInterval, &out.PollingInterval
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePolling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InitialCooldownPeriod != nil {
 		in, out := &in.InitialCooldownPeriod, &out.InitialCooldownPeriod
 		*out = new(int32)
@@ -950,6 +1279,13 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReplicaCountSchedules != nil {
+		in, out := &in.ReplicaCountSchedules, &out.ReplicaCountSchedules
+		*out = make([]ReplicaCountSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(AdvancedConfig)
@@ -965,7 +1301,7 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 	if in.Fallback != nil {
 		in, out := &in.Fallback, &out.Fallback
 		*out = new(Fallback)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1006,6 +1342,11 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CompositeScalerNames != nil {
+		in, out := &in.CompositeScalerNames, &out.CompositeScalerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(Conditions, len(*in))
@@ -1018,21 +1359,55 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PausedReplicaCount != nil {
 		in, out := &in.PausedReplicaCount, &out.PausedReplicaCount
 		*out = new(int32)
 		**out = **in
 	}
-	if in.TriggersTypes != nil {
-		in, out := &in.TriggersTypes, &out.TriggersTypes
-		*out = new(string)
+	if in.DryRunReplicaCount != nil {
+		in, out := &in.DryRunReplicaCount, &out.DryRunReplicaCount
+		*out = new(int32)
 		**out = **in
 	}
-	if in.AuthenticationsTypes != nil {
+	if in.ScaleTargets != nil {
+		in, out := &in.ScaleTargets, &out.ScaleTargets
+		*out = make([]ScaleTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TriggersTypes != nil {
+		in, out := &in.TriggersTypes, &out.TriggersTypes
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthenticationsTypes != nil {
 		in, out := &in.AuthenticationsTypes, &out.AuthenticationsTypes
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScalingFreeze != nil {
+		in, out := &in.ScalingFreeze, &out.ScalingFreeze
+		*out = new(ScalingFreezeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScalingBudget != nil {
+		in, out := &in.ScalingBudget, &out.ScalingBudget
+		*out = new(ScalingBudgetAllocation)
+		**out = **in
+	}
+	if in.RenderedSpec != nil {
+		in, out := &in.RenderedSpec, &out.RenderedSpec
+		*out = new(ScaledObjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -1045,9 +1420,436 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectTemplate) DeepCopyInto(out *ScaledObjectTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectTemplate.
+func (in *ScaledObjectTemplate) DeepCopy() *ScaledObjectTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaledObjectTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectTemplateList) DeepCopyInto(out *ScaledObjectTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaledObjectTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectTemplateList.
+func (in *ScaledObjectTemplateList) DeepCopy() *ScaledObjectTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaledObjectTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectTemplateRef) DeepCopyInto(out *ScaledObjectTemplateRef) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectTemplateRef.
+func (in *ScaledObjectTemplateRef) DeepCopy() *ScaledObjectTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectTemplateSpec) DeepCopyInto(out *ScaledObjectTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectTemplateSpec.
+func (in *ScaledObjectTemplateSpec) DeepCopy() *ScaledObjectTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudget) DeepCopyInto(out *ScalingBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudget.
+func (in *ScalingBudget) DeepCopy() *ScalingBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetAllocation) DeepCopyInto(out *ScalingBudgetAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetAllocation.
+func (in *ScalingBudgetAllocation) DeepCopy() *ScalingBudgetAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetList) DeepCopyInto(out *ScalingBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScalingBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetList.
+func (in *ScalingBudgetList) DeepCopy() *ScalingBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetMember) DeepCopyInto(out *ScalingBudgetMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetMember.
+func (in *ScalingBudgetMember) DeepCopy() *ScalingBudgetMember {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetSpec) DeepCopyInto(out *ScalingBudgetSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCPU != nil {
+		in, out := &in.MaxCPU, &out.MaxCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetSpec.
+func (in *ScalingBudgetSpec) DeepCopy() *ScalingBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetStatus) DeepCopyInto(out *ScalingBudgetStatus) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ScalingBudgetMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetStatus.
+func (in *ScalingBudgetStatus) DeepCopy() *ScalingBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetTargetUsage) DeepCopyInto(out *ScalingBudgetTargetUsage) {
+	*out = *in
+	if in.PodRequests != nil {
+		in, out := &in.PodRequests, &out.PodRequests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetTargetUsage.
+func (in *ScalingBudgetTargetUsage) DeepCopy() *ScalingBudgetTargetUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetTargetUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBudgetUsage) DeepCopyInto(out *ScalingBudgetUsage) {
+	*out = *in
+	if in.PodRequests != nil {
+		in, out := &in.PodRequests, &out.PodRequests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ScalingBudgetTargetUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBudgetUsage.
+func (in *ScalingBudgetUsage) DeepCopy() *ScalingBudgetUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBudgetUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingFreeze) DeepCopyInto(out *ScalingFreeze) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingFreeze.
+func (in *ScalingFreeze) DeepCopy() *ScalingFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingFreeze) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingFreezeList) DeepCopyInto(out *ScalingFreezeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScalingFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingFreezeList.
+func (in *ScalingFreezeList) DeepCopy() *ScalingFreezeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingFreezeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingFreezeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingFreezeSpec) DeepCopyInto(out *ScalingFreezeSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Duration = in.Duration
+	if in.ReplicaCount != nil {
+		in, out := &in.ReplicaCount, &out.ReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingFreezeSpec.
+func (in *ScalingFreezeSpec) DeepCopy() *ScalingFreezeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingFreezeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingFreezeStatus) DeepCopyInto(out *ScalingFreezeStatus) {
+	*out = *in
+	if in.ReplicaCount != nil {
+		in, out := &in.ReplicaCount, &out.ReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingFreezeStatus.
+func (in *ScalingFreezeStatus) DeepCopy() *ScalingFreezeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingFreezeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingModifiers) DeepCopyInto(out *ScalingModifiers) {
 	*out = *in
+	if in.CompositeMetrics != nil {
+		in, out := &in.CompositeMetrics, &out.CompositeMetrics
+		*out = make([]CompositeMetric, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingModifiers.
@@ -1100,6 +1902,26 @@ func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerAuthentication) DeepCopyInto(out *TriggerAuthentication) {
 	*out = *in
@@ -1229,6 +2051,70 @@ func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerCondition) DeepCopyInto(out *TriggerCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerCondition.
+func (in *TriggerCondition) DeepCopy() *TriggerCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerHysteresis) DeepCopyInto(out *TriggerHysteresis) {
+	*out = *in
+	if in.InactivePolls != nil {
+		in, out := &in.InactivePolls, &out.InactivePolls
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerHysteresis.
+func (in *TriggerHysteresis) DeepCopy() *TriggerHysteresis {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerHysteresis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastValueTime != nil {
+		in, out := &in.LastValueTime, &out.LastValueTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -1276,6 +2162,22 @@ func (in *VaultSecret) DeepCopy() *VaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedScaleTarget) DeepCopyInto(out *WeightedScaleTarget) {
+	*out = *in
+	out.ScaleTarget = in.ScaleTarget
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedScaleTarget.
+func (in *WeightedScaleTarget) DeepCopy() *WeightedScaleTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedScaleTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WithTriggers) DeepCopyInto(out *WithTriggers) {
 	*out = *in
@@ -1342,6 +2244,11 @@ func (in *WithTriggersSpec) DeepCopyInto(out *WithTriggersSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AdaptivePolling != nil {
+		in, out := &in.AdaptivePolling, &out.AdaptivePolling
+		*out = new(AdaptivePolling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -18,10 +18,13 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"net"
 	"os"
 	"time"
 
 	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	kubeinformers "k8s.io/client-go/informers"
@@ -29,8 +32,10 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -47,6 +52,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -201,6 +207,36 @@ func main() {
 		os.Exit(1)
 	}
 
+	// ScaledObjects are created from the template annotations of Deployments and StatefulSets only if enabled
+	enableWorkloadScaledObjects, err := kedautil.ResolveOsEnvBool("KEDA_WORKLOAD_SCALEDOBJECTS_ENABLED", false)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_WORKLOAD_SCALEDOBJECTS_ENABLED")
+		os.Exit(1)
+	}
+
+	operatorShards, err := kedautil.ResolveOsEnvInt("KEDA_OPERATOR_SHARDS", 0)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_OPERATOR_SHARDS")
+		os.Exit(1)
+	}
+
+	// with sharding every replica runs the ScaledObject and ScaledJob controllers for the shards it owns,
+	// the other controllers still run on the leader only
+	var shardManager *sharding.Manager
+	var shardedControllerLeaderElection *bool
+	if operatorShards > 0 {
+		shardManager, err = newShardManager(mgr, int32(operatorShards), metricsServiceAddr, ptr.Deref(leaseDuration, 0))
+		if err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		if err := mgr.Add(shardManager); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		shardedControllerLeaderElection = ptr.To(false)
+	}
+
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
 	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
 
@@ -234,8 +270,10 @@ func main() {
 		ScaleClient:  scaleClient,
 		ScaleHandler: scaledHandler,
 		EventEmitter: eventEmitter,
+		Sharding:     shardManager,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
+		NeedLeaderElection:      shardedControllerLeaderElection,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
@@ -247,12 +285,34 @@ func main() {
 		EventEmitter:      eventEmitter,
 		SecretsLister:     secretInformer.Lister(),
 		SecretsSynced:     secretInformer.Informer().HasSynced,
+		Sharding:          shardManager,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledJobMaxReconciles,
+		NeedLeaderElection:      shardedControllerLeaderElection,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
+	if err = (&kedacontrollers.ScalingBudgetReconciler{
+		Client:      mgr.GetClient(),
+		ScaleClient: scaleClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScalingBudget")
+		os.Exit(1)
+	}
+	if enableWorkloadScaledObjects {
+		for _, workload := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}} {
+			if err = (&kedacontrollers.WorkloadScaledObjectReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Recorder: eventRecorder,
+				Workload: workload,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "WorkloadScaledObject")
+				os.Exit(1)
+			}
+		}
+	}
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
 		Client:       mgr.GetClient(),
 		EventHandler: eventEmitter,
@@ -319,7 +379,9 @@ func main() {
 
 	kedautil.SetCACertDirs(caDirs)
 
-	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady)
+	// the certificates are issued for the operator service, the requests forwarded to the shard owners use it as authority
+	operatorAuthority := fmt.Sprintf("%s.%s.svc", operatorServiceName, kedautil.GetPodNamespace())
+	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady, shardManager, operatorAuthority)
 	if err := mgr.Add(&grpcServer); err != nil {
 		setupLog.Error(err, "unable to set up Metrics Service gRPC server")
 		os.Exit(1)
@@ -339,3 +401,25 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newShardManager returns the sharding Manager of this replica, the replica is identified by POD_NAME
+// and the other replicas forward the metric requests to POD_IP
+func newShardManager(mgr ctrl.Manager, shards int32, metricsServiceAddr string, leaseDuration time.Duration) (*sharding.Manager, error) {
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get identity of the replica: %w", err)
+		}
+		identity = hostname
+	}
+	podIP := os.Getenv("POD_IP")
+	if podIP == "" {
+		return nil, fmt.Errorf("POD_IP must be set when KEDA_OPERATOR_SHARDS is set")
+	}
+	_, port, err := net.SplitHostPort(metricsServiceAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics service address %q: %w", metricsServiceAddr, err)
+	}
+	return sharding.NewManager(mgr.GetClient(), mgr.GetAPIReader(), kedautil.GetPodNamespace(), identity, net.JoinHostPort(podIP, port), shards, leaseDuration)
+}
@@ -159,6 +159,14 @@ func setupWebhook(mgr manager.Manager, cacheMissToDirectClient bool) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterTriggerAuthentication")
 		os.Exit(1)
 	}
+	if err := (&kedav1alpha1.ScaledObjectTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ScaledObjectTemplate")
+		os.Exit(1)
+	}
+	if err := (&kedav1alpha1.ClusterScaledObjectTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterScaledObjectTemplate")
+		os.Exit(1)
+	}
 	if err := (&eventingv1alpha1.CloudEventSource{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CloudEventSource")
 		os.Exit(1)
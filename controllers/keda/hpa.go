@@ -208,6 +208,16 @@ func (r *ScaledObjectReconciler) deleteHPA(ctx context.Context, logger logr.Logg
 	return nil
 }
 
+// reconcileScaledObjectWithoutHPA deletes the HPA of the ScaledObject in dry-run or native scaling mode, so it doesn't
+// scale the target, and keeps the metric names in the status up to date, as the scale loop still evaluates the metrics
+func (r *ScaledObjectReconciler) reconcileScaledObjectWithoutHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+		return err
+	}
+	_, err := r.getScaledObjectMetricSpecs(ctx, logger, scaledObject)
+	return err
+}
+
 // getScaledObjectMetricSpecs returns MetricSpec for HPA, generater from Triggers defitinion in ScaledObject
 func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ([]autoscalingv2.MetricSpec, error) {
 	var scaledObjectMetricSpecs []autoscalingv2.MetricSpec
@@ -254,54 +264,60 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 
 	updateHealthStatus(scaledObject, externalMetricNames, status)
 
-	// if ScalingModifiers struct is not empty, expect Formula and Target to be
-	// non-empty (is validated beforehand - in cache). Only if target is > 0.0
-	// create a compositeScaler structure
+	// if ScalingModifiers struct is not empty, expect Formula and Target of every
+	// composite metric to be non-empty (is validated beforehand - in cache). Only
+	// if target is > 0.0 create a compositeScaler structure
 	if scaledObject.IsUsingModifiers() {
-		// convert string to float (this is already validated in:
-		// cache, err := r.ScaleHandler.GetScalersCache(ctx, scaledObject.DeepCopy())
-		// at the beginning of this function, where the whole scalingModifiers are validated)
-		validNumTarget, _ := strconv.ParseFloat(scaledObject.Spec.Advanced.ScalingModifiers.Target, 64)
-
-		// check & get metric specs type
-		metricType := autoscalingv2.AverageValueMetricType
-		if scaledObject.Spec.Advanced.ScalingModifiers.MetricType != "" {
-			metricType = scaledObject.Spec.Advanced.ScalingModifiers.MetricType
-		}
-
-		if metricType == autoscalingv2.UtilizationMetricType {
-			err := fmt.Errorf("error metric target type is Utilization, but it needs to be AverageValue or Value for external metrics")
-			return nil, err
-		}
+		compositeSpecs := []autoscalingv2.MetricSpec{}
+		compositeNames := []string{}
+		for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+			// convert string to float (this is already validated in:
+			// cache, err := r.ScaleHandler.GetScalersCache(ctx, scaledObject.DeepCopy())
+			// at the beginning of this function, where the whole scalingModifiers are validated)
+			validNumTarget, _ := strconv.ParseFloat(composite.Target, 64)
+
+			// check & get metric specs type
+			metricType := composite.GetMetricType()
+			if metricType == autoscalingv2.UtilizationMetricType {
+				err := fmt.Errorf("error metric target type is Utilization, but it needs to be AverageValue or Value for external metrics")
+				return nil, err
+			}
 
-		// if target is valid, use composite scaler. Expect defined formula that returns one metric
-		if validNumTarget > 0.0 {
-			quan := resource.NewMilliQuantity(int64(validNumTarget*1000), resource.DecimalSI)
+			// if target is valid, use composite scaler. Expect defined formula that returns one metric
+			if validNumTarget > 0.0 {
+				quan := resource.NewMilliQuantity(int64(validNumTarget*1000), resource.DecimalSI)
 
-			correctHpaTarget := autoscalingv2.MetricTarget{
-				Type: metricType,
-			}
-			if metricType == autoscalingv2.AverageValueMetricType {
-				correctHpaTarget.AverageValue = quan
-			} else if metricType == autoscalingv2.ValueMetricType {
-				correctHpaTarget.Value = quan
-			}
-			compMetricName := kedav1alpha1.CompositeMetricName
-			compositeSpec := autoscalingv2.MetricSpec{
-				Type: autoscalingv2.MetricSourceType("External"),
-				External: &autoscalingv2.ExternalMetricSource{
-					Metric: autoscalingv2.MetricIdentifier{
-						Name: compMetricName,
-						Selector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{kedav1alpha1.ScaledObjectOwnerAnnotation: scaledObject.Name},
+				correctHpaTarget := autoscalingv2.MetricTarget{
+					Type: metricType,
+				}
+				if metricType == autoscalingv2.AverageValueMetricType {
+					correctHpaTarget.AverageValue = quan
+				} else if metricType == autoscalingv2.ValueMetricType {
+					correctHpaTarget.Value = quan
+				}
+				compMetricName := composite.MetricName()
+				compositeSpecs = append(compositeSpecs, autoscalingv2.MetricSpec{
+					Type: autoscalingv2.MetricSourceType("External"),
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: compMetricName,
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{kedav1alpha1.ScaledObjectOwnerAnnotation: scaledObject.Name},
+							},
 						},
+						Target: correctHpaTarget,
 					},
-					Target: correctHpaTarget,
-				},
+				})
+				compositeNames = append(compositeNames, compMetricName)
 			}
-			status.CompositeScalerName = compMetricName
+		}
+
+		if len(compositeSpecs) > 0 {
+			status.CompositeScalerName = compositeNames[0]
+			status.CompositeScalerNames = compositeNames
 
-			// overwrite external metrics in returned array with composite metric ONLY (keep resource metrics)
+			// overwrite external metrics in returned array with composite metrics ONLY (keep resource metrics),
+			// the HPA scales on the highest of them
 			finalHpaSpecs := []autoscalingv2.MetricSpec{}
 			// keep resource specs
 			for _, rm := range scaledObjectMetricSpecs {
@@ -309,7 +325,7 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 					finalHpaSpecs = append(finalHpaSpecs, rm)
 				}
 			}
-			finalHpaSpecs = append(finalHpaSpecs, compositeSpec)
+			finalHpaSpecs = append(finalHpaSpecs, compositeSpecs...)
 			scaledObjectMetricSpecs = finalHpaSpecs
 		}
 	}
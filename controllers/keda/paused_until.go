@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// resolvePausedUntil handles PausedUntilAnnotation of the ScaledObject or ScaledJob. A duration is converted into
+// the time relative to now and all the pause annotations are removed once the time passed. It returns whether the pause expired.
+func resolvePausedUntil(ctx context.Context, c client.Client, logger logr.Logger, object client.Object) (bool, error) {
+	value, found := object.GetAnnotations()[kedav1alpha1.PausedUntilAnnotation]
+	if !found {
+		return false, nil
+	}
+	now := time.Now()
+	deadline, isDuration, err := kedav1alpha1.ParsePausedUntil(value, now)
+	if err != nil {
+		logger.Error(err, "invalid pause expiration, the annotation is ignored")
+		return false, nil
+	}
+	expired := !deadline.After(now)
+	if !expired && !isDuration {
+		return false, nil
+	}
+
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	annotations := maps.Clone(object.GetAnnotations())
+	if expired {
+		kedav1alpha1.RemovePauseAnnotations(annotations)
+	} else {
+		annotations[kedav1alpha1.PausedUntilAnnotation] = deadline.UTC().Format(time.RFC3339)
+	}
+	object.SetAnnotations(annotations)
+	if err := c.Patch(ctx, object, patch); err != nil {
+		return false, err
+	}
+	if expired {
+		logger.Info("Pause expired, removed the pause annotations", "pausedUntil", value)
+	} else {
+		logger.V(1).Info("Resolved pause expiration", "pausedUntil", annotations[kedav1alpha1.PausedUntilAnnotation])
+	}
+	return expired, nil
+}
+
+// getPausedUntilResult requeues the object when its pause expires, so the pause annotations are removed on time
+func getPausedUntilResult(object metav1.Object) ctrl.Result {
+	deadline, found := kedav1alpha1.GetPausedUntil(object.GetAnnotations())
+	if !found {
+		return ctrl.Result{}
+	}
+	now := time.Now()
+	if !deadline.After(now) {
+		return ctrl.Result{Requeue: true}
+	}
+	// add a small margin, so the pause already expired when we reconcile again
+	return ctrl.Result{RequeueAfter: deadline.Sub(now) + time.Second}
+}
+
+// earliestResult returns the result which requeues the object first
+func earliestResult(results ...ctrl.Result) ctrl.Result {
+	earliest := ctrl.Result{}
+	for _, result := range results {
+		switch {
+		case result.RequeueAfter > 0 && (earliest.RequeueAfter == 0 || result.RequeueAfter < earliest.RequeueAfter):
+			earliest.RequeueAfter = result.RequeueAfter
+		case result.Requeue:
+			earliest.Requeue = true
+		}
+	}
+	return earliest
+}
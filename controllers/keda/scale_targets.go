@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// scaleTargetHPALabel marks the HPAs of the additional targets of scaleTargetRefs with the name of the target
+const scaleTargetHPALabel = "scaledobject.keda.sh/scale-target"
+
+// ensureHPAsForScaleTargetRefs ensures there is an up-to-date HPA for every additional target of scaleTargetRefs,
+// the HPAs are derived from the HPA of scaleTargetRef and owned by the ScaledObject, the HPAs of removed targets are deleted
+func (r *ScaledObjectReconciler) ensureHPAsForScaleTargetRefs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) error {
+	if !scaledObject.HasScaleTargetRefs() && len(scaledObject.Status.ScaleTargets) == 0 {
+		return nil
+	}
+
+	var targets []kedav1alpha1.ScaleTargetStatus
+	if scaledObject.HasScaleTargetRefs() {
+		baseHpa, err := r.newHPAForScaledObject(ctx, logger, scaledObject, gvkr)
+		if err != nil {
+			return err
+		}
+		for _, target := range scaledObject.Spec.ScaleTargetRefs {
+			targetGvkr, err := kedav1alpha1.ParseGVKR(r.restMapper, target.APIVersion, target.Kind)
+			if err != nil {
+				logger.Error(err, "Failed to parse Group, Version, Kind, Resource", "apiVersion", target.APIVersion, "kind", target.Kind)
+				return err
+			}
+			if _, err := r.ScaleClient.Scales(scaledObject.Namespace).Get(ctx, targetGvkr.GroupResource(), target.Name, metav1.GetOptions{}); err != nil {
+				logger.Error(err, "Failed to get the scale of the target", "resource", targetGvkr.GVKString(), "name", target.Name)
+				r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, fmt.Sprintf("scale target %s %s is not scalable", targetGvkr.GVKString(), target.Name))
+				return err
+			}
+
+			hpa := newHPAForScaleTarget(baseHpa, scaledObject, target, targetGvkr)
+			if err := r.createOrUpdateScaleTargetHPA(ctx, logger, scaledObject, hpa); err != nil {
+				return err
+			}
+			targets = append(targets, kedav1alpha1.ScaleTargetStatus{
+				Name:            target.Name,
+				ScaleTargetKind: targetGvkr.GVKString(),
+				ScaleTargetGVKR: &targetGvkr,
+				HpaName:         hpa.Name,
+				Weight:          target.Weight,
+			})
+		}
+	}
+
+	if err := r.deleteScaleTargetHPAs(ctx, logger, scaledObject, targets); err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(scaledObject.Status.ScaleTargets, targets) {
+		return nil
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.ScaleTargets = targets
+	return kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
+}
+
+// newHPAForScaleTarget returns the HPA of an additional target, the AverageValue targets of the external metrics
+// and the replica bounds are adjusted by the weight of the target
+func newHPAForScaleTarget(baseHpa *autoscalingv2.HorizontalPodAutoscaler, scaledObject *kedav1alpha1.ScaledObject, target kedav1alpha1.WeightedScaleTarget, gvkr kedav1alpha1.GroupVersionKindResource) *autoscalingv2.HorizontalPodAutoscaler {
+	weight := target.GetWeight()
+	hpa := baseHpa.DeepCopy()
+	hpa.Name = kedav1alpha1.GetScaleTargetHPAName(getHPAName(scaledObject), target.ScaleTarget)
+	hpa.Labels["app.kubernetes.io/name"] = truncateLabelValue(hpa.Name)
+	hpa.Labels[scaleTargetHPALabel] = truncateLabelValue(target.Name)
+	hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+		Name:       target.Name,
+		Kind:       gvkr.Kind,
+		APIVersion: gvkr.GroupVersion().String(),
+	}
+
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas := kedav1alpha1.WeightedReplicaCount(*hpa.Spec.MinReplicas, weight)
+		hpa.Spec.MinReplicas = &minReplicas
+	}
+	hpa.Spec.MaxReplicas = kedav1alpha1.WeightedReplicaCount(hpa.Spec.MaxReplicas, weight)
+	if hpa.Spec.MinReplicas != nil && hpa.Spec.MaxReplicas < *hpa.Spec.MinReplicas {
+		hpa.Spec.MaxReplicas = *hpa.Spec.MinReplicas
+	}
+
+	// the target gets weight times the replicas of scaleTargetRef for the same metric value
+	for i := range hpa.Spec.Metrics {
+		external := hpa.Spec.Metrics[i].External
+		if external == nil || external.Target.Type != autoscalingv2.AverageValueMetricType || external.Target.AverageValue == nil {
+			continue
+		}
+		milliValue := int64(math.Round(float64(external.Target.AverageValue.MilliValue()) / weight))
+		external.Target.AverageValue = resource.NewMilliQuantity(max(milliValue, 1), resource.DecimalSI)
+	}
+	return hpa
+}
+
+// createOrUpdateScaleTargetHPA creates the HPA of an additional target or updates it if it differs,
+// an existing HPA which is not controlled by the ScaledObject is never updated
+func (r *ScaledObjectReconciler) createOrUpdateScaleTargetHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	foundHpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: hpa.Name, Namespace: hpa.Namespace}, foundHpa)
+	if errors.IsNotFound(err) {
+		logger.Info("Creating a new HPA for scale target", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name, "scaleTarget", hpa.Spec.ScaleTargetRef.Name)
+		return r.Client.Create(ctx, hpa)
+	} else if err != nil {
+		logger.Error(err, "failed to get HPA from cluster")
+		return err
+	}
+
+	if !metav1.IsControlledBy(foundHpa, scaledObject) {
+		err := fmt.Errorf("the HPA %s of scale target %s is not managed by the ScaledObject", hpa.Name, hpa.Spec.ScaleTargetRef.Name)
+		logger.Error(err, "Failed to update HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, err.Error())
+		return err
+	}
+
+	if len(hpa.Spec.Metrics) == len(foundHpa.Spec.Metrics) && equality.Semantic.DeepDerivative(hpa.Spec, foundHpa.Spec) &&
+		equality.Semantic.DeepDerivative(hpa.Labels, foundHpa.Labels) {
+		return nil
+	}
+	hpa.ResourceVersion = foundHpa.ResourceVersion
+	if err := r.Client.Update(ctx, hpa); err != nil {
+		logger.Error(err, "Failed to update HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+		return err
+	}
+	logger.Info("Updated HPA of scale target according to ScaledObject", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+	return nil
+}
+
+// deleteScaleTargetHPAs deletes the HPAs of the additional targets which are not in keep
+func (r *ScaledObjectReconciler) deleteScaleTargetHPAs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, keep []kedav1alpha1.ScaleTargetStatus) error {
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := r.Client.List(ctx, hpaList, client.InNamespace(scaledObject.Namespace), client.HasLabels{scaleTargetHPALabel}); err != nil {
+		logger.Error(err, "failed to list HPAs of scale targets")
+		return err
+	}
+	for i := range hpaList.Items {
+		hpa := &hpaList.Items[i]
+		if !metav1.IsControlledBy(hpa, scaledObject) || isScaleTargetHPA(keep, hpa.Name) {
+			continue
+		}
+		if err := r.deleteHPA(ctx, logger, scaledObject, hpa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isScaleTargetHPA(targets []kedav1alpha1.ScaleTargetStatus, hpaName string) bool {
+	for _, target := range targets {
+		if target.HpaName == hpaName {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateLabelValue shortens the value to the 63 characters allowed in a label
+func truncateLabelValue(value string) string {
+	if len(value) <= 63 {
+		return value
+	}
+	return strings.TrimRightFunc(value[:63], func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestNewHPAForScaleTarget(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+	}
+	minReplicas := int32(2)
+	baseHpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keda-hpa-consumer",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/name": "keda-hpa-consumer"},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				Name:       "consumer",
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "s0-queue"},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "s1-lag"},
+						Target: autoscalingv2.MetricTarget{
+							Type:  autoscalingv2.ValueMetricType,
+							Value: resource.NewQuantity(100, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+	target := kedav1alpha1.WeightedScaleTarget{
+		ScaleTarget: kedav1alpha1.ScaleTarget{Name: "consumer-zone-b"},
+		Weight:      "0.5",
+	}
+	gvkr := kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+
+	hpa := newHPAForScaleTarget(baseHpa, scaledObject, target, gvkr)
+
+	assert.Equal(t, "keda-hpa-consumer-deployment-consumer-zone-b", hpa.Name)
+	assert.Equal(t, "consumer-zone-b", hpa.Labels[scaleTargetHPALabel])
+	assert.Equal(t, "consumer-zone-b", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	// half of the replicas for the same metric value
+	assert.Equal(t, int64(20), hpa.Spec.Metrics[0].External.Target.AverageValue.Value())
+	assert.Equal(t, int64(100), hpa.Spec.Metrics[1].External.Target.Value.Value())
+
+	// the base HPA is not modified
+	assert.Equal(t, "keda-hpa-consumer", baseHpa.Name)
+	assert.Equal(t, int64(10), baseHpa.Spec.Metrics[0].External.Target.AverageValue.Value())
+}
@@ -35,8 +35,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -46,6 +48,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
@@ -64,6 +67,8 @@ type ScaledJobReconciler struct {
 	scaleHandler         scaling.ScaleHandler
 	SecretsLister        corev1listers.SecretLister
 	SecretsSynced        cache.InformerSynced
+	// Sharding distributes the ScaledJobs between the operator replicas, nil if sharding is disabled
+	Sharding *sharding.Manager
 }
 
 type scaledJobMetricsData struct {
@@ -85,7 +90,7 @@ func init() {
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
 	r.scaledJobGenerations = &sync.Map{}
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
@@ -95,7 +100,18 @@ func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options control
 				predicate.GenerationChangedPredicate{},
 			))).
 		WithEventFilter(util.IgnoreOtherNamespaces()).
-		Complete(r)
+		// Reconcile the selected ScaledJobs when a ScalingFreeze or ClusterScalingFreeze changes
+		Watches(&kedav1alpha1.ScalingFreeze{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, freeze client.Object) []reconcile.Request {
+			return scalingFreezeRequests(ctx, r.Client, freeze, &kedav1alpha1.ScaledJobList{})
+		})).
+		Watches(&kedav1alpha1.ClusterScalingFreeze{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, freeze client.Object) []reconcile.Request {
+			return scalingFreezeRequests(ctx, r.Client, freeze, &kedav1alpha1.ScaledJobList{})
+		}))
+	if r.Sharding != nil {
+		// Reconcile the ScaledJobs of the shards which this replica acquired or released
+		b = b.WatchesRawSource(shardingSource(r.Sharding, r.Client, func() client.ObjectList { return &kedav1alpha1.ScaledJobList{} }))
+	}
+	return b.Complete(r)
 }
 
 // Reconcile performs reconciliation on the identified ScaledJob resource based on the request information passed, returns the result and an error (if any).
@@ -117,6 +133,14 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// The ScaledJob is handled by the replica owning its shard,
+	// stop the scale loop in case this replica owned the shard before
+	if !r.Sharding.Owns(scaledJob.Namespace, scaledJob.Name) {
+		reqLogger.V(1).Info("ScaledJob belongs to a shard of another replica, skipping reconcile")
+		r.updatePromMetricsOnDelete(req.NamespacedName.String())
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledJob)
+	}
+
 	reqLogger.Info("Reconciling ScaledJob")
 
 	// Check if the ScaledJob instance is marked to be deleted, which is
@@ -173,7 +197,7 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		reqLogger.Error(err, "Error updating TriggerAuthentication Status")
 	}
 
-	return ctrl.Result{}, err
+	return earliestResult(getPausedUntilResult(scaledJob), getScalingFreezeResult(ctx, r.Client, reqLogger, scaledJob)), err
 }
 
 // reconcileScaledJob implements reconciler logic for K8s Jobs based ScaledJob
@@ -186,11 +210,21 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 		return "ScaledJob is paused, skipping reconcile loop", err
 	}
 
+	// Check whether a ScalingFreeze or ClusterScalingFreeze selects the ScaledJob, no Job is created while it is frozen
+	if err := r.updateStatusWithScalingFreeze(ctx, logger, scaledJob, conditions); err != nil {
+		return "Cannot update ScaledJob status with active ScalingFreeze", err
+	}
+
 	err = kedav1alpha1.ValidateTriggers(scaledJob.Spec.Triggers)
 	if err != nil {
 		return "ScaledJob doesn't have correct triggers specification", err
 	}
 
+	err = kedav1alpha1.ValidateAdaptivePolling(scaledJob.Spec.PollingInterval, scaledJob.Spec.AdaptivePolling)
+	if err != nil {
+		return "ScaledJob doesn't have correct adaptivePolling specification", err
+	}
+
 	err = r.updateStatusWithTriggersAndAuthsTypes(ctx, logger, scaledJob)
 	if err != nil {
 		return "Cannot update ScaledJob status with triggers'names and authentications'names", err
@@ -230,12 +264,21 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 }
 
 // checkIfPaused checks the presence of "autoscaling.keda.sh/paused" annotation on the scaledJob and stop the scale loop.
+// The pause annotations are removed once "autoscaling.keda.sh/paused-until" expired.
 func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, conditions *kedav1alpha1.Conditions) (bool, error) {
+	pauseExpired, err := resolvePausedUntil(ctx, r.Client, logger, scaledJob)
+	if err != nil {
+		return false, err
+	}
+	if pauseExpired {
+		r.EventEmitter.Emit(scaledJob, scaledJob.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledJobReadyType, eventreason.ScaledJobPauseExpired, kedav1alpha1.ScaledJobConditionPauseExpiredMessage)
+	}
+
 	pausedAnnotationValue, pausedAnnotation := scaledJob.GetAnnotations()[kedav1alpha1.PausedAnnotation]
 	pausedStatus := conditions.GetPausedCondition().Status == metav1.ConditionTrue
-	shouldPause := false
+	// a valid "autoscaling.keda.sh/paused-until" on its own pauses the scaledJob until it expires
+	shouldPause := kedav1alpha1.HasPausedUntil(scaledJob.GetAnnotations())
 	if pausedAnnotation {
-		var err error
 		shouldPause, err = strconv.ParseBool(pausedAnnotationValue)
 		if err != nil {
 			shouldPause = true
@@ -256,8 +299,11 @@ func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Log
 	}
 	if pausedStatus {
 		logger.Info("Unpausing ScaledJob.")
-		msg := kedav1alpha1.ScaledJobConditionUnpausedMessage
-		conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionUnpausedReason, msg)
+		if pauseExpired {
+			conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionPauseExpiredReason, kedav1alpha1.ScaledJobConditionPauseExpiredMessage)
+		} else {
+			conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionUnpausedReason, kedav1alpha1.ScaledJobConditionUnpausedMessage)
+		}
 	}
 	return false, nil
 }
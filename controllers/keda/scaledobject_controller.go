@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -38,8 +39,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -50,6 +53,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
@@ -65,6 +69,10 @@ import (
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="",resources="limitranges",verbs=list;watch
+// +kubebuilder:rbac:groups=keda.sh,resources=scalingfreezes;clusterscalingfreezes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=keda.sh,resources=scalingbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjecttemplates;clusterscaledobjecttemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -73,6 +81,8 @@ type ScaledObjectReconciler struct {
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
+	// Sharding distributes the ScaledObjects between the operator replicas, nil if sharding is disabled
+	Sharding *sharding.Manager
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
@@ -122,7 +132,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		return fmt.Errorf("ScaledObjectReconciler.EventEmitter is not initialized")
 	}
 	// Start controller
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
@@ -131,6 +141,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.DryRunPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
@@ -144,7 +155,29 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 				predicate.AnnotationChangedPredicate{},
 				kedacontrollerutil.HPASpecChangedPredicate{},
 			))).
-		Complete(r)
+		// Reconcile the selected ScaledObjects when a ScalingFreeze or ClusterScalingFreeze changes
+		Watches(&kedav1alpha1.ScalingFreeze{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, freeze client.Object) []reconcile.Request {
+			return scalingFreezeRequests(ctx, r.Client, freeze, &kedav1alpha1.ScaledObjectList{})
+		})).
+		Watches(&kedav1alpha1.ClusterScalingFreeze{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, freeze client.Object) []reconcile.Request {
+			return scalingFreezeRequests(ctx, r.Client, freeze, &kedav1alpha1.ScaledObjectList{})
+		})).
+		// Reconcile the ScaledObjects referencing a ScaledObjectTemplate or ClusterScaledObjectTemplate when it changes
+		Watches(&kedav1alpha1.ScaledObjectTemplate{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, template client.Object) []reconcile.Request {
+			return scaledObjectTemplateRequests(ctx, r.Client, kedav1alpha1.ScaledObjectTemplateKind, template)
+		})).
+		Watches(&kedav1alpha1.ClusterScaledObjectTemplate{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, template client.Object) []reconcile.Request {
+			return scaledObjectTemplateRequests(ctx, r.Client, kedav1alpha1.ClusterScaledObjectTemplateKind, template)
+		})).
+		// Reconcile the members of a ScalingBudget when it is allocated again, so their HPAs follow the allocation
+		Watches(&kedav1alpha1.ScalingBudget{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, budget client.Object) []reconcile.Request {
+			return scalingBudgetRequests(ctx, r.Client, budget.(*kedav1alpha1.ScalingBudget))
+		}))
+	if r.Sharding != nil {
+		// Reconcile the ScaledObjects of the shards which this replica acquired or released
+		b = b.WatchesRawSource(shardingSource(r.Sharding, r.Client, func() client.ObjectList { return &kedav1alpha1.ScaledObjectList{} }))
+	}
+	return b.Complete(r)
 }
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
@@ -165,6 +198,14 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// The ScaledObject is handled by the replica owning its shard,
+	// stop the scale loop in case this replica owned the shard before
+	if !r.Sharding.Owns(scaledObject.Namespace, scaledObject.Name) {
+		reqLogger.V(1).Info("ScaledObject belongs to a shard of another replica, skipping reconcile")
+		r.updatePromMetricsOnDelete(req.NamespacedName.String())
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledObject)
+	}
+
 	reqLogger.Info("Reconciling ScaledObject")
 
 	// Check if the ScaledObject instance is marked to be deleted, which is
@@ -205,7 +246,7 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		conditions.SetReadyCondition(metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionReadySuccessReason, msg)
 	}
 
-	if scaledObject.Spec.Fallback == nil || !fallback.HasValidFallback(scaledObject) {
+	if !scaledObject.HasFallback() || !fallback.HasValidFallback(scaledObject) {
 		conditions.SetFallbackCondition(metav1.ConditionFalse, "NoFallbackFound", "No fallbacks are active on this scaled object")
 	}
 
@@ -220,11 +261,45 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		reqLogger.Error(err, "Failed to update TriggerAuthentication Status after removing a finalizer")
 	}
 
-	return ctrl.Result{}, err
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return earliestResult(getReplicaCountScheduleResult(scaledObject), getPausedUntilResult(scaledObject),
+		getScalingFreezeResult(ctx, r.Client, reqLogger, scaledObject)), nil
+}
+
+// getReplicaCountScheduleResult requeues the ScaledObject on the next replicaCountSchedule window transition,
+// so the HPA bounds and the status are updated when the effective replica counts change
+func getReplicaCountScheduleResult(scaledObject *kedav1alpha1.ScaledObject) ctrl.Result {
+	now := time.Now()
+	next, found := scaledObject.GetNextReplicaCountScheduleTransition(now)
+	if !found {
+		return ctrl.Result{}
+	}
+	// add a small margin, so the window is already opened/closed when we reconcile again
+	return ctrl.Result{RequeueAfter: next.Sub(now) + time.Second}
 }
 
 // reconcileScaledObject implements reconciler logic for ScaledObject
 func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, conditions *kedav1alpha1.Conditions) (string, error) {
+	// Render the spec of a ScaledObject referencing a template, the ScaledObject is reconciled with the rendered spec
+	renderedSpecChanged, err := r.updateStatusWithRenderedSpec(ctx, logger, scaledObject)
+	if err != nil {
+		return "failed to render ScaledObject from its template", err
+	}
+
+	// Remove the pause annotations once "autoscaling.keda.sh/paused-until" expired, the ScaledObject is unpaused below
+	pauseExpired, err := resolvePausedUntil(ctx, r.Client, logger, scaledObject)
+	if err != nil {
+		return "failed to resolve the pause expiration of ScaledObject", err
+	}
+	if pauseExpired {
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectReadyType, eventreason.ScaledObjectPauseExpired, kedav1alpha1.ScaledObjectConditionPauseExpiredMessage)
+		if conditions.GetPausedCondition().Status == metav1.ConditionTrue {
+			conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionPauseExpiredReason, kedav1alpha1.ScaledObjectConditionPauseExpiredMessage)
+		}
+	}
+
 	// Check the presence of "autoscaling.keda.sh/paused" annotation on the scaledObject (since the presence of this annotation will pause
 	// autoscaling no matter what number of replicas is provided), and if so, stop the scale loop and delete the HPA on the scaled object.
 	needsToPause := scaledObject.NeedToBePausedByAnnotation()
@@ -254,6 +329,16 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectUnpaused", "pause annotation removed for ScaledObject")
 	}
 
+	// Check whether a ScalingFreeze or ClusterScalingFreeze selects the ScaledObject, the HPA is deleted below while it is frozen
+	if err := r.updateStatusWithScalingFreeze(ctx, logger, scaledObject, conditions); err != nil {
+		return "Cannot update ScaledObject status with active ScalingFreeze", err
+	}
+
+	// Check whether a ScalingBudget limits the ScaledObject, the HPA max replicas are capped by its allocation
+	if err := r.updateStatusWithScalingBudget(ctx, logger, scaledObject); err != nil {
+		return "Cannot update ScaledObject status with ScalingBudget", err
+	}
+
 	// Check scale target Name is specified
 	if scaledObject.Spec.ScaleTargetRef.Name == "" {
 		err := fmt.Errorf("ScaledObject.spec.scaleTargetRef.name is missing")
@@ -261,7 +346,7 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 	}
 
 	// Check the label needed for Metrics servers is present on ScaledObject
-	err := r.ensureScaledObjectLabel(ctx, logger, scaledObject)
+	err = r.ensureScaledObjectLabel(ctx, logger, scaledObject)
 	if err != nil {
 		return "failed to update ScaledObject with scaledObjectName label", err
 	}
@@ -277,20 +362,63 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
+	err = kedav1alpha1.ValidateScaleTargetRefs(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct scaleTargetRefs specification", err
+	}
+
 	err = kedav1alpha1.ValidateTriggers(scaledObject.Spec.Triggers)
 	if err != nil {
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
+	err = kedav1alpha1.ValidateScalingMode(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct scalingMode specification", err
+	}
+
+	err = kedav1alpha1.ValidateAdaptivePolling(scaledObject.Spec.PollingInterval, scaledObject.Spec.AdaptivePolling)
+	if err != nil {
+		return "ScaledObject doesn't have correct adaptivePolling specification", err
+	}
+
 	err = r.updateStatusWithTriggersAndAuthsTypes(ctx, logger, scaledObject)
 	if err != nil {
 		return "Cannot update ScaledObject status with triggers'types and authentications'types", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+	err = r.updateStatusWithActiveReplicaCountSchedule(ctx, logger, scaledObject)
 	if err != nil {
-		return "failed to ensure HPA is correctly created for ScaledObject", err
+		return "Cannot update ScaledObject status with active replicaCountSchedule", err
+	}
+
+	newHPACreated := false
+	switch {
+	case scaledObject.IsDryRun():
+		// In dry-run mode KEDA only evaluates the scaling, the HPAs scaling the target are left untouched
+		// so a dry-run ScaledObject can be trialed against a workload which is already autoscaled
+		if _, err := r.getScaledObjectMetricSpecs(ctx, logger, scaledObject); err != nil {
+			return "failed to get metric specs of dry-run ScaledObject", err
+		}
+	case scaledObject.IsNativeScaling():
+		// In native scaling mode there is no HPA, the scale executor computes the replicas and scales the target
+		if err := r.reconcileScaledObjectWithoutHPA(ctx, logger, scaledObject); err != nil {
+			return "failed to ensure there is no HPA for ScaledObject in native scaling mode", err
+		}
+	case scaledObject.Status.ScalingFreeze != nil:
+		// While frozen there is no HPA scaling the target, the scale executor holds its replicas
+		if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+			return "failed to delete HPA for frozen ScaledObject", err
+		}
+	default:
+		// Create a new HPA or update existing one according to ScaledObject
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "failed to ensure HPA is correctly created for ScaledObject", err
+		}
+		if err := r.ensureHPAsForScaleTargetRefs(ctx, logger, scaledObject, &gvkr); err != nil {
+			return "failed to ensure HPAs are correctly created for scaleTargetRefs of ScaledObject", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -303,8 +431,8 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		}
 	}
 
-	// Notify ScaleHandler if a new HPA was created or if ScaledObject was updated
-	if newHPACreated || scaleObjectSpecChanged {
+	// Notify ScaleHandler if a new HPA was created or if ScaledObject was updated or rendered again from its template
+	if newHPACreated || scaleObjectSpecChanged || renderedSpecChanged {
 		if r.requestScaleLoop(ctx, logger, scaledObject) != nil {
 			return "failed to start a new scale loop with scaling logic", err
 		}
@@ -319,6 +447,8 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 // ensureScaledObjectLabel ensures that scaledobject.keda.sh/name=<scaledObject.Name> label exist in the ScaledObject
 // This is how the MetricsAdapter will know which ScaledObject a metric is for when the HPA queries it.
 func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	// patch only the label, the spec of a ScaledObject referencing a template is replaced by the rendered one in memory
+	patch := client.MergeFrom(scaledObject.DeepCopy())
 	if scaledObject.Labels == nil {
 		scaledObject.Labels = map[string]string{kedav1alpha1.ScaledObjectOwnerAnnotation: scaledObject.Name}
 	} else {
@@ -330,7 +460,7 @@ func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, lo
 	}
 
 	logger.V(1).Info("Adding \"scaledobject.keda.sh/name\" label on ScaledObject", "value", scaledObject.Name)
-	return r.Client.Update(ctx, scaledObject)
+	return r.Client.Patch(ctx, scaledObject, patch)
 }
 
 func (r *ScaledObjectReconciler) checkIfTargetResourceReachPausedCount(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) bool {
@@ -383,10 +513,12 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	// do we need the scale to update the status later?
 	present := scaledObject.HasPausedAnnotation()
 	removePausedStatus := scaledObject.Status.PausedReplicaCount != nil && !present
+	removeDryRunStatus := scaledObject.Status.DryRunReplicaCount != nil && !scaledObject.IsDryRun()
 	wantStatusUpdate := scaledObject.Status.ScaleTargetKind != gvkString ||
 		statusGvkString != gvkString ||
 		scaledObject.Status.OriginalReplicaCount == nil ||
-		removePausedStatus
+		removePausedStatus ||
+		removeDryRunStatus
 
 	// check if we already know.
 	var scale *autoscalingv1.Scale
@@ -432,6 +564,10 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 			status.PausedReplicaCount = nil
 		}
 
+		if removeDryRunStatus {
+			status.DryRunReplicaCount = nil
+		}
+
 		if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
 			return gvkr, err
 		}
@@ -483,6 +619,13 @@ func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Cont
 
 // ensureHPAForScaledObjectIsDeleted ensures that in cluster any HPA for specified ScaledObject is deleted, returns true if no HPA exists
 func (r *ScaledObjectReconciler) ensureHPAForScaledObjectIsDeleted(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (bool, error) {
+	// the HPAs of the additional targets are deleted together with the HPA of scaleTargetRef
+	if len(scaledObject.Status.ScaleTargets) > 0 {
+		if err := r.deleteScaleTargetHPAs(ctx, logger, scaledObject, nil); err != nil {
+			return false, err
+		}
+	}
+
 	hpaName := getHPANameOnEnsure(scaledObject)
 	foundHpa := &autoscalingv2.HorizontalPodAutoscaler{}
 	// Check if HPA for this ScaledObject already exists
@@ -637,3 +780,19 @@ func (r *ScaledObjectReconciler) updateStatusWithTriggersAndAuthsTypes(ctx conte
 
 	return kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
 }
+
+func (r *ScaledObjectReconciler) updateStatusWithActiveReplicaCountSchedule(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	activeSchedule := ""
+	if schedule := scaledObject.GetActiveReplicaCountSchedule(time.Now()); schedule != nil {
+		activeSchedule = schedule.Name
+	}
+	if scaledObject.Status.ActiveReplicaCountSchedule == activeSchedule {
+		return nil
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.ActiveReplicaCountSchedule = activeSchedule
+
+	logger.Info("Updating ScaledObject status with active replicaCountSchedule", "activeReplicaCountSchedule", activeSchedule)
+
+	return kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
+}
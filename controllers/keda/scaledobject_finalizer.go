@@ -46,8 +46,14 @@ func (r *ScaledObjectReconciler) finalizeScaledObject(ctx context.Context, logge
 			return err
 		}
 
+		// the advanced config of a ScaledObject referencing a template may be provided by the template
+		advanced := scaledObject.Spec.Advanced
+		if scaledObject.Spec.TemplateRef != nil && scaledObject.Status.RenderedSpec != nil {
+			advanced = scaledObject.Status.RenderedSpec.Advanced
+		}
+
 		// if enabled, scale scaleTarget back to the original replica count (to the state it was before scaling with KEDA)
-		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.RestoreToOriginalReplicaCount {
+		if advanced != nil && advanced.RestoreToOriginalReplicaCount {
 			// If the scaling hasn't been yet initialized (for example due to the missing scaleTarget), we don't have the GVKR information about the scaleTarget.
 			// Thus we don't have enough information needed to properly set the number of replicas on the scaleTarget.
 			// Let's skip in this case.
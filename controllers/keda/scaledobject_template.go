@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// updateStatusWithRenderedSpec renders the spec of a ScaledObject referencing a template, records it in the status
+// and replaces the spec of the ScaledObject by it in memory. It returns true if the rendered spec changed, so that
+// the changes of the template are rolled out although the generation of the ScaledObject doesn't change.
+func (r *ScaledObjectReconciler) updateStatusWithRenderedSpec(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (bool, error) {
+	if scaledObject.Spec.TemplateRef == nil {
+		if scaledObject.Status.RenderedSpec == nil {
+			return false, nil
+		}
+		status := scaledObject.Status.DeepCopy()
+		status.RenderedSpec = nil
+		return false, kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
+	}
+
+	templateRef := scaledObject.Spec.TemplateRef
+	spec, err := kedav1alpha1.GetScaledObjectTemplateSpec(ctx, r.Client, scaledObject)
+	if err != nil {
+		return false, fmt.Errorf("error getting %s %s: %w", templateRef.GetKind(), templateRef.Name, err)
+	}
+	rendered, err := spec.Render(scaledObject)
+	if err != nil {
+		return false, fmt.Errorf("error rendering %s %s: %w", templateRef.GetKind(), templateRef.Name, err)
+	}
+
+	changed := !equality.Semantic.DeepEqual(rendered, scaledObject.Status.RenderedSpec)
+	if changed {
+		status := scaledObject.Status.DeepCopy()
+		status.RenderedSpec = rendered
+		if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return false, err
+		}
+		logger.Info("Rendered ScaledObject from its template", "kind", templateRef.GetKind(), "name", templateRef.Name)
+	}
+	scaledObject.ApplyRenderedSpec()
+	return changed, nil
+}
+
+// scaledObjectTemplateRequests returns the requests for the ScaledObjects referencing the template
+func scaledObjectTemplateRequests(ctx context.Context, c client.Client, kind string, template client.Object) []reconcile.Request {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := c.List(ctx, scaledObjects, client.InNamespace(template.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "error listing ScaledObjects referencing template", "kind", kind, "name", template.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, scaledObject := range scaledObjects.Items {
+		templateRef := scaledObject.Spec.TemplateRef
+		if templateRef != nil && templateRef.References(kind, template.GetNamespace(), template.GetName(), scaledObject.Namespace) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}})
+		}
+	}
+	return requests
+}
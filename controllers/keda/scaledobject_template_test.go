@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestUpdateStatusWithRenderedSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	template := &kedav1alpha1.ClusterScaledObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "queue"},
+		Spec: kedav1alpha1.ScaledObjectTemplateSpec{
+			Parameters: []kedav1alpha1.TemplateParameter{{Name: "queue"}},
+			Template:   runtime.RawExtension{Raw: []byte(`{"triggers": [{"type": "rabbitmq", "metadata": {"queueName": "${queue}", "value": "10"}}]}`)},
+		},
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "consumer"},
+			TemplateRef: &kedav1alpha1.ScaledObjectTemplateRef{
+				Name:       "queue",
+				Kind:       kedav1alpha1.ClusterScaledObjectTemplateKind,
+				Parameters: map[string]string{"queue": "orders"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(template, scaledObject).
+		WithStatusSubresource(scaledObject).
+		Build()
+	r := &ScaledObjectReconciler{Client: c}
+
+	changed, err := r.updateStatusWithRenderedSpec(context.Background(), logr.Discard(), scaledObject)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "orders", scaledObject.Spec.Triggers[0].Metadata["queueName"])
+
+	stored := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, stored))
+	assert.Empty(t, stored.Spec.Triggers)
+	require.NotNil(t, stored.Status.RenderedSpec)
+	assert.Equal(t, "orders", stored.Status.RenderedSpec.Triggers[0].Metadata["queueName"])
+
+	// rendering again without changes of the template doesn't roll out anything
+	changed, err = r.updateStatusWithRenderedSpec(context.Background(), logr.Discard(), stored)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// a change of the template is rolled out
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "queue"}, template))
+	template.Spec.Template.Raw = []byte(`{"triggers": [{"type": "rabbitmq", "metadata": {"queueName": "${queue}", "value": "20"}}]}`)
+	require.NoError(t, c.Update(context.Background(), template))
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, stored))
+	changed, err = r.updateStatusWithRenderedSpec(context.Background(), logr.Discard(), stored)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "20", stored.Spec.Triggers[0].Metadata["value"])
+}
+
+func TestScaledObjectTemplateRequests(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	newScaledObject := func(name, namespace string, templateRef *kedav1alpha1.ScaledObjectTemplateRef) *kedav1alpha1.ScaledObject {
+		return &kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: name}, TemplateRef: templateRef},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newScaledObject("orders", "default", &kedav1alpha1.ScaledObjectTemplateRef{Name: "queue"}),
+		newScaledObject("payments", "default", &kedav1alpha1.ScaledObjectTemplateRef{Name: "queue", Kind: kedav1alpha1.ClusterScaledObjectTemplateKind}),
+		newScaledObject("invoices", "billing", &kedav1alpha1.ScaledObjectTemplateRef{Name: "queue", Kind: kedav1alpha1.ClusterScaledObjectTemplateKind}),
+		newScaledObject("api", "default", nil),
+	).Build()
+
+	requests := scaledObjectTemplateRequests(context.Background(), c, kedav1alpha1.ScaledObjectTemplateKind,
+		&kedav1alpha1.ScaledObjectTemplate{ObjectMeta: metav1.ObjectMeta{Name: "queue", Namespace: "default"}})
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "orders", Namespace: "default"}}}, requests)
+
+	requests = scaledObjectTemplateRequests(context.Background(), c, kedav1alpha1.ClusterScaledObjectTemplateKind,
+		&kedav1alpha1.ClusterScaledObjectTemplate{ObjectMeta: metav1.ObjectMeta{Name: "queue"}})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "payments", Namespace: "default"}},
+		{NamespacedName: types.NamespacedName{Name: "invoices", Namespace: "billing"}},
+	}, requests)
+}
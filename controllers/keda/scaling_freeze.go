@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// getActiveScalingFreeze returns the active ScalingFreeze or ClusterScalingFreeze selecting the object, ScalingFreezes
+// take precedence over ClusterScalingFreezes and freezes of the same kind are ordered by name. It also returns the time
+// when any of the selecting freezes starts or ends next, zero if none of them has a schedule.
+func getActiveScalingFreeze(ctx context.Context, c client.Client, logger logr.Logger, object client.Object, now time.Time) (*kedav1alpha1.ScalingFreezeStatus, time.Time, error) {
+	var active *kedav1alpha1.ScalingFreezeStatus
+	var nextTransition time.Time
+	evaluate := func(kind, name string, spec *kedav1alpha1.ScalingFreezeSpec) {
+		if err := spec.Validate(); err != nil {
+			logger.Error(err, "invalid scaling freeze, ignoring it", "kind", kind, "name", name)
+			return
+		}
+		if next, found, _ := spec.NextTransition(now); found && (nextTransition.IsZero() || next.Before(nextTransition)) {
+			nextTransition = next
+		}
+		if isActive, _ := spec.IsActive(now); isActive && active == nil {
+			active = &kedav1alpha1.ScalingFreezeStatus{Kind: kind, Name: name, ReplicaCount: spec.ReplicaCount}
+		}
+	}
+
+	freezes := &kedav1alpha1.ScalingFreezeList{}
+	if err := c.List(ctx, freezes, client.InNamespace(object.GetNamespace())); err != nil {
+		return nil, time.Time{}, fmt.Errorf("error listing ScalingFreezes: %w", err)
+	}
+	sort.Slice(freezes.Items, func(i, j int) bool { return freezes.Items[i].Name < freezes.Items[j].Name })
+	for i := range freezes.Items {
+		freeze := &freezes.Items[i]
+		if selectorMatches(freeze.Spec.Selector, object.GetLabels()) {
+			evaluate(kedav1alpha1.ScalingFreezeKind, freeze.Name, &freeze.Spec)
+		}
+	}
+
+	clusterFreezes := &kedav1alpha1.ClusterScalingFreezeList{}
+	if err := c.List(ctx, clusterFreezes); err != nil {
+		return nil, time.Time{}, fmt.Errorf("error listing ClusterScalingFreezes: %w", err)
+	}
+	sort.Slice(clusterFreezes.Items, func(i, j int) bool { return clusterFreezes.Items[i].Name < clusterFreezes.Items[j].Name })
+	var namespaceLabels map[string]string
+	for i := range clusterFreezes.Items {
+		freeze := &clusterFreezes.Items[i]
+		if !selectorMatches(freeze.Spec.Selector, object.GetLabels()) {
+			continue
+		}
+		if freeze.Spec.NamespaceSelector != nil {
+			// the namespace is fetched only when some ClusterScalingFreeze selects namespaces
+			if namespaceLabels == nil {
+				namespace := &corev1.Namespace{}
+				if err := c.Get(ctx, types.NamespacedName{Name: object.GetNamespace()}, namespace); err != nil {
+					return nil, time.Time{}, fmt.Errorf("error getting namespace %s: %w", object.GetNamespace(), err)
+				}
+				namespaceLabels = namespace.Labels
+				if namespaceLabels == nil {
+					namespaceLabels = map[string]string{}
+				}
+			}
+			if !selectorMatches(freeze.Spec.NamespaceSelector, namespaceLabels) {
+				continue
+			}
+		}
+		evaluate(kedav1alpha1.ClusterScalingFreezeKind, freeze.Name, &freeze.Spec)
+	}
+	return active, nextTransition, nil
+}
+
+// selectorMatches returns whether the label selector selects the labels, a nil selector selects everything
+func selectorMatches(labelSelector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	if labelSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objectLabels))
+}
+
+// getScalingFreezeResult requeues the object on the next transition of the freezes selecting it,
+// so it is frozen and unfrozen on time
+func getScalingFreezeResult(ctx context.Context, c client.Client, logger logr.Logger, object client.Object) ctrl.Result {
+	now := time.Now()
+	_, next, err := getActiveScalingFreeze(ctx, c, logger, object, now)
+	if err != nil || next.IsZero() {
+		return ctrl.Result{}
+	}
+	// add a small margin, so the freeze already started/ended when we reconcile again
+	return ctrl.Result{RequeueAfter: next.Sub(now) + time.Second}
+}
+
+// updateStatusWithScalingFreeze records the active ScalingFreeze or ClusterScalingFreeze in the ScaledObject status,
+// the scale executor holds the replicas of the target while the ScaledObject is frozen
+func (r *ScaledObjectReconciler) updateStatusWithScalingFreeze(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, conditions *kedav1alpha1.Conditions) error {
+	freeze, _, err := getActiveScalingFreeze(ctx, r.Client, logger, scaledObject, time.Now())
+	if err != nil {
+		return err
+	}
+	previous := scaledObject.Status.ScalingFreeze
+	if freeze != nil {
+		conditions.SetPausedCondition(metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionScalingFreezeActiveReason, fmt.Sprintf("ScaledObject is frozen by %s", freeze))
+	} else if previous != nil {
+		conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionScalingFreezeEndedReason, fmt.Sprintf("%s of ScaledObject ended", previous))
+	}
+	if equality.Semantic.DeepEqual(freeze, previous) {
+		return nil
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.ScalingFreeze = freeze
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+		return err
+	}
+	if freeze != nil {
+		logger.Info("ScaledObject is frozen", "scalingFreeze", freeze.String())
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectReadyType, eventreason.ScaledObjectFrozen, fmt.Sprintf("ScaledObject is frozen by %s", freeze))
+	} else {
+		logger.Info("ScaledObject is unfrozen", "scalingFreeze", previous.String())
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectReadyType, eventreason.ScaledObjectUnfrozen, fmt.Sprintf("%s of ScaledObject ended", previous))
+	}
+	return nil
+}
+
+// updateStatusWithScalingFreeze records the active ScalingFreeze or ClusterScalingFreeze in the ScaledJob status,
+// the scale executor doesn't create any Job while the ScaledJob is frozen
+func (r *ScaledJobReconciler) updateStatusWithScalingFreeze(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, conditions *kedav1alpha1.Conditions) error {
+	freeze, _, err := getActiveScalingFreeze(ctx, r.Client, logger, scaledJob, time.Now())
+	if err != nil {
+		return err
+	}
+	previous := scaledJob.Status.ScalingFreeze
+	if freeze != nil {
+		conditions.SetPausedCondition(metav1.ConditionTrue, kedav1alpha1.ScaledJobConditionScalingFreezeActiveReason, fmt.Sprintf("ScaledJob is frozen by %s", freeze))
+	} else if previous != nil {
+		conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionScalingFreezeEndedReason, fmt.Sprintf("%s of ScaledJob ended", previous))
+	}
+	if equality.Semantic.DeepEqual(freeze, previous) {
+		return nil
+	}
+
+	status := scaledJob.Status.DeepCopy()
+	status.ScalingFreeze = freeze
+	if err := kedastatus.UpdateScaledJobStatus(ctx, r.Client, logger, scaledJob, status); err != nil {
+		return err
+	}
+	if freeze != nil {
+		logger.Info("ScaledJob is frozen", "scalingFreeze", freeze.String())
+		r.EventEmitter.Emit(scaledJob, scaledJob.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledJobReadyType, eventreason.ScaledJobFrozen, fmt.Sprintf("ScaledJob is frozen by %s", freeze))
+	} else {
+		logger.Info("ScaledJob is unfrozen", "scalingFreeze", previous.String())
+		r.EventEmitter.Emit(scaledJob, scaledJob.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledJobReadyType, eventreason.ScaledJobUnfrozen, fmt.Sprintf("%s of ScaledJob ended", previous))
+	}
+	return nil
+}
+
+// scalingFreezeRequests maps a ScalingFreeze or ClusterScalingFreeze to reconcile requests of the objects its selector selects,
+// the namespaceSelector is evaluated when the objects are reconciled
+func scalingFreezeRequests(ctx context.Context, c client.Client, freeze client.Object, list client.ObjectList) []reconcile.Request {
+	var selector *metav1.LabelSelector
+	var opts []client.ListOption
+	switch f := freeze.(type) {
+	case *kedav1alpha1.ScalingFreeze:
+		selector = f.Spec.Selector
+		opts = append(opts, client.InNamespace(f.Namespace))
+	case *kedav1alpha1.ClusterScalingFreeze:
+		selector = f.Spec.Selector
+	default:
+		return nil
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "error listing objects selected by scaling freeze", "name", freeze.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	appendRequest := func(object metav1.Object) {
+		if selectorMatches(selector, object.GetLabels()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}})
+		}
+	}
+	switch l := list.(type) {
+	case *kedav1alpha1.ScaledObjectList:
+		for i := range l.Items {
+			appendRequest(&l.Items[i])
+		}
+	case *kedav1alpha1.ScaledJobList:
+		for i := range l.Items {
+			appendRequest(&l.Items[i])
+		}
+	}
+	return requests
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetActiveScalingFreeze(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	replicaCount := int32(3)
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"env": "production"}}}
+	scheduledFreeze := &kedav1alpha1.ScalingFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-backup", Namespace: "payments"},
+		Spec: kedav1alpha1.ScalingFreezeSpec{
+			Start:    "0 22 * * *",
+			Duration: metav1.Duration{Duration: 2 * time.Hour},
+		},
+	}
+	otherTeamFreeze := &kedav1alpha1.ScalingFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "payments"},
+		Spec: kedav1alpha1.ScalingFreezeSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "checkout"}},
+		},
+	}
+	clusterFreeze := &kedav1alpha1.ClusterScalingFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "release"},
+		Spec: kedav1alpha1.ScalingFreezeSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+			ReplicaCount:      &replicaCount,
+		},
+	}
+	stagingFreeze := &kedav1alpha1.ClusterScalingFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-staging"},
+		Spec: kedav1alpha1.ScalingFreezeSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}},
+		},
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments", Labels: map[string]string{"team": "api"}},
+	}
+
+	tests := []struct {
+		name               string
+		objects            []runtime.Object
+		expectedFreeze     *kedav1alpha1.ScalingFreezeStatus
+		expectedTransition time.Time
+	}{
+		{
+			name:    "no freeze",
+			objects: []runtime.Object{namespace},
+		},
+		{
+			name:               "inactive and not selecting freezes",
+			objects:            []runtime.Object{namespace, scheduledFreeze, otherTeamFreeze, stagingFreeze},
+			expectedTransition: time.Date(2025, time.March, 3, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "cluster freeze selecting the namespace",
+			objects:            []runtime.Object{namespace, scheduledFreeze, otherTeamFreeze, stagingFreeze, clusterFreeze},
+			expectedFreeze:     &kedav1alpha1.ScalingFreezeStatus{Kind: kedav1alpha1.ClusterScalingFreezeKind, Name: "release", ReplicaCount: &replicaCount},
+			expectedTransition: time.Date(2025, time.March, 3, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.objects...).Build()
+
+			freeze, transition, err := getActiveScalingFreeze(context.TODO(), client, logr.Discard(), scaledObject, now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFreeze, freeze)
+			assert.True(t, test.expectedTransition.Equal(transition), "expected %s, got %s", test.expectedTransition, transition)
+		})
+	}
+}
+
+func TestGetActiveScalingFreezePrefersNamespacedFreeze(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		&kedav1alpha1.ClusterScalingFreeze{ObjectMeta: metav1.ObjectMeta{Name: "a-release"}},
+		&kedav1alpha1.ScalingFreeze{ObjectMeta: metav1.ObjectMeta{Name: "z-maintenance", Namespace: "payments"}},
+		&kedav1alpha1.ScalingFreeze{ObjectMeta: metav1.ObjectMeta{Name: "b-maintenance", Namespace: "payments"}},
+	).Build()
+	scaledJob := &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "payments"}}
+
+	freeze, _, err := getActiveScalingFreeze(context.TODO(), client, logr.Discard(), scaledJob, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, &kedav1alpha1.ScalingFreezeStatus{Kind: kedav1alpha1.ScalingFreezeKind, Name: "b-maintenance"}, freeze)
+}
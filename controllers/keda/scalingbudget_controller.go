@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/scale"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// ScalingBudgetReconciler allocates a ScalingBudget among the ScaledObjects it selects, the allocation
+// is recorded in the ScalingBudget status and picked up by the ScaledObjectReconciler
+type ScalingBudgetReconciler struct {
+	client.Client
+	ScaleClient scale.ScalesGetter
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=scalingbudgets;scalingbudgets/status,verbs=get;list;watch;update;patch
+
+// Reconcile allocates the ScalingBudget from the current replicas of its members and requeues it after its pollingInterval,
+// so the allocation follows the members as they scale
+func (r *ScalingBudgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	budget := &kedav1alpha1.ScalingBudget{}
+	if err := r.Client.Get(ctx, req.NamespacedName, budget); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get ScalingBudget")
+		return ctrl.Result{}, err
+	}
+	if budget.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	var status kedav1alpha1.ScalingBudgetStatus
+	if err := budget.Spec.Validate(); err != nil {
+		// an invalid budget doesn't limit its members
+		reqLogger.Error(err, "invalid scaling budget, ignoring it")
+	} else {
+		usages, err := r.getScalingBudgetUsages(ctx, reqLogger, budget)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status = budget.Spec.Allocate(usages)
+	}
+
+	if !equality.Semantic.DeepEqual(status, budget.Status) {
+		patch := client.MergeFrom(budget.DeepCopy())
+		budget.Status = status
+		if err := r.Client.Status().Patch(ctx, budget, patch); err != nil {
+			reqLogger.Error(err, "Failed to update ScalingBudget status")
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info("Allocated ScalingBudget", "replicas", status.Replicas, "members", len(status.Members))
+	}
+
+	return ctrl.Result{RequeueAfter: time.Duration(budget.Spec.GetPollingInterval()) * time.Second}, nil
+}
+
+// getScalingBudgetUsages returns the usages of the ScaledObjects selected by the budget, a member whose scale target
+// can't be inspected is counted without replicas
+func (r *ScalingBudgetReconciler) getScalingBudgetUsages(ctx context.Context, logger logr.Logger, budget *kedav1alpha1.ScalingBudget) ([]kedav1alpha1.ScalingBudgetUsage, error) {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects, client.InNamespace(budget.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing ScaledObjects: %w", err)
+	}
+
+	withRequests := budget.Spec.MaxCPU != nil || budget.Spec.MaxMemory != nil
+	var usages []kedav1alpha1.ScalingBudgetUsage
+	for i := range scaledObjects.Items {
+		scaledObject := &scaledObjects.Items[i]
+		scaledObject.ApplyRenderedSpec()
+		if scaledObject.GetDeletionTimestamp() != nil || !budget.Spec.Selects(scaledObject.Labels) {
+			continue
+		}
+
+		var replicas int32
+		var podRequests corev1.ResourceList
+		if scaledObject.Status.ScaleTargetGVKR != nil {
+			replicas, podRequests = r.getScaleTargetUsage(ctx, logger, scaledObject, withRequests)
+		}
+		// the additional targets of scaleTargetRefs are scaled along with scaleTargetRef and count towards the budget as well
+		var targets []kedav1alpha1.ScalingBudgetTargetUsage
+		for _, target := range scaledObject.Status.ScaleTargets {
+			if target.ScaleTargetGVKR == nil {
+				continue
+			}
+			targetReplicas, targetPodRequests := r.getScaleTargetUsage(ctx, logger, getScaleTargetScaledObject(scaledObject, target), withRequests)
+			targets = append(targets, kedav1alpha1.ScalingBudgetTargetUsage{
+				Weight:      target.GetWeight(),
+				Replicas:    targetReplicas,
+				PodRequests: targetPodRequests,
+			})
+		}
+		usages = append(usages, kedav1alpha1.NewScalingBudgetUsage(scaledObject, replicas, podRequests, targets))
+	}
+	return usages, nil
+}
+
+// getScaleTargetUsage returns the current replicas and, if requested, the pod requests of the scale target of the ScaledObject,
+// a scale target which can't be inspected is counted without replicas
+func (r *ScalingBudgetReconciler) getScaleTargetUsage(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, withRequests bool) (int32, corev1.ResourceList) {
+	replicas, err := resolver.GetCurrentReplicas(ctx, r.Client, r.ScaleClient, scaledObject)
+	if err != nil {
+		logger.Error(err, "error getting the replicas of scaling budget member", "scaledObject.Name", scaledObject.Name, "scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+	}
+	var podRequests corev1.ResourceList
+	if withRequests {
+		podRequests, err = getScaleTargetPodRequests(ctx, r.Client, scaledObject)
+		if err != nil {
+			logger.Error(err, "error getting the pod requests of scaling budget member", "scaledObject.Name", scaledObject.Name, "scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+		}
+	}
+	return replicas, podRequests
+}
+
+// getScaleTargetScaledObject returns a copy of the ScaledObject with an additional target of scaleTargetRefs as its scaleTargetRef,
+// so the target can be inspected like scaleTargetRef
+func getScaleTargetScaledObject(scaledObject *kedav1alpha1.ScaledObject, target kedav1alpha1.ScaleTargetStatus) *kedav1alpha1.ScaledObject {
+	targetScaledObject := scaledObject.DeepCopy()
+	targetScaledObject.Spec.ScaleTargetRef = &kedav1alpha1.ScaleTarget{Name: target.Name}
+	targetScaledObject.Status.ScaleTargetGVKR = target.ScaleTargetGVKR
+	return targetScaledObject
+}
+
+// getScaleTargetPodRequests returns the sum of the resource requests of the containers of a pod of the scale target
+func getScaleTargetPodRequests(ctx context.Context, c client.Client, scaledObject *kedav1alpha1.ScaledObject) (corev1.ResourceList, error) {
+	podTemplateSpec, _, err := resolver.ResolveScaleTargetPodSpec(ctx, c, scaledObject)
+	if err != nil || podTemplateSpec == nil {
+		return nil, err
+	}
+	requests := corev1.ResourceList{}
+	for _, container := range podTemplateSpec.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+	return requests, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScalingBudgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.ScalingBudget{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Allocate the budgets again when their members are added, removed or change their replica counts or priority
+		Watches(&kedav1alpha1.ScaledObject{}, handler.EnqueueRequestsFromMapFunc(r.scaledObjectRequests),
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			))).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
+
+// scaledObjectRequests maps a ScaledObject to reconcile requests of the ScalingBudgets selecting it or listing it as a member
+func (r *ScalingBudgetReconciler) scaledObjectRequests(ctx context.Context, scaledObject client.Object) []reconcile.Request {
+	budgets := &kedav1alpha1.ScalingBudgetList{}
+	if err := r.Client.List(ctx, budgets, client.InNamespace(scaledObject.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "error listing scaling budgets selecting ScaledObject", "name", scaledObject.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, budget := range budgets.Items {
+		_, member := budget.Status.GetMemberMaxReplicas(scaledObject.GetName())
+		if member || budget.Spec.Selects(scaledObject.GetLabels()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: budget.Namespace, Name: budget.Name}})
+		}
+	}
+	return requests
+}
+
+// updateStatusWithScalingBudget records the ScalingBudget limiting the ScaledObject in its status,
+// the HPA and native scaling don't scale the target beyond its MaxReplicas
+func (r *ScaledObjectReconciler) updateStatusWithScalingBudget(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	allocation, err := kedav1alpha1.GetScalingBudgetAllocation(ctx, r.Client, scaledObject)
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(allocation, scaledObject.Status.ScalingBudget) {
+		return nil
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.ScalingBudget = allocation
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+		return err
+	}
+	if allocation != nil {
+		logger.V(1).Info("ScaledObject is limited by scaling budget", "scalingBudget", allocation.Name, "maxReplicas", allocation.MaxReplicas)
+	}
+	return nil
+}
+
+// scalingBudgetRequests maps a ScalingBudget to reconcile requests of the ScaledObjects it selects or lists as members
+func scalingBudgetRequests(ctx context.Context, c client.Client, budget *kedav1alpha1.ScalingBudget) []reconcile.Request {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := c.List(ctx, scaledObjects, client.InNamespace(budget.Namespace)); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "error listing ScaledObjects selected by scaling budget", "name", budget.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, scaledObject := range scaledObjects.Items {
+		_, member := budget.Status.GetMemberMaxReplicas(scaledObject.Name)
+		if member || budget.Spec.Selects(scaledObject.Labels) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}})
+		}
+	}
+	return requests
+}
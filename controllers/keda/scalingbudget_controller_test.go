@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newScalingBudgetMember(name string, priority string, replicas int32, cpu string) (*kedav1alpha1.ScaledObject, *appsv1.Deployment) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "shared",
+			Labels:      map[string]string{"budget": "shared"},
+			Annotations: map[string]string{kedav1alpha1.ScalingBudgetPriorityAnnotation: priority},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: name},
+			MaxReplicaCount: ptr.To[int32](20),
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shared"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:      name,
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+				}}},
+			},
+		},
+	}
+	return scaledObject, deployment
+}
+
+func TestScalingBudgetReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	api, apiDeployment := newScalingBudgetMember("api", "10", 4, "500m")
+	batch, batchDeployment := newScalingBudgetMember("batch", "0", 4, "500m")
+	other, otherDeployment := newScalingBudgetMember("other", "0", 4, "500m")
+	other.Labels = nil
+	budget := &kedav1alpha1.ScalingBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "shared"},
+		Spec: kedav1alpha1.ScalingBudgetSpec{
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"budget": "shared"}},
+			MaxReplicas: ptr.To[int32](10),
+			MaxCPU:      ptr.To(resource.MustParse("4")),
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(api, apiDeployment, batch, batchDeployment, other, otherDeployment, budget).
+		WithStatusSubresource(budget).
+		Build()
+	r := &ScalingBudgetReconciler{Client: c}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "shared", Namespace: "shared"}})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, result)
+
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "shared", Namespace: "shared"}, budget))
+	assert.Equal(t, int32(8), budget.Status.Replicas)
+	assert.Equal(t, int64(4000), budget.Status.CPU.MilliValue())
+	// api may take all but the minimum replica of batch, batch only what api leaves, cpu limits both to 8 replicas
+	assert.Equal(t, []kedav1alpha1.ScalingBudgetMember{
+		{Name: "api", Priority: 10, Replicas: 4, MaxReplicas: 7},
+		{Name: "batch", Priority: 0, Replicas: 4, MaxReplicas: 4},
+	}, budget.Status.Members)
+
+	allocation, err := kedav1alpha1.GetScalingBudgetAllocation(context.Background(), c, api)
+	require.NoError(t, err)
+	assert.Equal(t, &kedav1alpha1.ScalingBudgetAllocation{Name: "shared", MaxReplicas: 7}, allocation)
+	allocation, err = kedav1alpha1.GetScalingBudgetAllocation(context.Background(), c, other)
+	require.NoError(t, err)
+	assert.Nil(t, allocation)
+}
+
+func TestGetScalingBudgetAllocation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shared", Labels: map[string]string{"team": "api"}},
+	}
+	newBudget := func(name string, maxReplicas int32, selector map[string]string) *kedav1alpha1.ScalingBudget {
+		return &kedav1alpha1.ScalingBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shared"},
+			Spec:       kedav1alpha1.ScalingBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+			Status: kedav1alpha1.ScalingBudgetStatus{Members: []kedav1alpha1.ScalingBudgetMember{
+				{Name: "api", MaxReplicas: maxReplicas},
+			}},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newBudget("cluster", 8, nil),
+		newBudget("team", 5, map[string]string{"team": "api"}),
+		// the ScaledObject was relabeled since the budget was allocated
+		newBudget("relabeled", 2, map[string]string{"team": "batch"}),
+	).Build()
+
+	allocation, err := kedav1alpha1.GetScalingBudgetAllocation(context.Background(), c, scaledObject)
+	require.NoError(t, err)
+	assert.Equal(t, &kedav1alpha1.ScalingBudgetAllocation{Name: "team", MaxReplicas: 5}, allocation)
+}
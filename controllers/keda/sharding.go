@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+// shardingSource enqueues the objects of the shards whose ownership changed on this replica,
+// so the new owner starts the scale loops and the previous owner stops them
+func shardingSource(shardManager *sharding.Manager, c client.Client, newList func() client.ObjectList) source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		changes := shardManager.Subscribe()
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case shards := <-changes:
+					for _, request := range shardRequests(ctx, c, shardManager.Shards(), shards, newList()) {
+						queue.Add(request)
+					}
+				}
+			}
+		}()
+		return nil
+	})
+}
+
+// shardRequests returns the requests for the objects which belong to one of the shards
+func shardRequests(ctx context.Context, c client.Client, shardCount int32, shards []int32, list client.ObjectList) []reconcile.Request {
+	if err := c.List(ctx, list); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "error listing objects of changed shards")
+		return nil
+	}
+	var requests []reconcile.Request
+	_ = meta.EachListItem(list, func(obj runtime.Object) error {
+		object, ok := obj.(client.Object)
+		if ok && slices.Contains(shards, sharding.ShardFor(object.GetNamespace(), object.GetName(), shardCount)) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}})
+		}
+		return nil
+	})
+	return requests
+}
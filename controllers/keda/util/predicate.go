@@ -89,7 +89,8 @@ func (PausedPredicate) Update(e event.UpdateEvent) bool {
 		oldPausedValue = oldAnnotations[kedav1alpha1.PausedAnnotation]
 	}
 
-	return newPausedValue != oldPausedValue
+	return newPausedValue != oldPausedValue ||
+		newAnnotations[kedav1alpha1.PausedUntilAnnotation] != oldAnnotations[kedav1alpha1.PausedUntilAnnotation]
 }
 
 type HPASpecChangedPredicate struct {
@@ -102,3 +103,16 @@ func (HPASpecChangedPredicate) Update(e event.UpdateEvent) bool {
 
 	return len(newObj.Spec.Metrics) != len(oldObj.Spec.Metrics) || !equality.Semantic.DeepDerivative(newObj.Spec, oldObj.Spec)
 }
+
+// DryRunPredicate triggers reconciliation when the dry-run annotation of the ScaledObject changes
+type DryRunPredicate struct {
+	predicate.Funcs
+}
+
+func (DryRunPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[kedav1alpha1.DryRunAnnotation] != e.ObjectOld.GetAnnotations()[kedav1alpha1.DryRunAnnotation]
+}
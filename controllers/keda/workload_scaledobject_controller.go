@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// WorkloadScaledObjectReconciler creates a ScaledObject rendered from a template for the workloads annotated with
+// autoscaling.keda.sh/template, the ScaledObject is owned by the workload and deleted when the annotation is removed
+type WorkloadScaledObjectReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Workload is an empty object of the kind of the reconciled workloads, e.g. a Deployment
+	Workload client.Object
+}
+
+// +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=create;delete
+
+// SetupWithManager initializes the WorkloadScaledObjectReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *WorkloadScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	gvk, err := apiutil.GVKForObject(r.Workload, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(gvk.Kind)+"-scaledobject").
+		For(r.Workload, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		// Create the ScaledObject again if it is deleted while the workload is still annotated
+		Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(_ event.CreateEvent) bool { return false },
+			UpdateFunc: func(_ event.UpdateEvent) bool { return false },
+		})).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
+
+// Reconcile creates, updates or deletes the ScaledObject of the workload according to its template annotations
+func (r *WorkloadScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	workload := r.Workload.DeepCopyObject().(client.Object)
+	if err := r.Client.Get(ctx, req.NamespacedName, workload); err != nil {
+		if errors.IsNotFound(err) {
+			// the ScaledObject of a deleted workload is garbage collected through its owner reference
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get workload")
+		return ctrl.Result{}, err
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	err := r.Client.Get(ctx, req.NamespacedName, scaledObject)
+	if err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "Failed to get ScaledObject of workload")
+		return ctrl.Result{}, err
+	}
+	exists := err == nil
+	owned := exists && metav1.IsControlledBy(scaledObject, workload)
+
+	templateRef, err := kedav1alpha1.GetWorkloadTemplateRef(workload.GetAnnotations())
+	if err != nil {
+		r.Recorder.Event(workload, corev1.EventTypeWarning, eventreason.WorkloadScaledObjectFailed, err.Error())
+		return ctrl.Result{}, nil
+	}
+	if templateRef == nil || workload.GetDeletionTimestamp() != nil {
+		if owned {
+			return ctrl.Result{}, r.deleteWorkloadScaledObject(ctx, reqLogger, workload, scaledObject)
+		}
+		return ctrl.Result{}, nil
+	}
+	if exists && !owned {
+		r.Recorder.Event(workload, corev1.EventTypeWarning, eventreason.WorkloadScaledObjectFailed,
+			fmt.Sprintf("ScaledObject %s already exists and isn't owned by the workload", scaledObject.Name))
+		return ctrl.Result{}, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(workload, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	scaleTargetRef := &kedav1alpha1.ScaleTarget{
+		Name:       workload.GetName(),
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+	}
+
+	if exists {
+		if equality.Semantic.DeepEqual(scaledObject.Spec.TemplateRef, templateRef) {
+			return ctrl.Result{}, nil
+		}
+		patch := client.MergeFrom(scaledObject.DeepCopy())
+		scaledObject.Spec.ScaleTargetRef = scaleTargetRef
+		scaledObject.Spec.TemplateRef = templateRef
+		if err := r.Client.Patch(ctx, scaledObject, patch); err != nil {
+			reqLogger.Error(err, "Failed to update ScaledObject of workload")
+			return ctrl.Result{}, err
+		}
+		reqLogger.Info("Updated ScaledObject from the template annotations of workload", "template", templateRef.Name)
+		return ctrl.Result{}, nil
+	}
+
+	scaledObject = &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: workload.GetName(), Namespace: workload.GetNamespace()},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: scaleTargetRef,
+			TemplateRef:    templateRef,
+		},
+	}
+	if err := r.checkWorkloadScaledObjectConflicts(ctx, scaledObject); err != nil {
+		r.Recorder.Event(workload, corev1.EventTypeWarning, eventreason.WorkloadScaledObjectFailed, err.Error())
+		reqLogger.Error(err, "Failed to create ScaledObject from the template annotations of workload")
+		return ctrl.Result{}, err
+	}
+	if err := controllerutil.SetControllerReference(workload, scaledObject, r.Scheme); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Client.Create(ctx, scaledObject); err != nil {
+		reqLogger.Error(err, "Failed to create ScaledObject of workload")
+		return ctrl.Result{}, err
+	}
+	reqLogger.Info("Created ScaledObject from the template annotations of workload", "template", templateRef.Name)
+	r.Recorder.Event(workload, corev1.EventTypeNormal, eventreason.WorkloadScaledObjectCreated,
+		fmt.Sprintf("ScaledObject %s was created from %s %s", scaledObject.Name, templateRef.GetKind(), templateRef.Name))
+	return ctrl.Result{}, nil
+}
+
+// checkWorkloadScaledObjectConflicts renders the ScaledObject and runs the conflict checks of the admission webhook,
+// so that no ScaledObject is created for a workload already scaled by another ScaledObject or HPA
+func (r *WorkloadScaledObjectReconciler) checkWorkloadScaledObjectConflicts(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) error {
+	templateRef := scaledObject.Spec.TemplateRef
+	spec, err := kedav1alpha1.GetScaledObjectTemplateSpec(ctx, r.Client, scaledObject)
+	if err != nil {
+		return fmt.Errorf("error getting %s %s: %w", templateRef.GetKind(), templateRef.Name, err)
+	}
+	renderedSpec, err := spec.Render(scaledObject)
+	if err != nil {
+		return fmt.Errorf("error rendering %s %s: %w", templateRef.GetKind(), templateRef.Name, err)
+	}
+	rendered := scaledObject.DeepCopy()
+	rendered.Spec = *renderedSpec
+
+	if _, err := kedav1alpha1.CheckScaledObjectConflicts(ctx, r.Client, r.Client.RESTMapper(), rendered); err != nil {
+		return err
+	}
+	if _, err := kedav1alpha1.CheckHpaConflicts(ctx, r.Client, r.Client.RESTMapper(), rendered); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WorkloadScaledObjectReconciler) deleteWorkloadScaledObject(ctx context.Context, logger logr.Logger, workload client.Object, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := r.Client.Delete(ctx, scaledObject); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete ScaledObject of workload")
+		return err
+	}
+	logger.Info("Deleted ScaledObject as the template annotations of workload were removed")
+	r.Recorder.Event(workload, corev1.EventTypeNormal, eventreason.WorkloadScaledObjectDeleted,
+		fmt.Sprintf("ScaledObject %s was deleted as the template annotations were removed", scaledObject.Name))
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newWorkloadScaledObjectTestClient(t *testing.T, objects ...client.Object) (client.Client, *WorkloadScaledObjectReconciler) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	template := &kedav1alpha1.ScaledObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "queue", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectTemplateSpec{
+			Parameters: []kedav1alpha1.TemplateParameter{{Name: "queue"}},
+			Template:   runtime.RawExtension{Raw: []byte(`{"triggers": [{"type": "rabbitmq", "metadata": {"queueName": "${queue}", "value": "10"}}]}`)},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, template)...).Build()
+	return c, &WorkloadScaledObjectReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Workload: &appsv1.Deployment{},
+	}
+}
+
+func newAnnotatedDeployment(annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default", UID: "consumer-uid", Annotations: annotations},
+	}
+}
+
+func TestWorkloadScaledObjectLifecycle(t *testing.T) {
+	deployment := newAnnotatedDeployment(map[string]string{
+		kedav1alpha1.WorkloadTemplateAnnotation:                          "queue",
+		kedav1alpha1.WorkloadTemplateParameterAnnotationPrefix + "queue": "orders",
+	})
+	c, r := newWorkloadScaledObjectTestClient(t, deployment)
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "consumer", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(ctx, request.NamespacedName, scaledObject))
+	assert.Equal(t, &kedav1alpha1.ScaleTarget{Name: "consumer", APIVersion: "apps/v1", Kind: "Deployment"}, scaledObject.Spec.ScaleTargetRef)
+	assert.Equal(t, &kedav1alpha1.ScaledObjectTemplateRef{Name: "queue", Parameters: map[string]string{"queue": "orders"}}, scaledObject.Spec.TemplateRef)
+	assert.True(t, metav1.IsControlledBy(scaledObject, deployment))
+
+	// a change of the parameters is applied to the ScaledObject
+	deployment.Annotations[kedav1alpha1.WorkloadTemplateParameterAnnotationPrefix+"queue"] = "payments"
+	require.NoError(t, c.Update(ctx, deployment))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, request.NamespacedName, scaledObject))
+	assert.Equal(t, "payments", scaledObject.Spec.TemplateRef.Parameters["queue"])
+
+	// the ScaledObject is deleted once the annotations are removed
+	deployment.Annotations = nil
+	require.NoError(t, c.Update(ctx, deployment))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.True(t, errors.IsNotFound(c.Get(ctx, request.NamespacedName, scaledObject)))
+}
+
+func TestWorkloadScaledObjectConflicts(t *testing.T) {
+	annotations := map[string]string{
+		kedav1alpha1.WorkloadTemplateAnnotation:                          "queue",
+		kedav1alpha1.WorkloadTemplateParameterAnnotationPrefix + "queue": "orders",
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "consumer", Namespace: "default"}}
+
+	// a ScaledObject of the same name which isn't owned by the workload is left untouched
+	existing := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "other"}},
+	}
+	c, r := newWorkloadScaledObjectTestClient(t, newAnnotatedDeployment(annotations), existing)
+	_, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, scaledObject))
+	assert.Nil(t, scaledObject.Spec.TemplateRef)
+
+	// no ScaledObject is created for a workload already scaled by another ScaledObject
+	other := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer-scaler", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "consumer"}},
+	}
+	c, r = newWorkloadScaledObjectTestClient(t, newAnnotatedDeployment(annotations), other)
+	_, err = r.Reconcile(context.Background(), request)
+	assert.ErrorContains(t, err, "already managed by the ScaledObject 'consumer-scaler'")
+	assert.True(t, errors.IsNotFound(c.Get(context.Background(), request.NamespacedName, scaledObject)))
+
+	// an invalid template kind is reported without a ScaledObject
+	annotations[kedav1alpha1.WorkloadTemplateKindAnnotation] = "Template"
+	c, r = newWorkloadScaledObjectTestClient(t, newAnnotatedDeployment(annotations))
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, errors.IsNotFound(c.Get(context.Background(), request.NamespacedName, scaledObject)))
+}
@@ -35,6 +35,33 @@ const (
 	// ScaledJobUpdateFailed is for event when ScaledJob update status fails
 	ScaledJobUpdateFailed = "ScaledJobUpdateFailed"
 
+	// ScaledObjectPauseExpired is for event when the pause of ScaledObject expired and the pause annotations were removed
+	ScaledObjectPauseExpired = "ScaledObjectPauseExpired"
+
+	// ScaledJobPauseExpired is for event when the pause of ScaledJob expired and the pause annotations were removed
+	ScaledJobPauseExpired = "ScaledJobPauseExpired"
+
+	// ScaledObjectFrozen is for event when a ScalingFreeze or ClusterScalingFreeze starts freezing ScaledObject
+	ScaledObjectFrozen = "ScaledObjectFrozen"
+
+	// ScaledObjectUnfrozen is for event when the ScalingFreeze or ClusterScalingFreeze of ScaledObject ended
+	ScaledObjectUnfrozen = "ScaledObjectUnfrozen"
+
+	// ScaledJobFrozen is for event when a ScalingFreeze or ClusterScalingFreeze starts freezing ScaledJob
+	ScaledJobFrozen = "ScaledJobFrozen"
+
+	// ScaledJobUnfrozen is for event when the ScalingFreeze or ClusterScalingFreeze of ScaledJob ended
+	ScaledJobUnfrozen = "ScaledJobUnfrozen"
+
+	// WorkloadScaledObjectCreated is for event when a ScaledObject is created from the template annotations of a workload
+	WorkloadScaledObjectCreated = "WorkloadScaledObjectCreated"
+
+	// WorkloadScaledObjectDeleted is for event when the ScaledObject of a workload is deleted as its template annotations were removed
+	WorkloadScaledObjectDeleted = "WorkloadScaledObjectDeleted"
+
+	// WorkloadScaledObjectFailed is for event when the ScaledObject of a workload can't be created from its template annotations
+	WorkloadScaledObjectFailed = "WorkloadScaledObjectFailed"
+
 	// ScaledObjectDeleted is for event when ScaledObject is deleted
 	ScaledObjectDeleted = "ScaledObjectDeleted"
 
@@ -62,12 +89,33 @@ const (
 	// KEDAScaleTargetDeactivated is for event when the scale target for ScaledObject was deactivated
 	KEDAScaleTargetDeactivated = "KEDAScaleTargetDeactivated"
 
+	// KEDAScaleTargetDryRun is for event when the ScaledObject in dry-run mode would have scaled the scale target
+	KEDAScaleTargetDryRun = "KEDAScaleTargetDryRun"
+
 	// KEDAScaleTargetActivationFailed is for event when the activation the scale target for ScaledObject fails
 	KEDAScaleTargetActivationFailed = "KEDAScaleTargetActivationFailed"
 
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetScaled is for event when the scale target of ScaledObject in native scaling mode was scaled
+	KEDAScaleTargetScaled = "KEDAScaleTargetScaled"
+
+	// KEDAScaleTargetScaleFailed is for event when the scaling of the scale target of ScaledObject in native scaling mode fails
+	KEDAScaleTargetScaleFailed = "KEDAScaleTargetScaleFailed"
+
+	// KEDAScaleTargetLimitedByScalingBudget is for event when scaling the scale target of ScaledObject is limited by a ScalingBudget
+	KEDAScaleTargetLimitedByScalingBudget = "KEDAScaleTargetLimitedByScalingBudget"
+
+	// KEDAScaleDecisionVetoed is for event when the scale decision webhook vetoes a scale change
+	KEDAScaleDecisionVetoed = "KEDAScaleDecisionVetoed"
+
+	// KEDAScaleDecisionClamped is for event when the scale decision webhook clamps a scale change to another replica count
+	KEDAScaleDecisionClamped = "KEDAScaleDecisionClamped"
+
+	// KEDAScaleDecisionWebhookFailed is for event when calling the scale decision webhook fails
+	KEDAScaleDecisionWebhookFailed = "KEDAScaleDecisionWebhookFailed"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
@@ -19,7 +19,9 @@ package fallback
 import (
 	"context"
 	"reflect"
+	"slices"
 	"strconv"
+	"time"
 
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -30,77 +32,143 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 var log = logf.Log.WithName("fallback")
 
-func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) bool {
-	if scaledObject.Spec.Fallback == nil {
+func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, fallbackSpec *kedav1alpha1.Fallback, metricSpec v2.MetricSpec) bool {
+	if fallbackSpec == nil {
 		return false
 	}
 
-	// If we are using ScalingModifiers, we only care whether its metric type is AverageValue (or not not set -> default, which is AverageValue).
+	// If we are using ScalingModifiers, we only care whether the metric types of its composite metrics are AverageValue or Value (or not set -> default, which is AverageValue).
 	// If not, test the type of metricSpec passed.
-	if scaledObject.IsUsingModifiers() && (scaledObject.Spec.Advanced.ScalingModifiers.MetricType != v2.AverageValueMetricType && scaledObject.Spec.Advanced.ScalingModifiers.MetricType != "") {
-		log.V(0).Info("Fallback can only be enabled for scalingModifiers with metric of type AverageValue", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scalingModifiers.MetricType", scaledObject.Spec.Advanced.ScalingModifiers.MetricType)
-		return false
-	} else if !scaledObject.IsUsingModifiers() && metricSpec.External.Target.Type != v2.AverageValueMetricType {
-		log.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricSpec.External.Target.Type", metricSpec.External.Target.Type)
-		return false
+	for _, targetType := range getTargetTypes(scaledObject, metricSpec) {
+		if targetType != v2.AverageValueMetricType && targetType != v2.ValueMetricType {
+			if scaledObject.IsUsingModifiers() {
+				log.V(0).Info("Fallback can only be enabled for scalingModifiers with metric of type AverageValue or Value", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scalingModifiers.MetricType", targetType)
+			} else {
+				log.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue or Value", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricSpec.External.Target.Type", metricSpec.External.Target.Type)
+			}
+			return false
+		}
 	}
 
 	return true
 }
 
-func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, scaleClient scale.ScalesGetter, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) ([]external_metrics.ExternalMetricValue, bool, error) {
+// getTargetTypes returns the types of the targets the HPA compares the metrics with,
+// which are the metric types of the composite metrics if the ScaledObject is using modifiers
+func getTargetTypes(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) []v2.MetricTargetType {
+	if scaledObject.IsUsingModifiers() {
+		var targetTypes []v2.MetricTargetType
+		for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+			targetTypes = append(targetTypes, composite.GetMetricType())
+		}
+		return targetTypes
+	}
+	return []v2.MetricTargetType{metricSpec.External.Target.Type}
+}
+
+// GetMetricsWithFallback returns the metrics of the trigger with the given index, or the fallback metrics
+// if the trigger has been failing for more than FailureThreshold times. The fallback defined on the trigger
+// takes precedence over the one defined on the ScaledObject. The metricsCache keeps the last known good metrics
+// for the lastKnownGood behavior.
+func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, scaleClient scale.ScalesGetter, metricsCache *metricscache.MetricsCache, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, triggerIndex int, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) ([]external_metrics.ExternalMetricValue, bool, error) {
 	status := scaledObject.Status.DeepCopy()
+	fallbackSpec, fallbackPolicy := scaledObject.GetFallbackForTrigger(triggerIndex)
 
 	initHealthStatus(status)
 	healthStatus := getHealthStatus(status, metricName)
 
 	if suppressedError == nil {
+		if metricsCache != nil && fallbackSpec != nil && fallbackSpec.Behavior == kedav1alpha1.FallbackBehaviorLastKnownGood {
+			metricsCache.StoreLastKnownGood(scaledObject.GenerateIdentifier(), metricName, metrics)
+		}
+
 		zero := int32(0)
 		healthStatus.NumberOfFailures = &zero
 		healthStatus.Status = kedav1alpha1.HealthStatusHappy
+		healthStatus.FallbackPolicy = ""
 		status.Health[metricName] = *healthStatus
 
-		updateStatus(ctx, client, scaledObject, status, metricSpec)
+		updateStatus(ctx, client, scaledObject, status, fallbackSpec, metricSpec)
 
 		return metrics, false, nil
 	}
 
 	healthStatus.Status = kedav1alpha1.HealthStatusFailing
 	*healthStatus.NumberOfFailures++
+	healthStatus.FallbackPolicy = ""
+	if isFallbackEnabled(scaledObject, fallbackSpec, metricSpec) && isValidFallback(scaledObject, fallbackSpec) &&
+		*healthStatus.NumberOfFailures > fallbackSpec.FailureThreshold {
+		healthStatus.FallbackPolicy = fallbackPolicy
+	}
 	status.Health[metricName] = *healthStatus
 
-	updateStatus(ctx, client, scaledObject, status, metricSpec)
+	updateStatus(ctx, client, scaledObject, status, fallbackSpec, metricSpec)
 
 	switch {
-	case !isFallbackEnabled(scaledObject, metricSpec):
+	case !isFallbackEnabled(scaledObject, fallbackSpec, metricSpec):
 		return nil, false, suppressedError
-	case !HasValidFallback(scaledObject):
+	case !isValidFallback(scaledObject, fallbackSpec):
 		log.Info("Failed to validate ScaledObject Spec. Please check that parameters are positive integers", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 		return nil, false, suppressedError
-	case *healthStatus.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold:
+	case *healthStatus.NumberOfFailures > fallbackSpec.FailureThreshold:
+		if fallbackSpec.Behavior == kedav1alpha1.FallbackBehaviorLastKnownGood {
+			if lastKnownGood, found := getLastKnownGood(metricsCache, scaledObject, fallbackSpec, metricName); found {
+				log.Info("Suppressing error, using last known good metrics",
+					"scaledObject.Namespace", scaledObject.Namespace,
+					"scaledObject.Name", scaledObject.Name,
+					"suppressedError", suppressedError,
+					"metricName", metricName)
+				// last known good metrics are served as regular metrics, so they keep feeding the scalingModifiers formula
+				return lastKnownGood, false, nil
+			}
+		}
+
 		var currentReplicas int32
 		var err error
 
-		if scaledObject.Spec.Fallback.Behavior != kedav1alpha1.FallbackBehaviorStatic {
+		// the HPA divides Value metrics by the current replica count, so it is needed regardless of the behavior
+		if needsCurrentReplicas(fallbackSpec.Behavior) || slices.Contains(getTargetTypes(scaledObject, metricSpec), v2.ValueMetricType) {
 			currentReplicas, err = resolver.GetCurrentReplicas(ctx, client, scaleClient, scaledObject)
 			if err != nil {
 				return nil, false, suppressedError
 			}
 		}
-		return doFallback(scaledObject, metricSpec, metricName, currentReplicas, suppressedError), true, nil
+		return doFallback(scaledObject, fallbackSpec, metricSpec, metricName, currentReplicas, suppressedError), true, nil
 	default:
 		return nil, false, suppressedError
 	}
 }
 
-func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject) bool {
-	for _, element := range scaledObject.Status.Health {
-		if element.Status == kedav1alpha1.HealthStatusFailing && *element.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold {
+// getLastKnownGood returns the last successfully retrieved metrics, if they are not older than the fallback MaxAge
+func getLastKnownGood(metricsCache *metricscache.MetricsCache, scaledObject *kedav1alpha1.ScaledObject, fallbackSpec *kedav1alpha1.Fallback, metricName string) ([]external_metrics.ExternalMetricValue, bool) {
+	if metricsCache == nil {
+		return nil, false
+	}
+	record, found := metricsCache.ReadLastKnownGood(scaledObject.GenerateIdentifier(), metricName)
+	if !found || len(record.Metric) == 0 {
+		return nil, false
+	}
+	if time.Since(record.Timestamp) > fallbackSpec.GetMaxAge() {
+		log.V(1).Info("Last known good metrics are too old, using fallback replicas", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricName", metricName, "timestamp", record.Timestamp)
+		return nil, false
+	}
+	return record.Metric, true
+}
+
+// needsCurrentReplicas returns whether the fallback behavior depends on the current replica count
+func needsCurrentReplicas(behavior string) bool {
+	return behavior != kedav1alpha1.FallbackBehaviorStatic && behavior != kedav1alpha1.FallbackBehaviorLastKnownGood
+}
+
+func fallbackExistsInScaledObject(status *kedav1alpha1.ScaledObjectStatus) bool {
+	for _, element := range status.Health {
+		if element.Status == kedav1alpha1.HealthStatusFailing && element.FallbackPolicy != "" {
 			return true
 		}
 	}
@@ -108,60 +176,74 @@ func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject) bool
 	return false
 }
 
+// HasValidFallback checks that every fallback defined on the ScaledObject and on its triggers is valid
 func HasValidFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
+	if scaledObject.Spec.Fallback != nil && !isValidFallback(scaledObject, scaledObject.Spec.Fallback) {
+		return false
+	}
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Fallback != nil && !isValidFallback(scaledObject, trigger.Fallback) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidFallback(scaledObject *kedav1alpha1.ScaledObject, fallbackSpec *kedav1alpha1.Fallback) bool {
 	modifierChecking := true
 	if scaledObject.IsUsingModifiers() {
-		value, err := strconv.ParseInt(scaledObject.Spec.Advanced.ScalingModifiers.Target, 10, 64)
-		modifierChecking = err == nil && value > 0
+		for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+			value, err := strconv.ParseInt(composite.Target, 10, 64)
+			modifierChecking = modifierChecking && err == nil && value > 0
+		}
 	}
-	return scaledObject.Spec.Fallback.FailureThreshold >= 0 &&
-		scaledObject.Spec.Fallback.Replicas >= 0 &&
+	return fallbackSpec.FailureThreshold >= 0 &&
+		fallbackSpec.Replicas >= 0 &&
 		modifierChecking
 }
 
-func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string, currentReplicas int32, suppressedError error) []external_metrics.ExternalMetricValue {
-	fallbackBehavior := scaledObject.Spec.Fallback.Behavior
-	fallbackReplicas := int64(scaledObject.Spec.Fallback.Replicas)
-	var replicas int64
-
-	switch fallbackBehavior {
-	case kedav1alpha1.FallbackBehaviorStatic:
-		replicas = fallbackReplicas
+// GetFallbackReplicas returns the replica count the fallback scales the target to according to its behavior
+func GetFallbackReplicas(fallbackSpec *kedav1alpha1.Fallback, currentReplicas int32) int32 {
+	switch fallbackSpec.Behavior {
 	case kedav1alpha1.FallbackBehaviorCurrentReplicas:
-		replicas = int64(currentReplicas)
+		return currentReplicas
 	case kedav1alpha1.FallbackBehaviorCurrentReplicasIfHigher:
-		currentReplicasCount := int64(currentReplicas)
-		if currentReplicasCount > fallbackReplicas {
-			replicas = currentReplicasCount
-		} else {
-			replicas = fallbackReplicas
-		}
+		return max(currentReplicas, fallbackSpec.Replicas)
 	case kedav1alpha1.FallbackBehaviorCurrentReplicasIfLower:
-		currentReplicasCount := int64(currentReplicas)
-		if currentReplicasCount < fallbackReplicas {
-			replicas = currentReplicasCount
-		} else {
-			replicas = fallbackReplicas
-		}
+		return min(currentReplicas, fallbackSpec.Replicas)
 	default:
-		replicas = fallbackReplicas
+		return fallbackSpec.Replicas
 	}
+}
 
-	var normalisationValue int64
-	if !scaledObject.IsUsingModifiers() {
-		normalisationValue = int64(metricSpec.External.Target.AverageValue.AsApproximateFloat64())
-	} else {
-		value, _ := strconv.ParseInt(scaledObject.Spec.Advanced.ScalingModifiers.Target, 10, 64)
-		normalisationValue = value
-		metricName = kedav1alpha1.CompositeMetricName
-	}
+// IsFallbackActive returns whether the fallback is active for the metric with the given name
+func IsFallbackActive(scaledObject *kedav1alpha1.ScaledObject, metricName string) bool {
+	health, found := scaledObject.Status.Health[metricName]
+	return found && health.Status == kedav1alpha1.HealthStatusFailing && health.FallbackPolicy != ""
+}
 
-	metric := external_metrics.ExternalMetricValue{
-		MetricName: metricName,
-		Value:      *resource.NewMilliQuantity(normalisationValue*1000*replicas, resource.DecimalSI),
-		Timestamp:  metav1.Now(),
+func doFallback(scaledObject *kedav1alpha1.ScaledObject, fallbackSpec *kedav1alpha1.Fallback, metricSpec v2.MetricSpec, metricName string, currentReplicas int32, suppressedError error) []external_metrics.ExternalMetricValue {
+	fallbackBehavior := fallbackSpec.Behavior
+	fallbackReplicas := int64(fallbackSpec.Replicas)
+	replicas := int64(GetFallbackReplicas(fallbackSpec, currentReplicas))
+
+	var fallbackMetrics []external_metrics.ExternalMetricValue
+	if scaledObject.IsUsingModifiers() {
+		// every composite metric falls back to the replicas with its own target
+		for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+			value, _ := strconv.ParseInt(composite.Target, 10, 64)
+			fallbackMetrics = append(fallbackMetrics, getFallbackMetric(composite.MetricName(), composite.GetMetricType(), value, replicas, currentReplicas))
+		}
+	} else {
+		targetType := metricSpec.External.Target.Type
+		var normalisationValue int64
+		if targetType == v2.ValueMetricType {
+			normalisationValue = int64(metricSpec.External.Target.Value.AsApproximateFloat64())
+		} else {
+			normalisationValue = int64(metricSpec.External.Target.AverageValue.AsApproximateFloat64())
+		}
+		fallbackMetrics = append(fallbackMetrics, getFallbackMetric(metricName, targetType, normalisationValue, replicas, currentReplicas))
 	}
-	fallbackMetrics := []external_metrics.ExternalMetricValue{metric}
 
 	log.Info("Suppressing error, using fallback metrics",
 		"scaledObject.Namespace", scaledObject.Namespace,
@@ -173,15 +255,31 @@ func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpe
 	return fallbackMetrics
 }
 
-func updateStatus(ctx context.Context, client runtimeclient.Client, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2.MetricSpec) {
+// getFallbackMetric returns the metric which makes the HPA compute the fallback replicas for the given target
+func getFallbackMetric(metricName string, targetType v2.MetricTargetType, normalisationValue, replicas int64, currentReplicas int32) external_metrics.ExternalMetricValue {
+	metricValue := normalisationValue * 1000 * replicas
+	if targetType == v2.ValueMetricType {
+		// the HPA computes ceil(currentReplicas * value / target) for Value metrics,
+		// so the value is scaled down by the current replica count to land on the fallback replicas
+		metricValue /= max(int64(currentReplicas), 1)
+	}
+
+	return external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(metricValue, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+}
+
+func updateStatus(ctx context.Context, client runtimeclient.Client, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, fallbackSpec *kedav1alpha1.Fallback, metricSpec v2.MetricSpec) {
 	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
 
-	if !isFallbackEnabled(scaledObject, metricSpec) || !HasValidFallback(scaledObject) {
+	if !isFallbackEnabled(scaledObject, fallbackSpec, metricSpec) || !isValidFallback(scaledObject, fallbackSpec) {
 		log.V(1).Info("Fallback is not enabled, hence skipping the health update to the scaledobject", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 		return
 	}
 
-	if fallbackExistsInScaledObject(scaledObject) {
+	if fallbackExistsInScaledObject(status) {
 		status.Conditions.SetFallbackCondition(metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object")
 	} else {
 		status.Conditions.SetFallbackCondition(metav1.ConditionFalse, "NoFallbackFound", "No fallbacks are active on this scaled object")
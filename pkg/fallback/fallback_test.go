@@ -38,6 +38,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
 )
 
 const metricName = "some_metric_name"
@@ -73,7 +74,7 @@ var _ = Describe("fallback", func() {
 		metricSpec := createMetricSpec(3)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -104,7 +105,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -133,7 +134,7 @@ var _ = Describe("fallback", func() {
 		expectNoStatusPatch(ctrl)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -149,7 +150,7 @@ var _ = Describe("fallback", func() {
 		expectNoStatusPatch(ctrl)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("some error"))
@@ -178,7 +179,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("some error"))
@@ -210,7 +211,7 @@ var _ = Describe("fallback", func() {
 		mockScaleAndDeployment(ctrl, client, scaleClient, 5)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -236,7 +237,7 @@ var _ = Describe("fallback", func() {
 			},
 		}
 
-		isEnabled := isFallbackEnabled(so, metricsSpec)
+		isEnabled := isFallbackEnabled(so, so.Spec.Fallback, metricsSpec)
 		Expect(isEnabled).Should(BeFalse())
 	})
 
@@ -268,7 +269,7 @@ var _ = Describe("fallback", func() {
 		mockScaleAndDeployment(ctrl, client, scaleClient, 5)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -297,7 +298,7 @@ var _ = Describe("fallback", func() {
 		metricSpec := createMetricSpec(10)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("some error"))
@@ -333,7 +334,7 @@ var _ = Describe("fallback", func() {
 		mockScaleAndDeployment(ctrl, client, scaleClient, 5)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		condition := so.Status.Conditions.GetFallbackCondition()
@@ -367,7 +368,7 @@ var _ = Describe("fallback", func() {
 		metricSpec := createMetricSpec(10)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("some error"))
@@ -401,7 +402,7 @@ var _ = Describe("fallback", func() {
 		mockScaleAndDeployment(ctrl, client, scaleClient, 4)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -435,7 +436,7 @@ var _ = Describe("fallback", func() {
 		mockScaleAndDeployment(ctrl, client, scaleClient, 6)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -467,13 +468,331 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
 		expectedValue := float64(100) // 10 replicas * 10 target value, ignoring current 15
 		Expect(value).Should(Equal(expectedValue))
 	})
+
+	It("should use the trigger fallback instead of the scaledobject fallback", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(1)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		so.Spec.Triggers[0].Fallback = &kedav1alpha1.Fallback{
+			FailureThreshold: int32(1),
+			Replicas:         int32(5),
+			Behavior:         kedav1alpha1.FallbackBehaviorStatic,
+		}
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).To(BeTrue())
+		value := metrics[0].Value.AsApproximateFloat64()
+		expectedValue := float64(50) // 5 replicas * 10 target value
+		Expect(value).Should(Equal(expectedValue))
+		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(2, kedav1alpha1.HealthStatusFailing))
+		Expect(so.Status.Health[metricName].FallbackPolicy).To(Equal(kedav1alpha1.FallbackPolicyTrigger))
+		condition := so.Status.Conditions.GetFallbackCondition()
+		Expect(condition.IsTrue()).Should(BeTrue())
+	})
+
+	It("should return error when only another trigger has a fallback", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(nil, &kedav1alpha1.ScaledObjectStatus{
+			Health: map[string]kedav1alpha1.HealthStatus{
+				metricName: {
+					NumberOfFailures: &startingNumberOfFailures,
+					Status:           kedav1alpha1.HealthStatusFailing,
+				},
+			},
+		})
+		so.Spec.Triggers = append(so.Spec.Triggers, kedav1alpha1.ScaleTriggers{
+			Type: "prometheus",
+			Fallback: &kedav1alpha1.Fallback{
+				FailureThreshold: int32(1),
+				Replicas:         int32(5),
+			},
+		})
+		metricSpec := createMetricSpec(10)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).Should(HaveOccurred())
+		Expect(fallbackActive).To(BeFalse())
+	})
+
+	It("should report the scaledobject fallback policy and reset it on success", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorStatic,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusFailing,
+					},
+				},
+			},
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(so.Status.Health[metricName].FallbackPolicy).To(Equal(kedav1alpha1.FallbackPolicyScaledObject))
+
+		primeGetMetrics(scaler, float64(6))
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(0, kedav1alpha1.HealthStatusHappy))
+		Expect(so.Status.Health[metricName].FallbackPolicy).To(BeEmpty())
+		condition := so.Status.Conditions.GetFallbackCondition()
+		Expect(condition.IsFalse()).Should(BeTrue())
+	})
+
+	It("should scale the fallback metric by current replicas when target type is 'Value'", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorStatic,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createValueMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		mockScaleAndDeployment(ctrl, client, scaleClient, 4)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		value := metrics[0].Value.AsApproximateFloat64()
+		expectedValue := float64(25) // 10 replicas * 10 target value / 4 current replicas
+		Expect(value).Should(Equal(expectedValue))
+	})
+
+	It("should use the current replicas as they are when target type is 'Value' and behavior is 'currentReplicas'", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorCurrentReplicas,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createValueMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		mockScaleAndDeployment(ctrl, client, scaleClient, 5)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		value := metrics[0].Value.AsApproximateFloat64()
+		expectedValue := float64(10) // 5 replicas * 10 target value / 5 current replicas
+		Expect(value).Should(Equal(expectedValue))
+	})
+
+	It("should return a fallback metric for every composite metric of scalingModifiers", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorStatic,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		so.Spec.Advanced = &kedav1alpha1.AdvancedConfig{
+			ScalingModifiers: kedav1alpha1.ScalingModifiers{
+				Formula: "queue",
+				Target:  "2",
+				CompositeMetrics: []kedav1alpha1.CompositeMetric{
+					{Name: "latency", Formula: "latency", Target: "5", MetricType: v2.ValueMetricType},
+				},
+			},
+		}
+		metricSpec := createMetricSpec(3)
+		expectStatusPatch(ctrl, client)
+
+		mockScaleAndDeployment(ctrl, client, scaleClient, 4)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, isFallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, nil, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isFallbackActive).To(BeTrue())
+		Expect(metrics).To(HaveLen(2))
+		Expect(metrics[0].MetricName).To(Equal(kedav1alpha1.CompositeMetricName))
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(20))) // 10 replicas * 2 target
+		Expect(metrics[1].MetricName).To(Equal(kedav1alpha1.CompositeMetricName + "-latency"))
+		Expect(metrics[1].Value.AsApproximateFloat64()).Should(Equal(float64(12.5))) // 10 replicas * 5 target / 4 current replicas
+	})
+
+	It("should enable fallback for triggers with target type 'Value'", func() {
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+			}, nil,
+		)
+
+		isEnabled := isFallbackEnabled(so, so.Spec.Fallback, createValueMetricSpec(3))
+		Expect(isEnabled).Should(BeTrue())
+	})
+
+	It("should serve the last known good metric when behavior is 'lastKnownGood'", func() {
+		primeGetMetrics(scaler, float64(7))
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		metricsCache := metricscache.NewMetricsCache()
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(0),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownGood,
+			}, nil,
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, &metricsCache, metrics, err, metricName, 0, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, &metricsCache, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).To(BeFalse())
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(7)))
+		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(1, kedav1alpha1.HealthStatusFailing))
+		Expect(so.Status.Health[metricName].FallbackPolicy).To(Equal(kedav1alpha1.FallbackPolicyScaledObject))
+	})
+
+	It("should use fallback replicas when the last known good metric is too old", func() {
+		primeGetMetrics(scaler, float64(7))
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		metricsCache := metricscache.NewMetricsCache()
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(0),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownGood,
+				MaxAge:           ptr.To[int32](0),
+			}, nil,
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, scaleClient, &metricsCache, metrics, err, metricName, 0, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, &metricsCache, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).To(BeTrue())
+		value := metrics[0].Value.AsApproximateFloat64()
+		expectedValue := float64(100) // 10 replicas * 10 target value
+		Expect(value).Should(Equal(expectedValue))
+	})
+
+	It("should use fallback replicas when there is no last known good metric", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error"))
+		metricsCache := metricscache.NewMetricsCache()
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(0),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownGood,
+			}, nil,
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, scaleClient, &metricsCache, metrics, err, metricName, 0, so, metricSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).To(BeTrue())
+		value := metrics[0].Value.AsApproximateFloat64()
+		expectedValue := float64(100) // 10 replicas * 10 target value
+		Expect(value).Should(Equal(expectedValue))
+	})
 })
 
 // Helper functions
@@ -624,3 +943,15 @@ func createMetricSpec(averageValue int) v2.MetricSpec {
 		},
 	}
 }
+
+func createValueMetricSpec(value int) v2.MetricSpec {
+	qty := resource.NewQuantity(int64(value), resource.DecimalSI)
+	return v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Target: v2.MetricTarget{
+				Type:  v2.ValueMetricType,
+				Value: qty,
+			},
+		},
+	}
+}
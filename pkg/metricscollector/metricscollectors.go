@@ -52,6 +52,12 @@ type MetricsCollector interface {
 	// RecordScaledObjectPaused marks whether the current ScaledObject is paused.
 	RecordScaledObjectPaused(namespace string, scaledObject string, active bool)
 
+	// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would have scaled to.
+	RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32)
+
+	// RecordSharedQuery counts the queries of the scalers served by the shared query layer, either reused (hit) or sent upstream (miss)
+	RecordSharedQuery(triggerType string, hit bool)
+
 	// RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 	RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error)
 
@@ -130,6 +136,20 @@ func RecordScaledObjectPaused(namespace string, scaledObject string, active bool
 	}
 }
 
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would have scaled to.
+func RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	for _, element := range collectors {
+		element.RecordScaledObjectDryRunReplicas(namespace, scaledObject, replicas)
+	}
+}
+
+// RecordSharedQuery counts the queries of the scalers served by the shared query layer, either reused (hit) or sent upstream (miss)
+func RecordSharedQuery(triggerType string, hit bool) {
+	for _, element := range collectors {
+		element.RecordSharedQuery(triggerType, hit)
+	}
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func RecordScalerError(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	for _, element := range collectors {
@@ -201,3 +221,10 @@ func RecordCloudEventQueueStatus(namespace string, value int) {
 func GetServerMetrics() *grpcprom.ServerMetrics {
 	return promServerMetrics
 }
+
+func getSharedQueryResult(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
@@ -30,6 +30,7 @@ var (
 	otScalerErrorsCounter            api.Int64Counter
 	otScaledObjectErrorsCounter      api.Int64Counter
 	otScaledJobErrorsCounter         api.Int64Counter
+	otSharedQueriesCounter           api.Int64Counter
 	otTriggerTotalsCounterDeprecated api.Int64UpDownCounter
 	otCrdTotalsCounterDeprecated     api.Int64UpDownCounter
 	otTriggerRegisteredTotalsCounter api.Int64UpDownCounter
@@ -47,6 +48,8 @@ var (
 
 	otelScalerActiveVals []OtelMetricFloat64Val
 	otelScalerPauseVals  []OtelMetricFloat64Val
+
+	otelScaledObjectDryRunReplicasVals []OtelMetricFloat64Val
 )
 
 type OtelMetrics struct {
@@ -115,6 +118,11 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	otSharedQueriesCounter, err = meter.Int64Counter("keda.scaler.shared.queries.count", api.WithDescription("Number of scaler queries served by the shared query layer, reused within the TTL (hit) or sent upstream (miss)"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	otTriggerTotalsCounterDeprecated, err = meter.Int64UpDownCounter("keda.trigger.totals", api.WithDescription("DEPRECATED - will be removed in 2.16 - use 'keda.trigger.registered.count' instead"))
 	if err != nil {
 		otLog.Error(err, msg)
@@ -220,6 +228,15 @@ func initMeters() {
 	if err != nil {
 		otLog.Error(err, msg)
 	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.dry.run.replicas",
+		api.WithDescription("The replica count a ScaledObject in dry-run mode would have scaled its target to"),
+		api.WithFloat64Callback(DryRunReplicasCallback),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
 }
 
 func BuildInfoCallback(_ context.Context, obsrv api.Int64Observer) error {
@@ -371,7 +388,36 @@ func (o *OtelMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	otelScalerPauseVals = append(otelScalerPauseVals, otelScalerPause)
 }
 
+func DryRunReplicasCallback(_ context.Context, obsrv api.Float64Observer) error {
+	for _, v := range otelScaledObjectDryRunReplicasVals {
+		obsrv.Observe(v.val, v.measurementOption)
+	}
+	otelScaledObjectDryRunReplicasVals = []OtelMetricFloat64Val{}
+	return nil
+}
+
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would have scaled to.
+func (o *OtelMetrics) RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	opt := api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("scaledObject").String(scaledObject))
+
+	otelDryRunReplicas := OtelMetricFloat64Val{}
+	otelDryRunReplicas.val = float64(replicas)
+	otelDryRunReplicas.measurementOption = opt
+	otelScaledObjectDryRunReplicasVals = append(otelScaledObjectDryRunReplicasVals, otelDryRunReplicas)
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
+// RecordSharedQuery counts the queries of the scalers served by the shared query layer, either reused (hit) or sent upstream (miss)
+func (o *OtelMetrics) RecordSharedQuery(triggerType string, hit bool) {
+	opt := api.WithAttributes(
+		attribute.Key("type").String(triggerType),
+		attribute.Key("result").String(getSharedQueryResult(hit)),
+	)
+	otSharedQueriesCounter.Add(context.Background(), 1, opt)
+}
+
 func (o *OtelMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
 		otScalerErrorsCounter.Add(context.Background(), 1, getScalerMeasurementOption(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject))
@@ -103,6 +103,41 @@ func TestLoopLatency(t *testing.T) {
 	assert.Equal(t, data.Value, float64(0.5))
 }
 
+func TestDryRunReplicas(t *testing.T) {
+	testOtel.RecordScaledObjectDryRunReplicas("namespace", "name", 7)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+	assert.NotEqual(t, len(scopeMetrics.Metrics), 0)
+
+	replicas := retrieveMetric(scopeMetrics.Metrics, "keda.scaled.object.dry.run.replicas")
+	assert.NotNil(t, replicas)
+	data := replicas.Data.(metricdata.Gauge[float64]).DataPoints[0]
+	assert.Equal(t, data.Value, float64(7))
+}
+
+func TestSharedQueries(t *testing.T) {
+	testOtel.RecordSharedQuery("aws-sqs-queue", true)
+	testOtel.RecordSharedQuery("aws-sqs-queue", true)
+	testOtel.RecordSharedQuery("aws-sqs-queue", false)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+	assert.NotEqual(t, len(scopeMetrics.Metrics), 0)
+
+	queries := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.shared.queries.count")
+	assert.NotNil(t, queries)
+	total := int64(0)
+	for _, data := range queries.Data.(metricdata.Sum[int64]).DataPoints {
+		total += data.Value
+	}
+	assert.Equal(t, int64(3), total)
+}
+
 func TestContinuousMetrics(t *testing.T) {
 	testOtel.RecordScalerActive("testnamespace", "testresource", "testscaler", 0, "testmetric", true, true)
 	testOtel.RecordScalerActive("testnamespace2", "testresource2", "testscaler2", 0, "testmetric", false, false)
@@ -77,6 +77,24 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	scaledObjectDryRunReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "dry_run_replicas",
+			Help:      "The replica count a ScaledObject in dry-run mode would have scaled its target to.",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scalerSharedQueries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "shared_queries_total",
+			Help:      "The total number of scaler queries served by the shared query layer, reused within the TTL (hit) or sent upstream (miss).",
+		},
+		[]string{"type", "result"},
+	)
 	scalerErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -165,6 +183,8 @@ func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(scalerErrors)
 	metrics.Registry.MustRegister(scaledObjectErrors)
 	metrics.Registry.MustRegister(scaledObjectPaused)
+	metrics.Registry.MustRegister(scaledObjectDryRunReplicas)
+	metrics.Registry.MustRegister(scalerSharedQueries)
 	metrics.Registry.MustRegister(triggerRegistered)
 	metrics.Registry.MustRegister(crdRegistered)
 	metrics.Registry.MustRegister(scaledJobErrors)
@@ -220,6 +240,17 @@ func (p *PromMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	scaledObjectPaused.With(labels).Set(float64(activeVal))
 }
 
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would have scaled to.
+func (p *PromMetrics) RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}
+	scaledObjectDryRunReplicas.With(labels).Set(float64(replicas))
+}
+
+// RecordSharedQuery counts the queries of the scalers served by the shared query layer, either reused (hit) or sent upstream (miss)
+func (p *PromMetrics) RecordSharedQuery(triggerType string, hit bool) {
+	scalerSharedQueries.WithLabelValues(triggerType, getSharedQueryResult(hit)).Inc()
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func (p *PromMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/utils"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 )
 
 var log = logf.Log.WithName("grpc_server")
@@ -39,11 +40,18 @@ type GrpcServer struct {
 	certDir       string
 	certsReady    chan struct{}
 	scalerHandler *scaling.ScaleHandler
+	router        *shardRouter
 	api.UnimplementedMetricsServiceServer
 }
 
 // GetMetrics returns metrics values in form of ExternalMetricValueList for specified ScaledObject reference
 func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error) {
+	if s.router != nil {
+		if metrics, forwarded := s.router.forward(ctx, in); forwarded {
+			return metrics, nil
+		}
+	}
+
 	v1beta1ExtMetrics := &v1beta1.ExternalMetricValueList{}
 	extMetrics, err := (*s.scalerHandler).GetScaledObjectMetrics(ctx, in.Name, in.Namespace, in.MetricName)
 	if err != nil {
@@ -60,14 +68,19 @@ func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*
 	return v1beta1ExtMetrics, nil
 }
 
-// NewGrpcServer creates a new instance of GrpcServer
-func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}) GrpcServer {
-	return GrpcServer{
+// NewGrpcServer creates a new instance of GrpcServer, if shardManager is set the requests are forwarded
+// to the replica owning the ScaledObject, authority must match the certificate of the replicas then
+func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}, shardManager *sharding.Manager, authority string) GrpcServer {
+	server := GrpcServer{
 		address:       address,
 		scalerHandler: scaleHandler,
 		certDir:       certDir,
 		certsReady:    certsReady,
 	}
+	if shardManager != nil {
+		server.router = newShardRouter(shardManager, certDir, authority)
+	}
+	return server
 }
 
 func (s *GrpcServer) startServer() error {
@@ -131,6 +144,7 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 // NeedLeaderElection is needed to implement LeaderElectionRunnable interface
 // of controller-runtime. This assures that the component is started/stoped
 // when this particular instance is selected/deselected as a leader.
+// With sharding every replica serves the metrics of its shards.
 func (s *GrpcServer) NeedLeaderElection() bool {
-	return true
+	return s.router == nil
 }
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"context"
+	"sync"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"google.golang.org/grpc/metadata"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+// forwardedMetadataKey marks the requests forwarded by another replica,
+// they are always served locally to avoid forwarding loops while the shards are rebalanced
+const forwardedMetadataKey = "keda-forwarded"
+
+// shardRouter forwards the metric requests to the operator replica owning the shard of the ScaledObject
+type shardRouter struct {
+	sharding      *sharding.Manager
+	certDir       string
+	authority     string
+	clientMetrics *grpcprom.ClientMetrics
+	clients       map[string]*GrpcClient
+	mutex         sync.Mutex
+}
+
+func newShardRouter(shardManager *sharding.Manager, certDir, authority string) *shardRouter {
+	return &shardRouter{
+		sharding:      shardManager,
+		certDir:       certDir,
+		authority:     authority,
+		clientMetrics: grpcprom.NewClientMetrics(),
+		clients:       map[string]*GrpcClient{},
+	}
+}
+
+// forward sends the request to the replica owning the ScaledObject, false is returned if the request
+// should be served locally because this replica owns the ScaledObject, the owner is unknown or unreachable
+func (r *shardRouter) forward(ctx context.Context, in *api.ScaledObjectRef) (*v1beta1.ExternalMetricValueList, bool) {
+	if r.sharding.Owns(in.Namespace, in.Name) {
+		return nil, false
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(forwardedMetadataKey)) > 0 {
+		return nil, false
+	}
+	address, found := r.sharding.OwnerAddress(in.Namespace, in.Name)
+	if !found {
+		return nil, false
+	}
+
+	client, err := r.getClient(address)
+	if err != nil {
+		log.Error(err, "error creating client of the shard owner", "address", address)
+		return nil, false
+	}
+	metrics, err := client.client.GetMetrics(metadata.AppendToOutgoingContext(ctx, forwardedMetadataKey, "true"), in)
+	if err != nil {
+		log.Error(err, "error forwarding metrics request to the shard owner, serving it locally", "address", address,
+			"scaledObjectName", in.Name, "scaledObjectNamespace", in.Namespace)
+		r.deleteClient(address)
+		return nil, false
+	}
+	return metrics, true
+}
+
+func (r *shardRouter) getClient(address string) (*GrpcClient, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if client, found := r.clients[address]; found {
+		return client, nil
+	}
+	client, err := NewGrpcClient(address, r.certDir, r.authority, r.clientMetrics)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[address] = client
+	return client, nil
+}
+
+func (r *shardRouter) deleteClient(address string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if client, found := r.clients[address]; found {
+		_ = client.connection.Close()
+		delete(r.clients, address)
+	}
+}
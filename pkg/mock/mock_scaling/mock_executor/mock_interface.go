@@ -42,6 +42,18 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// DeleteScalingState mocks base method.
+func (m *MockScaleExecutor) DeleteScalingState(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteScalingState", key)
+}
+
+// DeleteScalingState indicates an expected call of DeleteScalingState.
+func (mr *MockScaleExecutorMockRecorder) DeleteScalingState(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalingState", reflect.TypeOf((*MockScaleExecutor)(nil).DeleteScalingState), key)
+}
+
 // RequestJobScale mocks base method.
 func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive, isError bool, scaleTo, maxScale int64) {
 	m.ctrl.T.Helper()
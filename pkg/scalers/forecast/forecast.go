@@ -0,0 +1,109 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// Model is a forecasting model for a series of equally spaced values
+type Model string
+
+const (
+	// ModelHoltWinters is additive Holt-Winters (triple exponential smoothing) with level, trend and seasonality
+	ModelHoltWinters Model = "holtWinters"
+	// ModelSeasonalNaive forecasts the value observed one season before
+	ModelSeasonalNaive Model = "seasonalNaive"
+)
+
+// Parameters are the smoothing factors of the Holt-Winters model, all of them must be in [0, 1]
+type Parameters struct {
+	// Alpha is the smoothing factor of the level
+	Alpha float64
+	// Beta is the smoothing factor of the trend
+	Beta float64
+	// Gamma is the smoothing factor of the seasonality
+	Gamma float64
+}
+
+// Forecast returns the value forecasted by the model horizon steps after the last value of the series,
+// seasonLength is the number of values of one season
+func Forecast(model Model, values []float64, seasonLength, horizon int, params Parameters) (float64, error) {
+	switch model {
+	case ModelHoltWinters:
+		return HoltWinters(values, seasonLength, horizon, params)
+	case ModelSeasonalNaive:
+		return SeasonalNaive(values, seasonLength, horizon)
+	default:
+		return 0, fmt.Errorf("unknown forecasting model %q", model)
+	}
+}
+
+// SeasonalNaive returns the value of the last season at the position of the forecasted value,
+// it requires at least one season of values
+func SeasonalNaive(values []float64, seasonLength, horizon int) (float64, error) {
+	if err := validate(values, seasonLength, horizon, 1); err != nil {
+		return 0, err
+	}
+	last := len(values) - 1
+	seasons := (horizon-1)/seasonLength + 1
+	return values[last+horizon-seasons*seasonLength], nil
+}
+
+// HoltWinters fits additive Holt-Winters to the values and returns the value forecasted horizon steps ahead,
+// it requires at least two seasons of values to initialize the trend and the seasonality
+func HoltWinters(values []float64, seasonLength, horizon int, params Parameters) (float64, error) {
+	if err := validate(values, seasonLength, horizon, 2); err != nil {
+		return 0, err
+	}
+	for _, factor := range []float64{params.Alpha, params.Beta, params.Gamma} {
+		if factor < 0 || factor > 1 || math.IsNaN(factor) {
+			return 0, fmt.Errorf("smoothing factors must be in [0, 1], got alpha=%v beta=%v gamma=%v", params.Alpha, params.Beta, params.Gamma)
+		}
+	}
+
+	firstSeason, secondSeason := mean(values[:seasonLength]), mean(values[seasonLength:2*seasonLength])
+	trend := (secondSeason - firstSeason) / float64(seasonLength)
+	// the mean of the first season is the level in the middle of it, the smoothing starts at its end
+	level := firstSeason + trend*float64(seasonLength-1)/2
+	// the initial seasonality is the mean deviation from the detrended season mean over all complete seasons
+	seasons := len(values) / seasonLength
+	seasonals := make([]float64, len(values))
+	for season := 0; season < seasons; season++ {
+		seasonMean := mean(values[season*seasonLength : (season+1)*seasonLength])
+		for i := 0; i < seasonLength; i++ {
+			deviation := values[season*seasonLength+i] - seasonMean - trend*(float64(i)-float64(seasonLength-1)/2)
+			seasonals[i] += deviation / float64(seasons)
+		}
+	}
+
+	for i := seasonLength; i < len(values); i++ {
+		previousLevel := level
+		level = params.Alpha*(values[i]-seasonals[i-seasonLength]) + (1-params.Alpha)*(level+trend)
+		trend = params.Beta*(level-previousLevel) + (1-params.Beta)*trend
+		seasonals[i] = params.Gamma*(values[i]-level) + (1-params.Gamma)*seasonals[i-seasonLength]
+	}
+
+	seasonal := seasonals[len(values)-seasonLength+(horizon-1)%seasonLength]
+	return level + float64(horizon)*trend + seasonal, nil
+}
+
+func validate(values []float64, seasonLength, horizon, seasons int) error {
+	if seasonLength < 1 {
+		return fmt.Errorf("season length must be at least 1, got %d", seasonLength)
+	}
+	if horizon < 1 {
+		return fmt.Errorf("horizon must be at least 1, got %d", horizon)
+	}
+	if len(values) < seasons*seasonLength {
+		return fmt.Errorf("%d values are required for forecasting, got %d", seasons*seasonLength, len(values))
+	}
+	return nil
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
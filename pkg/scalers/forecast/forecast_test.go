@@ -0,0 +1,75 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dailyPattern returns seasons of values with a peak in the middle of every season on top of a linear trend
+func dailyPattern(seasons, seasonLength int, trend float64) []float64 {
+	values := make([]float64, 0, seasons*seasonLength)
+	for i := 0; i < seasons*seasonLength; i++ {
+		position := float64(i%seasonLength) / float64(seasonLength)
+		values = append(values, 100+trend*float64(i)+50*math.Sin(2*math.Pi*position))
+	}
+	return values
+}
+
+func TestSeasonalNaive(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	value, err := SeasonalNaive(values, 4, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), value)
+
+	value, err = SeasonalNaive(values, 4, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+
+	value, err = SeasonalNaive(values, 4, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(6), value)
+
+	_, err = SeasonalNaive(values[:3], 4, 1)
+	assert.EqualError(t, err, "4 values are required for forecasting, got 3")
+}
+
+func TestHoltWinters(t *testing.T) {
+	seasonLength := 24
+	values := dailyPattern(5, seasonLength, 0)
+	expected := dailyPattern(7, seasonLength, 0)
+
+	for _, horizon := range []int{1, 6, 12, 30} {
+		value, err := HoltWinters(values, seasonLength, horizon, Parameters{Alpha: 0.5, Beta: 0.1, Gamma: 0.3})
+		assert.NoError(t, err)
+		assert.InDelta(t, expected[len(values)-1+horizon], value, 1, "horizon %d", horizon)
+	}
+}
+
+func TestHoltWintersFollowsTrend(t *testing.T) {
+	seasonLength := 12
+	values := dailyPattern(6, seasonLength, 0.5)
+	expected := dailyPattern(7, seasonLength, 0.5)
+
+	value, err := HoltWinters(values, seasonLength, 6, Parameters{Alpha: 0.5, Beta: 0.1, Gamma: 0.3})
+	assert.NoError(t, err)
+	assert.InDelta(t, expected[len(values)-1+6], value, 2)
+}
+
+func TestHoltWintersErrors(t *testing.T) {
+	_, err := HoltWinters(dailyPattern(1, 24, 0), 24, 1, Parameters{Alpha: 0.5, Beta: 0.1, Gamma: 0.3})
+	assert.EqualError(t, err, "48 values are required for forecasting, got 24")
+
+	_, err = HoltWinters(dailyPattern(2, 24, 0), 24, 0, Parameters{Alpha: 0.5, Beta: 0.1, Gamma: 0.3})
+	assert.EqualError(t, err, "horizon must be at least 1, got 0")
+
+	_, err = HoltWinters(dailyPattern(2, 24, 0), 24, 1, Parameters{Alpha: 1.5, Beta: 0.1, Gamma: 0.3})
+	assert.EqualError(t, err, "smoothing factors must be in [0, 1], got alpha=1.5 beta=0.1 gamma=0.3")
+}
+
+func TestForecastUnknownModel(t *testing.T) {
+	_, err := Forecast("arima", dailyPattern(2, 4, 0), 4, 1, Parameters{})
+	assert.EqualError(t, err, "unknown forecasting model \"arima\"")
+}
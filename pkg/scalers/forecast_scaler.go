@@ -0,0 +1,446 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/xhit/go-str2duration/v2"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/forecast"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// forecastHistoryStoreMemory keeps the history in memory only, it is backfilled from Prometheus on every restart
+	forecastHistoryStoreMemory = "memory"
+	// forecastHistoryStoreConfigMap persists the history in a ConfigMap, it is opt-in because the operator
+	// has to be granted create and update on ConfigMaps in the namespace of the ScaledObject or ScaledJob
+	forecastHistoryStoreConfigMap = "configMap"
+
+	// forecastHistoryConfigMapKey is the key of the history in the data of the ConfigMap
+	forecastHistoryConfigMapKey = "history"
+
+	// forecastLoadMinBackoff and forecastLoadMaxBackoff bound the delay before the history is loaded again
+	// after the persisted history couldn't be loaded or Prometheus couldn't backfill it
+	forecastLoadMinBackoff = 30 * time.Second
+	forecastLoadMaxBackoff = 30 * time.Minute
+)
+
+// forecastScaler scales on the value of a Prometheus query forecasted from its history by a seasonal model,
+// the history is backfilled from Prometheus at startup and optionally kept in a ConfigMap across restarts
+type forecastScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *forecastMetadata
+	prometheus *prometheusScaler
+	// store persists the history, nil keeps the history in memory only
+	store  forecastHistoryStore
+	logger logr.Logger
+
+	mutex   sync.Mutex
+	history []forecastSample
+	// storeLoaded is set once the persisted history is loaded, the history is not saved before
+	// so the persisted history is never overwritten
+	storeLoaded bool
+	loaded      bool
+	loadBackoff time.Duration
+	nextLoad    time.Time
+}
+
+// forecastMetadata contains the forecasting parameters, the Prometheus query of the forecasted metric
+// is configured with the metadata of the prometheus scaler
+type forecastMetadata struct {
+	triggerIndex int
+
+	Threshold           float64        `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64        `keda:"name=activationThreshold, order=triggerMetadata, optional"`
+	Model               forecast.Model `keda:"name=model,               order=triggerMetadata, enum=holtWinters;seasonalNaive, default=holtWinters"`
+	Horizon             string         `keda:"name=horizon,             order=triggerMetadata, default=10m"`
+	Step                string         `keda:"name=step,                order=triggerMetadata, default=5m"`
+	SeasonLength        string         `keda:"name=seasonLength,        order=triggerMetadata, default=24h"`
+	HistoryWindow       string         `keda:"name=historyWindow,       order=triggerMetadata, default=7d"`
+	Alpha               float64        `keda:"name=alpha,               order=triggerMetadata, default=0.5"`
+	Beta                float64        `keda:"name=beta,                order=triggerMetadata, default=0.1"`
+	Gamma               float64        `keda:"name=gamma,               order=triggerMetadata, default=0.3"`
+	HistoryStore        string         `keda:"name=historyStore,        order=triggerMetadata, enum=configMap;memory, default=memory"`
+
+	horizon       time.Duration
+	step          time.Duration
+	seasonLength  time.Duration
+	historyWindow time.Duration
+}
+
+// forecastSample is a value of the history, the timestamp is in unix seconds
+type forecastSample struct {
+	Timestamp int64   `json:"t"`
+	Value     float64 `json:"v"`
+}
+
+// forecastHistoryStore persists the history of the forecasted metric
+type forecastHistoryStore interface {
+	Load(ctx context.Context) ([]forecastSample, error)
+	Save(ctx context.Context, history []forecastSample) error
+}
+
+func (m *forecastMetadata) Validate() error {
+	var err error
+	if m.horizon, err = str2duration.ParseDuration(m.Horizon); err != nil {
+		return fmt.Errorf("horizon parsing error %w", err)
+	}
+	if m.step, err = str2duration.ParseDuration(m.Step); err != nil {
+		return fmt.Errorf("step parsing error %w", err)
+	}
+	if m.seasonLength, err = str2duration.ParseDuration(m.SeasonLength); err != nil {
+		return fmt.Errorf("seasonLength parsing error %w", err)
+	}
+	if m.historyWindow, err = str2duration.ParseDuration(m.HistoryWindow); err != nil {
+		return fmt.Errorf("historyWindow parsing error %w", err)
+	}
+
+	// the history is kept with a resolution of a second
+	if m.step < time.Second {
+		return fmt.Errorf("step must be at least 1s")
+	}
+	if m.horizon <= 0 {
+		return fmt.Errorf("horizon must be greater than 0")
+	}
+	if m.seasonLength < m.step {
+		return fmt.Errorf("seasonLength must be at least one step")
+	}
+	seasons := 1
+	if m.Model == forecast.ModelHoltWinters {
+		seasons = 2
+	}
+	if m.historyWindow < time.Duration(seasons)*m.seasonLength {
+		return fmt.Errorf("historyWindow must cover at least %d seasons for the %s model", seasons, m.Model)
+	}
+	for _, factor := range []float64{m.Alpha, m.Beta, m.Gamma} {
+		if factor < 0 || factor > 1 {
+			return fmt.Errorf("alpha, beta and gamma must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// seasonSteps returns the number of steps of a season
+func (m *forecastMetadata) seasonSteps() int {
+	return int(m.seasonLength / m.step)
+}
+
+// horizonSteps returns the number of steps to the forecasted value
+func (m *forecastMetadata) horizonSteps() int {
+	return int(math.Ceil(float64(m.horizon) / float64(m.step)))
+}
+
+// NewForecastScaler creates a new forecastScaler
+func NewForecastScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseForecastMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing forecast metadata: %w", err)
+	}
+
+	prometheus, err := NewPrometheusScaler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &forecastScaler{
+		metricType: metricType,
+		metadata:   meta,
+		prometheus: prometheus.(*prometheusScaler),
+		logger:     InitializeLogger(config, "forecast_scaler"),
+	}
+	if meta.HistoryStore == forecastHistoryStoreConfigMap {
+		s.store = newConfigMapForecastHistoryStore(kubeClient, config)
+	}
+	return s, nil
+}
+
+func parseForecastMetadata(config *scalersconfig.ScalerConfig) (*forecastMetadata, error) {
+	meta := &forecastMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing forecast metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *forecastScaler) Close(ctx context.Context) error {
+	return s.prometheus.Close(ctx)
+}
+
+func (s *forecastScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString("forecast")
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *forecastScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	val, err := s.getForecastedValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error forecasting prometheus query")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.ActivationThreshold, nil
+}
+
+// getForecastedValue records the current value of the query in the history and returns the value forecasted
+// horizon ahead, or the current value if it is higher, so the target is scaled ahead of the peaks but never below
+// the current load. The current value is returned as long as the history is too short for forecasting.
+func (s *forecastScaler) getForecastedValue(ctx context.Context) (float64, error) {
+	current, err := s.prometheus.ExecutePromQuery(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if !s.loaded && !now.Before(s.nextLoad) {
+		if s.loadHistory(ctx, now) {
+			s.loaded = true
+		} else {
+			s.loadBackoff = min(max(2*s.loadBackoff, forecastLoadMinBackoff), forecastLoadMaxBackoff)
+			s.nextLoad = now.Add(s.loadBackoff)
+			s.logger.V(1).Info("Forecast history not loaded, retrying later", "retryAfter", s.loadBackoff)
+		}
+	}
+	if s.recordSample(now, current) {
+		s.saveHistory(ctx)
+	}
+
+	values := resampleForecastHistory(s.history, s.metadata.step)
+	forecasted, err := forecast.Forecast(s.metadata.Model, values, s.metadata.seasonSteps(), s.metadata.horizonSteps(),
+		forecast.Parameters{Alpha: s.metadata.Alpha, Beta: s.metadata.Beta, Gamma: s.metadata.Gamma})
+	if err != nil {
+		s.logger.V(1).Info("Not able to forecast, using the current value", "reason", err.Error())
+		return current, nil
+	}
+
+	s.logger.V(1).Info("Forecasted value", "current", current, "forecasted", forecasted, "horizon", s.metadata.horizon)
+	return max(current, forecasted), nil
+}
+
+// loadHistory loads the persisted history and backfills it from Prometheus, if it doesn't cover the history window
+// or has a gap, e.g. because the operator was down. It returns false if the persisted history couldn't be loaded
+// or the history has to be backfilled and Prometheus returned an error or no samples, so the load is retried.
+func (s *forecastScaler) loadHistory(ctx context.Context, now time.Time) bool {
+	if s.store != nil && !s.storeLoaded {
+		history, err := s.store.Load(ctx)
+		if err != nil {
+			s.logger.Error(err, "error loading forecast history")
+			return false
+		}
+		s.history = mergeForecastHistory(history, s.history)
+		s.storeLoaded = true
+	}
+	s.trimHistory(now)
+
+	if len(s.history) > 0 &&
+		!time.Unix(s.history[0].Timestamp, 0).After(now.Add(-s.metadata.historyWindow+s.metadata.step)) &&
+		time.Unix(s.history[len(s.history)-1].Timestamp, 0).After(now.Add(-2*s.metadata.step)) {
+		return true
+	}
+
+	samples, err := s.prometheus.ExecutePromRangeQuery(ctx, now.Add(-s.metadata.historyWindow), now, s.metadata.step)
+	if err != nil {
+		s.logger.Error(err, "error backfilling forecast history from prometheus")
+		return false
+	}
+	if len(samples) == 0 {
+		return false
+	}
+
+	// Prometheus may have a shorter retention than the persisted history, keep the samples it doesn't cover
+	firstBackfilled := samples[0].Timestamp.Unix()
+	history := make([]forecastSample, 0, len(s.history)+len(samples))
+	for _, sample := range s.history {
+		if sample.Timestamp < firstBackfilled {
+			history = append(history, sample)
+		}
+	}
+	for _, sample := range samples {
+		history = append(history, forecastSample{Timestamp: sample.Timestamp.Unix(), Value: sample.Value})
+	}
+	s.history = mergeForecastHistory(history, s.history)
+	s.logger.V(1).Info("Backfilled forecast history from prometheus", "samples", len(samples))
+	s.saveHistory(ctx)
+	return true
+}
+
+// mergeForecastHistory appends the samples of recorded newer than the last sample of history,
+// so the samples recorded while the history couldn't be loaded are kept
+func mergeForecastHistory(history, recorded []forecastSample) []forecastSample {
+	for _, sample := range recorded {
+		if len(history) == 0 || sample.Timestamp > history[len(history)-1].Timestamp {
+			history = append(history, sample)
+		}
+	}
+	return history
+}
+
+// recordSample appends the value to the history if a step elapsed since the last sample
+func (s *forecastScaler) recordSample(now time.Time, value float64) bool {
+	if len(s.history) > 0 && now.Sub(time.Unix(s.history[len(s.history)-1].Timestamp, 0)) < s.metadata.step {
+		return false
+	}
+	s.history = append(s.history, forecastSample{Timestamp: now.Unix(), Value: value})
+	s.trimHistory(now)
+	return true
+}
+
+// trimHistory drops the samples older than the history window
+func (s *forecastScaler) trimHistory(now time.Time) {
+	cutoff := now.Add(-s.metadata.historyWindow).Unix()
+	i := 0
+	for i < len(s.history) && s.history[i].Timestamp < cutoff {
+		i++
+	}
+	s.history = s.history[i:]
+}
+
+func (s *forecastScaler) saveHistory(ctx context.Context) {
+	if s.store == nil || !s.storeLoaded {
+		return
+	}
+	if err := s.store.Save(ctx, s.history); err != nil {
+		s.logger.Error(err, "error saving forecast history")
+	}
+}
+
+// resampleForecastHistory returns the values of the history at every step from the first sample to the last one,
+// a step without a sample gets the value of the previous sample
+func resampleForecastHistory(history []forecastSample, step time.Duration) []float64 {
+	if len(history) == 0 {
+		return nil
+	}
+	stepSeconds := int64(step / time.Second)
+	start := history[0].Timestamp
+	count := int((history[len(history)-1].Timestamp-start)/stepSeconds) + 1
+
+	values := make([]float64, count)
+	j := 0
+	for i := range values {
+		timestamp := start + int64(i)*stepSeconds
+		for j+1 < len(history) && history[j+1].Timestamp <= timestamp {
+			j++
+		}
+		values[i] = history[j].Value
+	}
+	return values
+}
+
+// configMapForecastHistoryStore persists the history in a ConfigMap owned by the ScaledObject or ScaledJob
+type configMapForecastHistoryStore struct {
+	client          client.Client
+	name            string
+	namespace       string
+	ownerReferences []metav1.OwnerReference
+}
+
+func newConfigMapForecastHistoryStore(kubeClient client.Client, config *scalersconfig.ScalerConfig) *configMapForecastHistoryStore {
+	// the kind is part of the name, so a ScaledObject and a ScaledJob with the same name don't share the history
+	prefix := fmt.Sprintf("keda-forecast-%s-", strings.ToLower(config.ScalableObjectType))
+	suffix := fmt.Sprintf("-%d", config.TriggerIndex)
+	objectName := config.ScalableObjectName
+	if maxLength := 253 - len(prefix) - len(suffix); len(objectName) > maxLength {
+		objectName = objectName[:maxLength]
+	}
+	name := prefix + objectName + suffix
+	store := &configMapForecastHistoryStore{
+		client:    kubeClient,
+		name:      name,
+		namespace: config.ScalableObjectNamespace,
+	}
+	if owner, err := meta.Accessor(config.ScaledObject); err == nil && owner.GetUID() != "" && config.ScalableObjectType != "" {
+		store.ownerReferences = []metav1.OwnerReference{{
+			APIVersion: kedav1alpha1.SchemeGroupVersion.String(),
+			Kind:       config.ScalableObjectType,
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+		}}
+	}
+	return store
+}
+
+func (c *configMapForecastHistoryStore) Load(ctx context.Context) ([]forecastSample, error) {
+	configMap := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, types.NamespacedName{Name: c.name, Namespace: c.namespace}, configMap)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var history []forecastSample
+	if data, ok := configMap.Data[forecastHistoryConfigMapKey]; ok {
+		if err := json.Unmarshal([]byte(data), &history); err != nil {
+			return nil, fmt.Errorf("error parsing forecast history of ConfigMap %s/%s: %w", c.namespace, c.name, err)
+		}
+	}
+	return history, nil
+}
+
+func (c *configMapForecastHistoryStore) Save(ctx context.Context, history []forecastSample) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = c.client.Get(ctx, types.NamespacedName{Name: c.name, Namespace: c.namespace}, configMap)
+	if errors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            c.name,
+				Namespace:       c.namespace,
+				Labels:          map[string]string{"app.kubernetes.io/managed-by": "keda-operator"},
+				OwnerReferences: c.ownerReferences,
+			},
+			Data: map[string]string{forecastHistoryConfigMapKey: string(data)},
+		}
+		return c.client.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[forecastHistoryConfigMapKey] = string(data)
+	return c.client.Update(ctx, configMap)
+}
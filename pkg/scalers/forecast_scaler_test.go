@@ -0,0 +1,279 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseForecastMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type forecastMetricIdentifier struct {
+	metadataTestData *parseForecastMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testForecastMetadata = []parseForecastMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed with defaults
+	{map[string]string{"threshold": "100"}, false},
+	// properly formed
+	{map[string]string{"threshold": "100", "model": "seasonalNaive", "horizon": "30m", "step": "1m", "seasonLength": "7d", "historyWindow": "14d", "historyStore": "memory"}, false},
+	// unknown model
+	{map[string]string{"threshold": "100", "model": "arima"}, true},
+	// unknown history store
+	{map[string]string{"threshold": "100", "historyStore": "disk"}, true},
+	// invalid horizon
+	{map[string]string{"threshold": "100", "horizon": "soon"}, true},
+	// history window shorter than two seasons for holtWinters
+	{map[string]string{"threshold": "100", "historyWindow": "36h"}, true},
+	// history window of one season for seasonalNaive
+	{map[string]string{"threshold": "100", "model": "seasonalNaive", "historyWindow": "24h"}, false},
+	// sub-second step
+	{map[string]string{"threshold": "100", "step": "500ms"}, true},
+	// season shorter than a step
+	{map[string]string{"threshold": "100", "step": "2h", "seasonLength": "1h", "historyWindow": "2h"}, true},
+	// smoothing factor out of range
+	{map[string]string{"threshold": "100", "alpha": "1.5"}, true},
+}
+
+var forecastMetricIdentifiers = []forecastMetricIdentifier{
+	{&testForecastMetadata[1], 0, "s0-forecast"},
+	{&testForecastMetadata[1], 1, "s1-forecast"},
+}
+
+func TestForecastParseMetadata(t *testing.T) {
+	for _, testData := range testForecastMetadata {
+		_, err := parseForecastMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestForecastGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range forecastMetricIdentifiers {
+		meta, err := parseForecastMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockForecastScaler := forecastScaler{metadata: meta}
+
+		metricSpec := mockForecastScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// newForecastPrometheusServer returns a Prometheus server answering range queries with the index of every sample
+// as its value and instant queries with the current value
+func newForecastPrometheusServer(t *testing.T, current string, backfill bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/api/v1/query":
+			fmt.Fprintf(writer, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[%d,"%s"]}]}}`, time.Now().Unix(), current)
+		case "/api/v1/query_range":
+			if !backfill {
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			start, err := time.Parse(time.RFC3339, request.URL.Query().Get("start"))
+			require.NoError(t, err)
+			end, err := time.Parse(time.RFC3339, request.URL.Query().Get("end"))
+			require.NoError(t, err)
+			step, err := strconv.ParseFloat(request.URL.Query().Get("step"), 64)
+			require.NoError(t, err)
+
+			values := ""
+			for i := 0; !start.Add(time.Duration(i) * time.Duration(step) * time.Second).After(end); i++ {
+				if i > 0 {
+					values += ","
+				}
+				values += fmt.Sprintf(`[%d,"%d"]`, start.Add(time.Duration(i)*time.Duration(step)*time.Second).Unix(), i)
+			}
+			fmt.Fprintf(writer, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[%s]}]}}`, values)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestForecastScalerBackfillsAndPersistsHistory(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+	metadata := map[string]string{
+		"threshold":     "10",
+		"query":         "sum(rate(http_requests_total[1m]))",
+		"model":         "seasonalNaive",
+		"horizon":       "1h",
+		"step":          "1h",
+		"seasonLength":  "24h",
+		"historyWindow": "48h",
+		"historyStore":  "configMap",
+	}
+
+	server := newForecastPrometheusServer(t, "5", true)
+	defer server.Close()
+	metadata["serverAddress"] = server.URL
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata:         metadata,
+		ScalableObjectName:      "consumer",
+		ScalableObjectNamespace: "default",
+		ScalableObjectType:      "ScaledObject",
+	}
+
+	scaler, err := NewForecastScaler(kubeClient, config)
+	require.NoError(t, err)
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	// 49 samples are backfilled with their index as value, the value one season before the horizon is 25
+	assert.Equal(t, int64(25), metrics[0].Value.Value())
+	assert.True(t, active)
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{Name: "keda-forecast-scaledobject-consumer-0", Namespace: "default"}, configMap))
+	assert.Contains(t, configMap.Data, forecastHistoryConfigMapKey)
+
+	// after a restart the history is loaded from the ConfigMap, even if Prometheus can't backfill it
+	failingServer := newForecastPrometheusServer(t, "5", false)
+	defer failingServer.Close()
+	metadata["serverAddress"] = failingServer.URL
+
+	scaler, err = NewForecastScaler(kubeClient, config)
+	require.NoError(t, err)
+
+	metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), metrics[0].Value.Value())
+}
+
+func TestForecastHistoryConfigMapName(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+	scaledObjectStore := newConfigMapForecastHistoryStore(kubeClient, &scalersconfig.ScalerConfig{
+		ScalableObjectName: "consumer",
+		ScalableObjectType: "ScaledObject",
+		TriggerIndex:       1,
+	})
+	scaledJobStore := newConfigMapForecastHistoryStore(kubeClient, &scalersconfig.ScalerConfig{
+		ScalableObjectName: "consumer",
+		ScalableObjectType: "ScaledJob",
+		TriggerIndex:       1,
+	})
+	assert.Equal(t, "keda-forecast-scaledobject-consumer-1", scaledObjectStore.name)
+	assert.Equal(t, "keda-forecast-scaledjob-consumer-1", scaledJobStore.name)
+
+	longNameStore := newConfigMapForecastHistoryStore(kubeClient, &scalersconfig.ScalerConfig{
+		ScalableObjectName: strings.Repeat("a", 253),
+		ScalableObjectType: "ScaledJob",
+		TriggerIndex:       1,
+	})
+	assert.Len(t, longNameStore.name, 253)
+	assert.True(t, strings.HasPrefix(longNameStore.name, "keda-forecast-scaledjob-"))
+	assert.True(t, strings.HasSuffix(longNameStore.name, "-1"))
+}
+
+func TestForecastScalerRetriesFailedBackfill(t *testing.T) {
+	failingServer := newForecastPrometheusServer(t, "5", false)
+	defer failingServer.Close()
+	backfillingServer := newForecastPrometheusServer(t, "5", true)
+	defer backfillingServer.Close()
+	var backfill atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if backfill.Load() {
+			backfillingServer.Config.Handler.ServeHTTP(writer, request)
+			return
+		}
+		failingServer.Config.Handler.ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	scaler, err := NewForecastScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"serverAddress": server.URL,
+			"threshold":     "10",
+			"query":         "sum(rate(http_requests_total[1m]))",
+			"model":         "seasonalNaive",
+			"horizon":       "1h",
+			"step":          "1h",
+			"seasonLength":  "24h",
+			"historyWindow": "48h",
+			"historyStore":  "memory",
+		},
+	})
+	require.NoError(t, err)
+	forecastScaler := scaler.(*forecastScaler)
+
+	metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), metrics[0].Value.Value())
+	assert.False(t, forecastScaler.loaded)
+	assert.Equal(t, forecastLoadMinBackoff, forecastScaler.loadBackoff)
+
+	// the backfill is not retried before the backoff elapsed
+	backfill.Store(true)
+	metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), metrics[0].Value.Value())
+	assert.False(t, forecastScaler.loaded)
+
+	forecastScaler.nextLoad = time.Time{}
+	metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), metrics[0].Value.Value())
+	assert.True(t, forecastScaler.loaded)
+}
+
+func TestForecastScalerUsesCurrentValueWithoutHistory(t *testing.T) {
+	server := newForecastPrometheusServer(t, "5", false)
+	defer server.Close()
+
+	scaler, err := NewForecastScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"serverAddress": server.URL,
+			"threshold":     "10",
+			"query":         "sum(rate(http_requests_total[1m]))",
+			"historyStore":  "memory",
+		},
+	})
+	require.NoError(t, err)
+
+	metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), "forecast")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), metrics[0].Value.Value())
+}
+
+func TestResampleForecastHistory(t *testing.T) {
+	history := []forecastSample{
+		{Timestamp: 0, Value: 1},
+		{Timestamp: 60, Value: 2},
+		{Timestamp: 200, Value: 3},
+		{Timestamp: 230, Value: 4},
+		{Timestamp: 300, Value: 5},
+	}
+	assert.Equal(t, []float64{1, 2, 2, 2, 4, 5}, resampleForecastHistory(history, time.Minute))
+	assert.Nil(t, resampleForecastHistory(nil, time.Minute))
+}
@@ -0,0 +1,194 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/scale"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// kubernetesReplicasScaler follows the replica count of another workload, or the desired replica count of
+// another ScaledObject, so that the target is scaled proportionally to it
+type kubernetesReplicasScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    kubernetesReplicasMetadata
+	kubeClient  client.Client
+	scaleClient scale.ScalesGetter
+	logger      logr.Logger
+}
+
+const (
+	kubernetesReplicasSpec   = "spec"
+	kubernetesReplicasStatus = "status"
+)
+
+type kubernetesReplicasMetadata struct {
+	Name             string  `keda:"name=name,             order=triggerMetadata, optional"`
+	Kind             string  `keda:"name=kind,             order=triggerMetadata, default=Deployment"`
+	APIVersion       string  `keda:"name=apiVersion,       order=triggerMetadata, default=apps/v1"`
+	Replicas         string  `keda:"name=replicas,         order=triggerMetadata, enum=spec;status, default=spec"`
+	ScaledObjectName string  `keda:"name=scaledObjectName, order=triggerMetadata, optional"`
+	Value            float64 `keda:"name=value,            order=triggerMetadata, default=1"`
+	ActivationValue  float64 `keda:"name=activationValue,  order=triggerMetadata, default=0"`
+
+	namespace          string
+	scalableObjectName string
+	triggerIndex       int
+	asMetricSource     bool
+}
+
+func (m *kubernetesReplicasMetadata) Validate() error {
+	if (m.Name == "") == (m.ScaledObjectName == "") {
+		return fmt.Errorf("exactly one of name or scaledObjectName must be provided")
+	}
+	if m.ScaledObjectName != "" && m.ScaledObjectName == m.scalableObjectName {
+		return fmt.Errorf("scaledObjectName can't reference the ScaledObject of the trigger")
+	}
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+
+	return nil
+}
+
+// NewKubernetesReplicasScaler creates a new kubernetesReplicasScaler
+func NewKubernetesReplicasScaler(kubeClient client.Client, scaleClient scale.ScalesGetter, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKubernetesReplicasMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes replicas metadata: %w", err)
+	}
+
+	return &kubernetesReplicasScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		kubeClient:  kubeClient,
+		scaleClient: scaleClient,
+		logger:      InitializeLogger(config, "kubernetes_replicas_scaler"),
+	}, nil
+}
+
+func parseKubernetesReplicasMetadata(config *scalersconfig.ScalerConfig) (kubernetesReplicasMetadata, error) {
+	meta := kubernetesReplicasMetadata{}
+	meta.namespace = config.ScalableObjectNamespace
+	meta.scalableObjectName = config.ScalableObjectName
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
+
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, fmt.Errorf("error parsing kubernetes replicas metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+func (s *kubernetesReplicasScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *kubernetesReplicasScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := s.metadata.Name
+	if s.metadata.ScaledObjectName != "" {
+		name = s.metadata.ScaledObjectName
+	}
+	metricName := kedautil.NormalizeString(fmt.Sprintf("replicas-%s", name))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric
+func (s *kubernetesReplicasScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var replicas int32
+	var err error
+	if s.metadata.ScaledObjectName != "" {
+		replicas, err = s.getScaledObjectDesiredReplicas(ctx)
+	} else {
+		replicas, err = s.getWorkloadReplicas(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting followed replicas: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(replicas))
+
+	return []external_metrics.ExternalMetricValue{metric}, float64(replicas) > s.metadata.ActivationValue, nil
+}
+
+// getWorkloadReplicas returns the replica count of the followed workload read through its scale subresource,
+// status replicas only count the instances the workload controller observes
+func (s *kubernetesReplicasScaler) getWorkloadReplicas(ctx context.Context) (int32, error) {
+	gvkr, err := kedav1alpha1.ParseGVKR(s.kubeClient.RESTMapper(), s.metadata.APIVersion, s.metadata.Kind)
+	if err != nil {
+		return 0, err
+	}
+
+	scale, err := s.scaleClient.Scales(s.metadata.namespace).Get(ctx, gvkr.GroupResource(), s.metadata.Name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	if s.metadata.Replicas == kubernetesReplicasStatus {
+		return scale.Status.Replicas, nil
+	}
+	return scale.Spec.Replicas, nil
+}
+
+// getScaledObjectDesiredReplicas returns the replica count the followed ScaledObject is scaling its target to:
+// the replica count computed in dry-run mode, the desired replicas of its HPA, or the replicas of its target
+// when the target is scaled to zero or has no HPA
+func (s *kubernetesReplicasScaler) getScaledObjectDesiredReplicas(ctx context.Context) (int32, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := s.kubeClient.Get(ctx, types.NamespacedName{Name: s.metadata.ScaledObjectName, Namespace: s.metadata.namespace}, scaledObject); err != nil {
+		return 0, err
+	}
+
+	if scaledObject.IsDryRun() && scaledObject.Status.DryRunReplicaCount != nil {
+		return *scaledObject.Status.DryRunReplicaCount, nil
+	}
+
+	gvkr := scaledObject.Status.ScaleTargetGVKR
+	if gvkr == nil {
+		return 0, fmt.Errorf("scale target of ScaledObject %s/%s isn't resolved yet", s.metadata.namespace, s.metadata.ScaledObjectName)
+	}
+
+	scale, err := s.scaleClient.Scales(s.metadata.namespace).Get(ctx, gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	// the HPA keeps its last desired replicas while KEDA holds the target at zero
+	if scale.Spec.Replicas == 0 || scaledObject.Status.HpaName == "" {
+		return scale.Spec.Replicas, nil
+	}
+
+	hpa := &v2.HorizontalPodAutoscaler{}
+	err = s.kubeClient.Get(ctx, types.NamespacedName{Name: scaledObject.Status.HpaName, Namespace: s.metadata.namespace}, hpa)
+	switch {
+	case errors.IsNotFound(err):
+		return scale.Spec.Replicas, nil
+	case err != nil:
+		return 0, err
+	}
+	return hpa.Status.DesiredReplicas, nil
+}
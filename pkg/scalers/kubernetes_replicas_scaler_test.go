@@ -0,0 +1,184 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseKubernetesReplicasMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type kubernetesReplicasMetricIdentifier struct {
+	metadataTestData *parseKubernetesReplicasMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testKubernetesReplicasMetadata = []parseKubernetesReplicasMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed with defaults
+	{map[string]string{"name": "api"}, false},
+	// properly formed
+	{map[string]string{"name": "api", "kind": "StatefulSet", "apiVersion": "apps/v1", "replicas": "status", "value": "4", "activationValue": "1"}, false},
+	// properly formed with a ScaledObject
+	{map[string]string{"scaledObjectName": "api", "value": "4"}, false},
+	// both a workload and a ScaledObject
+	{map[string]string{"name": "api", "scaledObjectName": "api"}, true},
+	// the ScaledObject of the trigger
+	{map[string]string{"scaledObjectName": "proxy"}, true},
+	// unknown replicas
+	{map[string]string{"name": "api", "replicas": "ready"}, true},
+	// invalid value
+	{map[string]string{"name": "api", "value": "0"}, true},
+}
+
+var kubernetesReplicasMetricIdentifiers = []kubernetesReplicasMetricIdentifier{
+	{&testKubernetesReplicasMetadata[1], 0, "s0-replicas-api"},
+	{&testKubernetesReplicasMetadata[3], 1, "s1-replicas-api"},
+}
+
+func TestKubernetesReplicasParseMetadata(t *testing.T) {
+	for _, testData := range testKubernetesReplicasMetadata {
+		_, err := parseKubernetesReplicasMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectName: "proxy"})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestKubernetesReplicasGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range kubernetesReplicasMetricIdentifiers {
+		meta, err := parseKubernetesReplicasMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockKubernetesReplicasScaler := kubernetesReplicasScaler{metadata: meta}
+
+		metricSpec := mockKubernetesReplicasScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func expectKubernetesReplicasScale(ctrl *gomock.Controller, scaleClient *mock_scale.MockScalesGetter, resource schema.GroupResource, name string, specReplicas, statusReplicas int32) {
+	scaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	scaleClient.EXPECT().Scales(gomock.Eq("default")).Return(scaleInterface)
+	scaleInterface.EXPECT().Get(gomock.Any(), gomock.Eq(resource), gomock.Eq(name), gomock.Any()).Return(&autoscalingv1.Scale{
+		Spec:   autoscalingv1.ScaleSpec{Replicas: specReplicas},
+		Status: autoscalingv1.ScaleStatus{Replicas: statusReplicas},
+	}, nil)
+}
+
+func TestKubernetesReplicasScalerFollowsWorkload(t *testing.T) {
+	tests := []struct {
+		replicas string
+		expected int64
+	}{
+		{kubernetesReplicasSpec, 8},
+		{kubernetesReplicasStatus, 6},
+	}
+
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		scaleClient := mock_scale.NewMockScalesGetter(ctrl)
+		expectKubernetesReplicasScale(ctrl, scaleClient, schema.GroupResource{Group: "apps", Resource: "deployments"}, "api", 8, 6)
+
+		scaler, err := NewKubernetesReplicasScaler(fake.NewClientBuilder().Build(), scaleClient, &scalersconfig.ScalerConfig{
+			TriggerMetadata:         map[string]string{"name": "api", "replicas": test.replicas, "value": "4"},
+			ScalableObjectName:      "proxy",
+			ScalableObjectNamespace: "default",
+		})
+		require.NoError(t, err)
+
+		metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "replicas")
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, metrics[0].Value.Value())
+		assert.True(t, active)
+	}
+}
+
+func TestKubernetesReplicasScalerFollowsScaledObject(t *testing.T) {
+	gvkr := &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+	tests := []struct {
+		name           string
+		dryRun         bool
+		hpaName        string
+		targetReplicas int32
+		expected       int64
+	}{
+		{"desired replicas of the HPA", false, "keda-hpa-api", 3, 9},
+		{"target scaled to zero", false, "keda-hpa-api", 0, 0},
+		{"missing HPA", false, "keda-hpa-missing", 3, 3},
+		{"without HPA", false, "", 3, 3},
+		{"dry-run", true, "keda-hpa-api", 3, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "api"},
+				},
+				Status: kedav1alpha1.ScaledObjectStatus{
+					ScaleTargetGVKR:    gvkr,
+					HpaName:            test.hpaName,
+					DryRunReplicaCount: ptr.To[int32](5),
+				},
+			}
+			if test.dryRun {
+				scaledObject.Annotations = map[string]string{kedav1alpha1.DryRunAnnotation: "true"}
+			}
+			hpa := &v2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "keda-hpa-api", Namespace: "default"},
+				Status:     v2.HorizontalPodAutoscalerStatus{DesiredReplicas: 9},
+			}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+			require.NoError(t, v2.AddToScheme(scheme))
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject, hpa).Build()
+
+			ctrl := gomock.NewController(t)
+			scaleClient := mock_scale.NewMockScalesGetter(ctrl)
+			if !test.dryRun {
+				expectKubernetesReplicasScale(ctrl, scaleClient, gvkr.GroupResource(), "api", test.targetReplicas, test.targetReplicas)
+			}
+
+			scaler, err := NewKubernetesReplicasScaler(kubeClient, scaleClient, &scalersconfig.ScalerConfig{
+				TriggerMetadata:         map[string]string{"scaledObjectName": "api", "value": "4"},
+				ScalableObjectName:      "proxy",
+				ScalableObjectNamespace: "default",
+			})
+			require.NoError(t, err)
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "replicas")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.Equal(t, test.expected > 0, active)
+		})
+	}
+}
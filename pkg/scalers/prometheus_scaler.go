@@ -64,6 +64,24 @@ type promQueryResult struct {
 	} `json:"data"`
 }
 
+type promRangeQueryResult struct {
+	Status string `json:"status"`
+
+	Data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric struct{}        `json:"metric"`
+			Values [][]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// promSample is a value of a series returned by a range query
+type promSample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
 // NewPrometheusScaler creates a new prometheusScaler
 func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -187,53 +205,10 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
 	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, t)
 
-	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
-	if s.metadata.Namespace != "" {
-		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.Namespace)
-	}
-
-	for queryParameterKey, queryParameterValue := range s.metadata.QueryParameters {
-		queryParameterKeyEscaped := url_pkg.QueryEscape(queryParameterKey)
-		queryParameterValueEscaped := url_pkg.QueryEscape(queryParameterValue)
-		url = fmt.Sprintf("%s&%s=%s", url, queryParameterKeyEscaped, queryParameterValueEscaped)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return -1, err
-	}
-
-	for headerName, headerValue := range s.metadata.CustomHeaders {
-		req.Header.Add(headerName, headerValue)
-	}
-
-	switch {
-	case s.metadata.PrometheusAuth.Disabled():
-		break
-	case s.metadata.PrometheusAuth.EnabledBearerAuth():
-		req.Header.Set("Authorization", s.metadata.PrometheusAuth.GetBearerToken())
-	case s.metadata.PrometheusAuth.EnabledBasicAuth():
-		req.SetBasicAuth(s.metadata.PrometheusAuth.Username, s.metadata.PrometheusAuth.Password)
-	case s.metadata.PrometheusAuth.EnabledCustomAuth():
-		req.Header.Set(s.metadata.PrometheusAuth.CustomAuthHeader, s.metadata.PrometheusAuth.CustomAuthValue)
-	}
-
-	r, err := s.httpClient.Do(req)
-	if err != nil {
-		return -1, err
-	}
-
-	b, err := io.ReadAll(r.Body)
+	b, err := s.doPromRequest(ctx, url)
 	if err != nil {
 		return -1, err
 	}
-	defer r.Body.Close()
-
-	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
-		err := fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
-		s.logger.Error(err, "prometheus query api returned error")
-		return -1, err
-	}
 
 	var result promQueryResult
 	err = json.Unmarshal(b, &result)
@@ -285,6 +260,111 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	return v, nil
 }
 
+// ExecutePromRangeQuery executes the query over the range with the step and returns the samples of the series,
+// the query must not return multiple series
+func (s *prometheusScaler) ExecutePromRangeQuery(ctx context.Context, start, end time.Time, step time.Duration) ([]promSample, error) {
+	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
+	url := fmt.Sprintf("%s/api/v1/query_range?query=%s&start=%s&end=%s&step=%s", s.metadata.ServerAddress, queryEscaped,
+		start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339), strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	b, err := s.doPromRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var result promRangeQueryResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Data.Result) == 0 {
+		return nil, nil
+	} else if len(result.Data.Result) > 1 {
+		return nil, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.Query)
+	}
+
+	samples := make([]promSample, 0, len(result.Data.Result[0].Values))
+	for _, value := range result.Data.Result[0].Values {
+		if len(value) < 2 {
+			return nil, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.Query)
+		}
+		timestamp, ok := value[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %s returned an invalid timestamp %v", s.metadata.Query, value[0])
+		}
+		str, ok := value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %s returned an invalid value %v", s.metadata.Query, value[1])
+		}
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			continue
+		}
+		samples = append(samples, promSample{
+			Timestamp: time.Unix(0, int64(timestamp*float64(time.Second))),
+			Value:     v,
+		})
+	}
+	return samples, nil
+}
+
+// doPromRequest adds the namespace, the query parameters, the custom headers and the authentication
+// to the request of the Prometheus API and returns the body of a successful response
+func (s *prometheusScaler) doPromRequest(ctx context.Context, url string) ([]byte, error) {
+	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
+	if s.metadata.Namespace != "" {
+		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.Namespace)
+	}
+
+	for queryParameterKey, queryParameterValue := range s.metadata.QueryParameters {
+		queryParameterKeyEscaped := url_pkg.QueryEscape(queryParameterKey)
+		queryParameterValueEscaped := url_pkg.QueryEscape(queryParameterValue)
+		url = fmt.Sprintf("%s&%s=%s", url, queryParameterKeyEscaped, queryParameterValueEscaped)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for headerName, headerValue := range s.metadata.CustomHeaders {
+		req.Header.Add(headerName, headerValue)
+	}
+
+	switch {
+	case s.metadata.PrometheusAuth.Disabled():
+		break
+	case s.metadata.PrometheusAuth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.PrometheusAuth.GetBearerToken())
+	case s.metadata.PrometheusAuth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.PrometheusAuth.Username, s.metadata.PrometheusAuth.Password)
+	case s.metadata.PrometheusAuth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.PrometheusAuth.CustomAuthHeader, s.metadata.PrometheusAuth.CustomAuthValue)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
+		err := fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
+		s.logger.Error(err, "prometheus query api returned error")
+		return nil, err
+	}
+
+	return b, nil
+}
+
 func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// triggerMetricKey identifies a metric of a trigger of a ScaledObject or ScaledJob, unnamed triggers
+// of the same type share their name so the triggers are identified by their index
+type triggerMetricKey struct {
+	triggerIndex int
+	metricName   string
+}
+
+// triggerActivity is the activation of a trigger metric kept across the polls for the trigger hysteresis
+type triggerActivity struct {
+	active        bool
+	inactivePolls int32
+}
+
+// applyActivationHysteresis returns the activation of the trigger metric after applying the hysteresis of the trigger
+// to the activation reported by the scaler. An active trigger stays active while its value is above the deactivation
+// threshold and until it was inactive for the configured number of consecutive polls. The scalable object is kept active
+// meanwhile, so its LastActiveTime is updated and the cooldownPeriod starts only once the trigger is deactivated.
+// The activation is restored from triggerStatuses after a restart, ScaledJobs don't have any trigger statuses.
+func (h *scaleHandler) applyActivationHysteresis(key string, triggers []kedav1alpha1.ScaleTriggers, triggerStatuses []kedav1alpha1.TriggerStatus,
+	triggerIndex int, metricName string, isActive bool, metrics []external_metrics.ExternalMetricValue) bool {
+	if triggerIndex >= len(triggers) || triggers[triggerIndex].Hysteresis == nil {
+		return isActive
+	}
+	hysteresis := triggers[triggerIndex].Hysteresis
+
+	h.triggerActivitiesLock.Lock()
+	defer h.triggerActivitiesLock.Unlock()
+
+	if h.triggerActivities == nil {
+		h.triggerActivities = map[string]map[triggerMetricKey]*triggerActivity{}
+	}
+	if h.triggerActivities[key] == nil {
+		h.triggerActivities[key] = map[triggerMetricKey]*triggerActivity{}
+	}
+	activityKey := triggerMetricKey{triggerIndex: triggerIndex, metricName: metricName}
+	activity, found := h.triggerActivities[key][activityKey]
+	if !found {
+		// the activation is restored from the status after a restart of KEDA, so an active trigger isn't deactivated right away
+		activity = &triggerActivity{}
+		for _, trigger := range triggerStatuses {
+			if int(trigger.Index) == triggerIndex && trigger.MetricName == metricName {
+				activity.active = trigger.Active
+				break
+			}
+		}
+		h.triggerActivities[key][activityKey] = activity
+	}
+
+	if isActive {
+		activity.active = true
+		activity.inactivePolls = 0
+		return true
+	}
+	if !activity.active {
+		return false
+	}
+
+	if threshold, ok := hysteresis.GetDeactivationThreshold(); ok {
+		value := float64(0)
+		for _, metric := range metrics {
+			value += metric.Value.AsApproximateFloat64()
+		}
+		if value > threshold {
+			activity.inactivePolls = 0
+			return true
+		}
+	}
+
+	activity.inactivePolls++
+	if activity.inactivePolls < hysteresis.GetInactivePolls() {
+		return true
+	}
+	activity.active = false
+	activity.inactivePolls = 0
+	return false
+}
+
+// deleteTriggerActivities removes the activation of the triggers of the scalableObject kept for the hysteresis
+func (h *scaleHandler) deleteTriggerActivities(key string) {
+	h.triggerActivitiesLock.Lock()
+	defer h.triggerActivitiesLock.Unlock()
+	delete(h.triggerActivities, key)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestApplyActivationHysteresis(t *testing.T) {
+	inactivePolls := int32(3)
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{
+					Type: "rabbitmq",
+					Hysteresis: &kedav1alpha1.TriggerHysteresis{
+						DeactivationThreshold: "2",
+						InactivePolls:         &inactivePolls,
+					},
+				},
+				{
+					Type: "prometheus",
+				},
+			},
+		},
+	}
+	metrics := func(value float64) []external_metrics.ExternalMetricValue {
+		return []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", value)}
+	}
+
+	sh := scaleHandler{}
+	apply := func(isActive bool, value float64) bool {
+		return sh.applyActivationHysteresis(scaledObject.GenerateIdentifier(), scaledObject.Spec.Triggers, scaledObject.Status.Triggers,
+			0, "s0-queue", isActive, metrics(value))
+	}
+
+	// inactive until the scaler reports activity
+	assert.False(t, apply(false, 4))
+	assert.True(t, apply(true, 10))
+
+	// above the deactivation threshold the trigger stays active
+	assert.True(t, apply(false, 4))
+	assert.True(t, apply(false, 3))
+
+	// below the deactivation threshold it has to be inactive for 3 consecutive polls
+	assert.True(t, apply(false, 1))
+	assert.True(t, apply(false, 1))
+	// going above the threshold resets the polls
+	assert.True(t, apply(false, 4))
+	assert.True(t, apply(false, 1))
+	assert.True(t, apply(false, 0))
+	assert.False(t, apply(false, 0))
+
+	// once deactivated, values in between the thresholds don't activate the trigger
+	assert.False(t, apply(false, 4))
+
+	// triggers without hysteresis report the activity of the scaler
+	assert.False(t, sh.applyActivationHysteresis(scaledObject.GenerateIdentifier(), scaledObject.Spec.Triggers, scaledObject.Status.Triggers,
+		1, "s1-prometheus", false, metrics(100)))
+}
+
+func TestApplyActivationHysteresisRestoresActivityFromStatus(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{
+					Type:       "rabbitmq",
+					Hysteresis: &kedav1alpha1.TriggerHysteresis{DeactivationThreshold: "2"},
+				},
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Triggers: []kedav1alpha1.TriggerStatus{{Name: "rabbitmq", Index: 0, MetricName: "s0-queue", Active: true}},
+		},
+	}
+
+	sh := scaleHandler{}
+	assert.True(t, sh.applyActivationHysteresis(scaledObject.GenerateIdentifier(), scaledObject.Spec.Triggers, scaledObject.Status.Triggers,
+		0, "s0-queue", false, []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 4)}))
+
+	sh.deleteTriggerActivities(scaledObject.GenerateIdentifier())
+	scaledObject.Status.Triggers = nil
+	assert.False(t, sh.applyActivationHysteresis(scaledObject.GenerateIdentifier(), scaledObject.Spec.Triggers, scaledObject.Status.Triggers,
+		0, "s0-queue", false, []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 4)}))
+}
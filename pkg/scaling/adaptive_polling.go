@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// updateAdaptivePolling records whether the object was idle in the last poll, so the scale loop switches between
+// pollingInterval and the idle polling interval. The object is idle while it isn't active, none of the trigger
+// values reached the activation fraction of the trigger activation threshold and isScaledIn reports that the
+// target is scaled in, isScaledIn is called only if the object is idle otherwise.
+func (h *scaleHandler) updateAdaptivePolling(key string, adaptivePolling *kedav1alpha1.AdaptivePolling, triggers []kedav1alpha1.ScaleTriggers,
+	isActive bool, triggerValues map[int]float64, isScaledIn func() bool) {
+	if adaptivePolling == nil {
+		return
+	}
+	idle := !isActive
+	if idle {
+		fraction := adaptivePolling.GetActivationFraction()
+		for triggerIndex, value := range triggerValues {
+			if triggerIndex < len(triggers) && isNearActivation(&triggers[triggerIndex], value, fraction) {
+				idle = false
+				break
+			}
+		}
+	}
+	idle = idle && isScaledIn()
+
+	h.idleScalableObjectsLock.Lock()
+	defer h.idleScalableObjectsLock.Unlock()
+	if h.idleScalableObjects == nil {
+		h.idleScalableObjects = map[string]bool{}
+	}
+	h.idleScalableObjects[key] = idle
+}
+
+// isNearActivation returns whether the trigger value reached the fraction of its activation threshold,
+// triggers without any activation threshold activate on any positive value
+func isNearActivation(trigger *kedav1alpha1.ScaleTriggers, value float64, fraction float64) bool {
+	threshold, _ := trigger.GetActivationThreshold()
+	return value > 0 && value >= fraction*threshold
+}
+
+// isScaledObjectScaledIn returns whether the target of the ScaledObject is scaled to zero or to idleReplicaCount
+func (h *scaleHandler) isScaledObjectScaledIn(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) bool {
+	currentReplicas, err := resolver.GetCurrentReplicas(ctx, h.client, h.scaleClient, scaledObject)
+	if err != nil {
+		log.Error(err, "error getting current replicas for adaptive polling", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		return false
+	}
+	idleReplicas := scaledObject.GetIdleReplicaCount()
+	return currentReplicas == 0 || (idleReplicas != nil && currentReplicas == *idleReplicas)
+}
+
+// isScaledJobScaledIn returns whether the ScaledJob has no running jobs
+func (h *scaleHandler) isScaledJobScaledIn(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) bool {
+	jobs := &batchv1.JobList{}
+	err := h.client.List(ctx, jobs, client.InNamespace(scaledJob.Namespace),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.Name}))
+	if err != nil {
+		log.Error(err, "error listing jobs for adaptive polling", "scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+		return false
+	}
+	for _, job := range jobs.Items {
+		if !isJobFinished(&job) {
+			return false
+		}
+	}
+	return true
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// getPollingInterval returns the interval until the next poll of the object
+func (h *scaleHandler) getPollingInterval(withTriggers *kedav1alpha1.WithTriggers) time.Duration {
+	if withTriggers.Spec.AdaptivePolling == nil {
+		return withTriggers.GetPollingInterval()
+	}
+
+	h.idleScalableObjectsLock.Lock()
+	idle := h.idleScalableObjects[withTriggers.GenerateIdentifier()]
+	h.idleScalableObjectsLock.Unlock()
+	if idle {
+		return withTriggers.Spec.AdaptivePolling.GetIdlePollingInterval()
+	}
+	return withTriggers.GetPollingInterval()
+}
+
+func (h *scaleHandler) deleteAdaptivePolling(key string) {
+	h.idleScalableObjectsLock.Lock()
+	defer h.idleScalableObjectsLock.Unlock()
+	delete(h.idleScalableObjects, key)
+}
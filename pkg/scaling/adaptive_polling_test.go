@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestAdaptivePollingInterval(t *testing.T) {
+	pollingInterval := int32(30)
+	idlePollingInterval := int32(300)
+	triggers := []kedav1alpha1.ScaleTriggers{
+		{Type: "aws-sqs-queue", Metadata: map[string]string{"queueLength": "10", "activationQueueLength": "10"}},
+		{Type: "azure-monitor", Metadata: map[string]string{"targetValue": "50"}},
+	}
+	withTriggers := &kedav1alpha1.WithTriggers{
+		ObjectMeta:   metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		InternalKind: "ScaledObject",
+		Spec: kedav1alpha1.WithTriggersSpec{
+			PollingInterval: &pollingInterval,
+			AdaptivePolling: &kedav1alpha1.AdaptivePolling{IdlePollingInterval: &idlePollingInterval, ActivationFraction: "0.5"},
+			Triggers:        triggers,
+		},
+	}
+	key := withTriggers.GenerateIdentifier()
+
+	tests := []struct {
+		name             string
+		isActive         bool
+		scaledOut        bool
+		triggerValues    map[int]float64
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "idle",
+			triggerValues:    map[int]float64{0: 2, 1: 0},
+			expectedInterval: 300 * time.Second,
+		},
+		{
+			name:             "idle but not scaled in",
+			scaledOut:        true,
+			triggerValues:    map[int]float64{0: 2, 1: 0},
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:             "metric near activation threshold",
+			triggerValues:    map[int]float64{0: 5, 1: 0},
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:             "metric of trigger without activation threshold",
+			triggerValues:    map[int]float64{0: 0, 1: 1},
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:             "active",
+			isActive:         true,
+			triggerValues:    map[int]float64{},
+			expectedInterval: 30 * time.Second,
+		},
+	}
+
+	sh := scaleHandler{}
+	assert.Equal(t, 30*time.Second, sh.getPollingInterval(withTriggers), "the first poll uses pollingInterval")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sh.updateAdaptivePolling(key, withTriggers.Spec.AdaptivePolling, triggers, test.isActive, test.triggerValues,
+				func() bool { return !test.scaledOut })
+			assert.Equal(t, test.expectedInterval, sh.getPollingInterval(withTriggers))
+		})
+	}
+
+	sh.updateAdaptivePolling(key, withTriggers.Spec.AdaptivePolling, triggers, false, nil, func() bool { return true })
+	sh.deleteAdaptivePolling(key)
+	assert.Equal(t, 30*time.Second, sh.getPollingInterval(withTriggers))
+}
+
+func TestIsScaledJobScaledIn(t *testing.T) {
+	scaledJob := &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"}}
+	newJob := func(name string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "namespace", Labels: map[string]string{"scaledjob.keda.sh/name": "name"}},
+			Status:     batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	completed := batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}
+
+	sh := scaleHandler{client: fake.NewClientBuilder().WithObjects(newJob("completed", completed)).Build()}
+	assert.True(t, sh.isScaledJobScaledIn(context.Background(), scaledJob))
+
+	sh = scaleHandler{client: fake.NewClientBuilder().WithObjects(newJob("completed", completed), newJob("running")).Build()}
+	assert.False(t, sh.isScaledJobScaledIn(context.Background(), scaledJob))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"math"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// MetricTransforms applies the transforms of the triggers of a ScaledObject or ScaledJob to the metric values
+// returned by their scalers. It keeps the state of the smoothing for every metric and is shared between the caches
+// of the same object, so the smoothing survives cache invalidation. The state advances only with the fresh values
+// the scale loop gets from the scalers, other readers of the metrics get the last transformed values.
+type MetricTransforms struct {
+	transforms []*kedav1alpha1.MetricTransform
+	states     map[metricTransformKey]*metricTransformState
+	mutex      sync.Mutex
+}
+
+// metricTransformKey identifies a value of a metric of a trigger
+type metricTransformKey struct {
+	triggerIndex int
+	metricName   string
+	position     int
+}
+
+type metricTransformState struct {
+	// window holds the last values returned by the scaler
+	window []float64
+	// accepted is the last value which was not dropped as an outlier
+	accepted float64
+	// ema is the exponential moving average, valid once initialized
+	ema            float64
+	emaInitialized bool
+	// last is the last transformed value, valid once initialized
+	last            float64
+	lastInitialized bool
+}
+
+// NewMetricTransforms returns MetricTransforms for the triggers
+func NewMetricTransforms(triggers []kedav1alpha1.ScaleTriggers) *MetricTransforms {
+	t := &MetricTransforms{states: map[metricTransformKey]*metricTransformState{}}
+	t.SetTriggers(triggers)
+	return t
+}
+
+// SetTriggers updates the transforms of the triggers, the state of a trigger is reset if its transform changed
+func (t *MetricTransforms) SetTriggers(triggers []kedav1alpha1.ScaleTriggers) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	transforms := make([]*kedav1alpha1.MetricTransform, len(triggers))
+	for i, trigger := range triggers {
+		transforms[i] = trigger.Transform
+	}
+	for key := range t.states {
+		if key.triggerIndex >= len(transforms) || key.triggerIndex >= len(t.transforms) ||
+			!equality.Semantic.DeepEqual(transforms[key.triggerIndex], t.transforms[key.triggerIndex]) {
+			delete(t.states, key)
+		}
+	}
+	t.transforms = transforms
+}
+
+// HasTransform returns whether the trigger has a transform
+func (t *MetricTransforms) HasTransform(triggerIndex int) bool {
+	if t == nil {
+		return false
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return triggerIndex >= 0 && triggerIndex < len(t.transforms) && t.transforms[triggerIndex] != nil
+}
+
+// HasTransforms returns whether any of the triggers has a transform
+func HasTransforms(triggers []kedav1alpha1.ScaleTriggers) bool {
+	return slices.ContainsFunc(triggers, func(trigger kedav1alpha1.ScaleTriggers) bool {
+		return trigger.Transform != nil
+	})
+}
+
+// Apply returns the fresh metric values of the trigger with its transform applied and advances the state of the
+// smoothing, it must be called once for every value returned by the scaler. The metric values passed in are not
+// modified as they can be shared with other callers.
+func (t *MetricTransforms) Apply(triggerIndex int, metricName string, metrics []external_metrics.ExternalMetricValue) []external_metrics.ExternalMetricValue {
+	return t.transformMetrics(triggerIndex, metricName, metrics, true)
+}
+
+// Last returns the last transformed metric values of the trigger without advancing the state of the smoothing,
+// a value the state is not initialized for yet is transformed as if it was the first value
+func (t *MetricTransforms) Last(triggerIndex int, metricName string, metrics []external_metrics.ExternalMetricValue) []external_metrics.ExternalMetricValue {
+	return t.transformMetrics(triggerIndex, metricName, metrics, false)
+}
+
+func (t *MetricTransforms) transformMetrics(triggerIndex int, metricName string, metrics []external_metrics.ExternalMetricValue, advance bool) []external_metrics.ExternalMetricValue {
+	if t == nil {
+		return metrics
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if triggerIndex < 0 || triggerIndex >= len(t.transforms) || t.transforms[triggerIndex] == nil {
+		return metrics
+	}
+	transform := t.transforms[triggerIndex]
+
+	transformed := make([]external_metrics.ExternalMetricValue, len(metrics))
+	for i, metric := range metrics {
+		key := metricTransformKey{triggerIndex: triggerIndex, metricName: metricName, position: i}
+		state, found := t.states[key]
+		var value float64
+		switch {
+		case advance:
+			if !found {
+				state = &metricTransformState{}
+				t.states[key] = state
+			}
+			value = state.transform(transform, metric.Value.AsApproximateFloat64())
+		case found && state.lastInitialized:
+			value = state.last
+		default:
+			value = (&metricTransformState{}).transform(transform, metric.Value.AsApproximateFloat64())
+		}
+
+		transformed[i] = *metric.DeepCopy()
+		transformed[i].Value = *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+	}
+	return transformed
+}
+
+// transform applies the transform to the value: outliers are dropped, the median of the window is taken,
+// the exponential moving average is applied, then the value is scaled, offset and clamped
+func (s *metricTransformState) transform(transform *kedav1alpha1.MetricTransform, value float64) float64 {
+	raw := value
+	if threshold, ok := transform.GetOutlierThreshold(); ok && len(s.window) >= 3 && isOutlier(s.window, raw, threshold) {
+		value = s.accepted
+	} else {
+		s.accepted = raw
+	}
+
+	// outliers are kept in the window, so a lasting change of the level becomes the median and is accepted
+	s.window = append(s.window, raw)
+	if excess := len(s.window) - transform.GetWindow(); excess > 0 {
+		s.window = s.window[excess:]
+	}
+
+	if transform.Median {
+		value = median(s.window)
+	}
+
+	if alpha, ok := transform.GetEMA(); ok {
+		if s.emaInitialized {
+			value = alpha*value + (1-alpha)*s.ema
+		}
+		s.ema = value
+		s.emaInitialized = true
+	}
+
+	value = value*transform.GetScale() + transform.GetOffset()
+
+	if minValue, ok := transform.GetMin(); ok {
+		value = math.Max(value, minValue)
+	}
+	if maxValue, ok := transform.GetMax(); ok {
+		value = math.Min(value, maxValue)
+	}
+	s.last = value
+	s.lastInitialized = true
+	return value
+}
+
+// isOutlier returns whether the value deviates from the median of the window by more than threshold
+// median absolute deviations, no value is an outlier of a window without deviation
+func isOutlier(window []float64, value, threshold float64) bool {
+	windowMedian := median(window)
+	deviations := make([]float64, len(window))
+	for i, v := range window {
+		deviations[i] = math.Abs(v - windowMedian)
+	}
+	mad := median(deviations)
+	if mad == 0 {
+		return false
+	}
+	return math.Abs(value-windowMedian) > threshold*mad
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// applyValues applies the transform of the first trigger to the values one after the other
+func applyValues(transforms *MetricTransforms, values ...float64) []float64 {
+	result := make([]float64, 0, len(values))
+	for _, value := range values {
+		metrics := []external_metrics.ExternalMetricValue{{
+			MetricName: "s0-metric",
+			Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+		}}
+		transformed := transforms.Apply(0, "s0-metric", metrics)
+		result = append(result, transformed[0].Value.AsApproximateFloat64())
+	}
+	return result
+}
+
+func newTestMetricTransforms(transform *kedav1alpha1.MetricTransform) *MetricTransforms {
+	return NewMetricTransforms([]kedav1alpha1.ScaleTriggers{{Type: "prometheus", Transform: transform}})
+}
+
+func TestMetricTransformsLinearAndClamp(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{Scale: "0.5", Offset: "2", Min: "3", Max: "10"})
+	assert.Equal(t, []float64{3, 4.5, 10}, applyValues(transforms, 0, 5, 100))
+}
+
+func TestMetricTransformsEMA(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{EMA: "0.5"})
+	assert.Equal(t, []float64{10, 15, 7.5}, applyValues(transforms, 10, 20, 0))
+}
+
+func TestMetricTransformsMedian(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{Median: true, Window: ptr.To[int32](3)})
+	assert.Equal(t, []float64{10, 55, 10, 12, 12}, applyValues(transforms, 10, 100, 1, 12, 50))
+}
+
+func TestMetricTransformsDropOutliers(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{OutlierThreshold: "3", Window: ptr.To[int32](5)})
+	// the spike is dropped, a lasting change of the level is accepted once it is the median of the window
+	assert.Equal(t, []float64{10, 12, 11, 9, 9, 11, 11, 11, 50}, applyValues(transforms, 10, 12, 11, 9, 500, 11, 50, 50, 50))
+}
+
+func TestMetricTransformsWithoutTransform(t *testing.T) {
+	transforms := NewMetricTransforms([]kedav1alpha1.ScaleTriggers{{Type: "prometheus"}})
+	assert.Equal(t, []float64{1, 2}, applyValues(transforms, 1, 2))
+
+	var disabled *MetricTransforms
+	assert.Equal(t, []float64{1, 2}, applyValues(disabled, 1, 2))
+}
+
+func TestMetricTransformsDoNotModifyMetrics(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{Scale: "2"})
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(5, resource.DecimalSI)}}
+
+	transformed := transforms.Apply(0, "s0-metric", metrics)
+	assert.Equal(t, float64(10), transformed[0].Value.AsApproximateFloat64())
+	assert.Equal(t, float64(5), metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestMetricTransformsLastDoesNotAdvance(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{EMA: "0.5"})
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(20, resource.DecimalSI)}}
+
+	// a value read before the first poll is transformed as the first value
+	assert.Equal(t, float64(20), transforms.Last(0, "s0-metric", metrics)[0].Value.AsApproximateFloat64())
+
+	assert.Equal(t, []float64{10, 15}, applyValues(transforms, 10, 20))
+	// reading the metric serves the last transformed value however often it is read
+	assert.Equal(t, float64(15), transforms.Last(0, "s0-metric", metrics)[0].Value.AsApproximateFloat64())
+	assert.Equal(t, float64(15), transforms.Last(0, "s0-metric", metrics)[0].Value.AsApproximateFloat64())
+	assert.Equal(t, []float64{17.5}, applyValues(transforms, 20))
+}
+
+func TestMetricTransformsResetOnChange(t *testing.T) {
+	transforms := newTestMetricTransforms(&kedav1alpha1.MetricTransform{EMA: "0.5"})
+	assert.Equal(t, []float64{10, 15}, applyValues(transforms, 10, 20))
+
+	// the state is kept while the transform is unchanged
+	transforms.SetTriggers([]kedav1alpha1.ScaleTriggers{{Type: "prometheus", Transform: &kedav1alpha1.MetricTransform{EMA: "0.5"}}})
+	assert.Equal(t, []float64{17.5}, applyValues(transforms, 20))
+
+	transforms.SetTriggers([]kedav1alpha1.ScaleTriggers{{Type: "prometheus", Transform: &kedav1alpha1.MetricTransform{EMA: "0.25"}}})
+	assert.Equal(t, []float64{20}, applyValues(transforms, 20))
+}
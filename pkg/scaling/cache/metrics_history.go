@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricSample is a trigger value observed at a point in time
+type MetricSample struct {
+	Value     float64
+	Timestamp time.Time
+}
+
+// MetricsHistory keeps a bounded ring buffer of past samples for every trigger of a ScaledObject,
+// it backs the history functions of scalingModifiers formula
+type MetricsHistory struct {
+	size    int
+	samples map[string]*sampleRing
+	mutex   sync.RWMutex
+}
+
+type sampleRing struct {
+	samples []MetricSample
+	next    int
+}
+
+// NewMetricsHistory returns MetricsHistory which keeps at most size samples for every trigger
+func NewMetricsHistory(size int) *MetricsHistory {
+	return &MetricsHistory{
+		size:    size,
+		samples: map[string]*sampleRing{},
+	}
+}
+
+// MetricsHistorySize returns the number of samples covering window when a sample is recorded every pollingInterval
+func MetricsHistorySize(window, pollingInterval time.Duration) int {
+	pollingInterval = max(pollingInterval, time.Second)
+	return int((window+pollingInterval-1)/pollingInterval) + 1
+}
+
+// Size returns the number of samples kept for every trigger
+func (h *MetricsHistory) Size() int {
+	return h.size
+}
+
+// Record stores the trigger value, the oldest sample of the trigger is overwritten once the buffer is full
+func (h *MetricsHistory) Record(trigger string, value float64, timestamp time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	ring, found := h.samples[trigger]
+	if !found {
+		ring = &sampleRing{samples: make([]MetricSample, 0, h.size)}
+		h.samples[trigger] = ring
+	}
+	sample := MetricSample{Value: value, Timestamp: timestamp}
+	if len(ring.samples) < h.size {
+		ring.samples = append(ring.samples, sample)
+	} else {
+		ring.samples[ring.next] = sample
+	}
+	ring.next = (ring.next + 1) % h.size
+}
+
+// Window returns the samples of the trigger not older than window, ordered from the oldest to the newest
+func (h *MetricsHistory) Window(trigger string, window time.Duration, now time.Time) []MetricSample {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	ring, found := h.samples[trigger]
+	if !found {
+		return nil
+	}
+	var result []MetricSample
+	start := 0
+	if len(ring.samples) == h.size {
+		start = ring.next
+	}
+	for i := 0; i < len(ring.samples); i++ {
+		sample := ring.samples[(start+i)%len(ring.samples)]
+		if now.Sub(sample.Timestamp) <= window {
+			result = append(result, sample)
+		}
+	}
+	return result
+}
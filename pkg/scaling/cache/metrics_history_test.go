@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHistoryWindow(t *testing.T) {
+	history := NewMetricsHistory(3)
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		history.Record("trigger", float64(i), now.Add(time.Duration(i-4)*time.Minute))
+	}
+
+	// only the last 3 samples are kept, ordered from the oldest
+	samples := history.Window("trigger", time.Hour, now)
+	assert.Equal(t, []float64{2, 3, 4}, sampleValues(samples))
+
+	samples = history.Window("trigger", time.Minute, now)
+	assert.Equal(t, []float64{3, 4}, sampleValues(samples))
+
+	assert.Empty(t, history.Window("unknown", time.Hour, now))
+}
+
+func TestMetricsHistorySize(t *testing.T) {
+	assert.Equal(t, 3601, MetricsHistorySize(time.Hour, time.Second))
+	assert.Equal(t, 121, MetricsHistorySize(time.Hour, 30*time.Second))
+	assert.Equal(t, 3, MetricsHistorySize(time.Hour, 45*time.Minute))
+	// intervals shorter than a second are recorded at most once per second
+	assert.Equal(t, 61, MetricsHistorySize(time.Minute, 0))
+}
+
+func sampleValues(samples []MetricSample) []float64 {
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		values = append(values, sample.Value)
+	}
+	return values
+}
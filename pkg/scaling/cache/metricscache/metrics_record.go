@@ -2,6 +2,7 @@ package metricscache
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -10,17 +11,21 @@ type MetricsRecord struct {
 	IsActive    bool
 	Metric      []external_metrics.ExternalMetricValue
 	ScalerError error
+	// Timestamp is when the record has been stored, it is set only for last known good records
+	Timestamp time.Time
 }
 
 type MetricsCache struct {
-	metricRecords map[string]map[string]MetricsRecord
-	lock          *sync.RWMutex
+	metricRecords  map[string]map[string]MetricsRecord
+	lastKnownGoods map[string]map[string]MetricsRecord
+	lock           *sync.RWMutex
 }
 
 func NewMetricsCache() MetricsCache {
 	return MetricsCache{
-		metricRecords: map[string]map[string]MetricsRecord{},
-		lock:          &sync.RWMutex{},
+		metricRecords:  map[string]map[string]MetricsRecord{},
+		lastKnownGoods: map[string]map[string]MetricsRecord{},
+		lock:           &sync.RWMutex{},
 	}
 }
 
@@ -38,8 +43,39 @@ func (mc *MetricsCache) StoreRecords(scaledObjectIdentifier string, metricsRecor
 	mc.metricRecords[scaledObjectIdentifier] = metricsRecords
 }
 
+// ReadLastKnownGood returns the last successfully retrieved metrics for the metric
+func (mc *MetricsCache) ReadLastKnownGood(scaledObjectIdentifier, metricName string) (MetricsRecord, bool) {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+	record, ok := mc.lastKnownGoods[scaledObjectIdentifier][metricName]
+
+	return record, ok
+}
+
+// StoreLastKnownGood stores successfully retrieved metrics for the metric together with the current time
+func (mc *MetricsCache) StoreLastKnownGood(scaledObjectIdentifier, metricName string, metrics []external_metrics.ExternalMetricValue) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+	if _, ok := mc.lastKnownGoods[scaledObjectIdentifier]; !ok {
+		mc.lastKnownGoods[scaledObjectIdentifier] = map[string]MetricsRecord{}
+	}
+	mc.lastKnownGoods[scaledObjectIdentifier][metricName] = MetricsRecord{
+		Metric:    metrics,
+		Timestamp: time.Now(),
+	}
+}
+
+// Delete removes the records of the ScaledObject, the last known good records are kept
+// as they have to survive scalers cache invalidations caused by scaler errors
 func (mc *MetricsCache) Delete(scaledObjectIdentifier string) {
 	mc.lock.Lock()
 	defer mc.lock.Unlock()
 	delete(mc.metricRecords, scaledObjectIdentifier)
 }
+
+// DeleteLastKnownGoods removes the last known good records of the ScaledObject
+func (mc *MetricsCache) DeleteLastKnownGoods(scaledObjectIdentifier string) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+	delete(mc.lastKnownGoods, scaledObjectIdentifier)
+}
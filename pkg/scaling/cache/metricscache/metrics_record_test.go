@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+func TestLastKnownGoodSurvivesDelete(t *testing.T) {
+	mc := NewMetricsCache()
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "metric", Value: *resource.NewQuantity(5, resource.DecimalSI)}}
+
+	mc.StoreRecords("so", map[string]MetricsRecord{"metric": {Metric: metrics}})
+	mc.StoreLastKnownGood("so", "metric", metrics)
+
+	mc.Delete("so")
+	_, found := mc.ReadRecord("so", "metric")
+	assert.False(t, found)
+	record, found := mc.ReadLastKnownGood("so", "metric")
+	assert.True(t, found)
+	assert.Equal(t, metrics, record.Metric)
+	assert.False(t, record.Timestamp.IsZero())
+
+	mc.DeleteLastKnownGoods("so")
+	_, found = mc.ReadLastKnownGood("so", "metric")
+	assert.False(t, found)
+}
@@ -40,8 +40,19 @@ type ScalersCache struct {
 	Scalers                  []ScalerBuilder
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
-	CompiledFormula          *vm.Program
-	mutex                    sync.RWMutex
+	// CompiledFormulas are the scalingModifiers formulas by the name of their composite metric
+	CompiledFormulas map[string]*vm.Program
+	// MetricsHistory is shared between the caches of the same ScaledObject, so it survives cache invalidation
+	MetricsHistory *MetricsHistory
+	// SharedQueries coalesce the identical upstream queries of the scalers across the caches, nil disables sharing
+	SharedQueries *SharedQueries
+	// TriggerConditions are the compiled conditions by the index of their trigger
+	TriggerConditions map[int]*kedav1alpha1.CompiledTriggerCondition
+	// MetricTransforms smooth and transform the metric values of the triggers, nil if no trigger has a transform
+	MetricTransforms *MetricTransforms
+	// RenderedSpec is the spec the ScaledObject was rendered from its template with when the cache was built
+	RenderedSpec *kedav1alpha1.ScaledObjectSpec
+	mutex        sync.RWMutex
 }
 
 type ScalerBuilder struct {
@@ -141,14 +152,14 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 }
 
 // GetMetricsAndActivityForScaler returns metric value, activity and latency for a scaler identified by the metric name
-// and by the input index (from the list of scalers in this ScaledObject)
+// and by the input index (from the list of scalers in this ScaledObject), the transform of the trigger is not applied
 func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index int, metricName string) ([]external_metrics.ExternalMetricValue, bool, time.Duration, error) {
 	sb, err := c.getScalerBuilder(index)
 	if err != nil {
 		return nil, false, -1, err
 	}
 	startTime := time.Now()
-	metric, activity, err := sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+	metric, activity, err := c.getMetricsAndActivity(ctx, sb, metricName)
 	if err == nil {
 		return metric, activity, time.Since(startTime), nil
 	}
@@ -162,6 +173,21 @@ func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index
 	return metric, activity, time.Since(startTime), err
 }
 
+// getMetricsAndActivity queries the scaler through the shared query layer, if the queries of the scaler can be shared
+func (c *ScalersCache) getMetricsAndActivity(ctx context.Context, sb ScalerBuilder, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if c.SharedQueries == nil {
+		return sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+	}
+	key, shareable := SharedQueryKey(sb.ScalerConfig, metricName)
+	if !shareable {
+		return sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+	}
+	return c.SharedQueries.Query(ctx, key, sb.ScalerConfig.TriggerType, metricName, sb.ScalerConfig.GlobalHTTPTimeout,
+		func(ctx context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+			return sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+		})
+}
+
 func (c *ScalersCache) refreshScaler(ctx context.Context, index int) (scalers.Scaler, error) {
 	oldSb, err := c.getScalerBuilder(index)
 	if err != nil {
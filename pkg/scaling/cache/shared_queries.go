@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// defaultSharedQueryTimeout bounds the shared queries of the scalers without any timeout
+const defaultSharedQueryTimeout = 3 * time.Second
+
+// nonSharedTriggerTypes are the triggers whose values depend on the ScaledObject or ScaledJob querying them,
+// so their queries are never shared
+var nonSharedTriggerTypes = []string{"cpu", "memory", "external", "external-push"}
+
+// SharedQueries is the shared query layer of the scalers. Queries of the triggers with the same type, resolved
+// metadata and authentication, e.g. the same queue used by several ScaledObjects, are sent upstream only once:
+// concurrent queries are coalesced and successful results are reused within the TTL.
+type SharedQueries struct {
+	ttl     time.Duration
+	queries map[string]*sharedQuery
+	mutex   sync.Mutex
+}
+
+// sharedQuery is a query which is in flight until done is closed
+type sharedQuery struct {
+	done       chan struct{}
+	metricName string
+	metrics    []external_metrics.ExternalMetricValue
+	activity   bool
+	err        error
+	expiresAt  time.Time
+}
+
+// NewSharedQueries returns SharedQueries which reuse the results for ttl
+func NewSharedQueries(ttl time.Duration) *SharedQueries {
+	return &SharedQueries{
+		ttl:     ttl,
+		queries: map[string]*sharedQuery{},
+	}
+}
+
+// SharedQueryKey returns the key identifying the upstream query of the scaler metric, the second return value
+// is false if the queries of the trigger can't be shared
+func SharedQueryKey(config scalersconfig.ScalerConfig, metricName string) (string, bool) {
+	if slices.Contains(nonSharedTriggerTypes, config.TriggerType) {
+		return "", false
+	}
+	// only the environment variables referenced by the trigger affect its query
+	resolvedEnv := map[string]string{}
+	for key, value := range config.TriggerMetadata {
+		if strings.HasSuffix(key, "FromEnv") {
+			resolvedEnv[value] = config.ResolvedEnv[value]
+		}
+	}
+	// the metric name is prefixed with the trigger index, which differs between the objects
+	metricName = strings.TrimPrefix(metricName, fmt.Sprintf("s%d-", config.TriggerIndex))
+
+	identity, err := json.Marshal(struct {
+		Type        string
+		Namespace   string
+		Metric      string
+		Metadata    map[string]string
+		ResolvedEnv map[string]string
+		AuthParams  map[string]string
+		PodIdentity kedav1alpha1.AuthPodIdentity
+	}{
+		Type:        config.TriggerType,
+		Namespace:   config.ScalableObjectNamespace,
+		Metric:      metricName,
+		Metadata:    config.TriggerMetadata,
+		ResolvedEnv: resolvedEnv,
+		AuthParams:  config.AuthParams,
+		PodIdentity: config.PodIdentity,
+	})
+	if err != nil {
+		return "", false
+	}
+	// the key is hashed, so the resolved secrets aren't kept in it
+	hash := sha256.Sum256(identity)
+	return hex.EncodeToString(hash[:]), true
+}
+
+// Query returns the result of the query identified by key. The query is sent upstream only if no query with
+// the same key is in flight or was successful within the TTL, otherwise its result is reused. The upstream query
+// runs with a context independent of the callers bounded by timeout, so a caller giving up doesn't fail it for
+// the others, every caller stops waiting for the result once its own ctx is done.
+func (q *SharedQueries) Query(ctx context.Context, key string, triggerType string, metricName string, timeout time.Duration,
+	query func(ctx context.Context) ([]external_metrics.ExternalMetricValue, bool, error)) ([]external_metrics.ExternalMetricValue, bool, error) {
+	q.mutex.Lock()
+	shared, found := q.queries[key]
+	if found {
+		select {
+		case <-shared.done:
+			if time.Now().After(shared.expiresAt) {
+				found = false
+			}
+		default:
+		}
+	}
+	if !found {
+		q.deleteExpired()
+		shared = &sharedQuery{done: make(chan struct{}), metricName: metricName}
+		q.queries[key] = shared
+		go q.run(ctx, key, shared, timeout, query)
+	}
+	q.mutex.Unlock()
+
+	metricscollector.RecordSharedQuery(triggerType, found)
+	select {
+	case <-shared.done:
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+	return shared.metricsFor(metricName), shared.activity, shared.err
+}
+
+// run sends the shared query upstream and stores its result, the context keeps the values of ctx
+// but isn't canceled with it
+func (q *SharedQueries) run(ctx context.Context, key string, shared *sharedQuery, timeout time.Duration,
+	query func(ctx context.Context) ([]external_metrics.ExternalMetricValue, bool, error)) {
+	if timeout <= 0 {
+		timeout = defaultSharedQueryTimeout
+	}
+	queryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	metrics, activity, err := query(queryCtx)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	shared.metrics, shared.activity, shared.err = metrics, activity, err
+	shared.expiresAt = time.Now().Add(q.ttl)
+	if err != nil && q.queries[key] == shared {
+		// failed queries are not reused, only the concurrent queries get the error
+		delete(q.queries, key)
+	}
+	close(shared.done)
+}
+
+// deleteExpired drops the finished queries past the TTL, it must be called with the mutex held
+func (q *SharedQueries) deleteExpired() {
+	now := time.Now()
+	for key, shared := range q.queries {
+		select {
+		case <-shared.done:
+			if now.After(shared.expiresAt) {
+				delete(q.queries, key)
+			}
+		default:
+		}
+	}
+}
+
+// metricsFor returns a copy of the query result named after the metric of the requesting scaler
+func (s *sharedQuery) metricsFor(metricName string) []external_metrics.ExternalMetricValue {
+	if s.metrics == nil {
+		return nil
+	}
+	metrics := make([]external_metrics.ExternalMetricValue, len(s.metrics))
+	for i, metric := range s.metrics {
+		metrics[i] = *metric.DeepCopy()
+		if metric.MetricName == s.metricName {
+			metrics[i].MetricName = metricName
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+func TestSharedQueryKey(t *testing.T) {
+	config := scalersconfig.ScalerConfig{
+		ScalableObjectName:      "orders",
+		ScalableObjectNamespace: "shop",
+		TriggerType:             "aws-sqs-queue",
+		TriggerMetadata:         map[string]string{"queueURL": "orders", "queueLengthFromEnv": "QUEUE_LENGTH"},
+		ResolvedEnv:             map[string]string{"QUEUE_LENGTH": "5", "UNRELATED": "a"},
+		AuthParams:              map[string]string{"awsAccessKeyID": "id"},
+		TriggerIndex:            0,
+	}
+	key, shareable := SharedQueryKey(config, "s0-aws-sqs-queue-orders")
+	assert.True(t, shareable)
+
+	// another ScaledObject with the same trigger at another index and different unrelated env
+	other := config
+	other.ScalableObjectName = "invoices"
+	other.TriggerIndex = 2
+	other.ResolvedEnv = map[string]string{"QUEUE_LENGTH": "5", "UNRELATED": "b"}
+	otherKey, shareable := SharedQueryKey(other, "s2-aws-sqs-queue-orders")
+	assert.True(t, shareable)
+	assert.Equal(t, key, otherKey)
+
+	differentAuth := config
+	differentAuth.AuthParams = map[string]string{"awsAccessKeyID": "other"}
+	differentAuthKey, _ := SharedQueryKey(differentAuth, "s0-aws-sqs-queue-orders")
+	assert.NotEqual(t, key, differentAuthKey)
+
+	differentNamespace := config
+	differentNamespace.ScalableObjectNamespace = "billing"
+	differentNamespaceKey, _ := SharedQueryKey(differentNamespace, "s0-aws-sqs-queue-orders")
+	assert.NotEqual(t, key, differentNamespaceKey)
+
+	external := config
+	external.TriggerType = "external"
+	_, shareable = SharedQueryKey(external, "s0-external")
+	assert.False(t, shareable)
+}
+
+func TestSharedQueriesCoalesceConcurrentQueries(t *testing.T) {
+	queries := NewSharedQueries(time.Minute)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	query := func(context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+		calls.Add(1)
+		<-release
+		return []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(7, resource.DecimalSI)}}, true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]external_metrics.ExternalMetricValue, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			metrics, activity, err := queries.Query(context.TODO(), "key", "aws-sqs-queue", fmt.Sprintf("s%d-queue", i), time.Second, query)
+			assert.NoError(t, err)
+			assert.True(t, activity)
+			results[i] = metrics
+		}(i)
+		// let the first query start before the others
+		if i == 0 {
+			assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for i, metrics := range results {
+		assert.Len(t, metrics, 1)
+		assert.Equal(t, fmt.Sprintf("s%d-queue", i), metrics[0].MetricName)
+		assert.Equal(t, int64(7), metrics[0].Value.Value())
+	}
+}
+
+func TestSharedQueriesReuseWithinTTL(t *testing.T) {
+	queries := NewSharedQueries(50 * time.Millisecond)
+	calls := 0
+	query := func(context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+		calls++
+		return []external_metrics.ExternalMetricValue{{MetricName: "s0-queue"}}, false, nil
+	}
+
+	_, _, err := queries.Query(context.TODO(), "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+	assert.NoError(t, err)
+	_, _, err = queries.Query(context.TODO(), "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = queries.Query(context.TODO(), "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSharedQueriesDontReuseErrors(t *testing.T) {
+	queries := NewSharedQueries(time.Minute)
+	calls := 0
+	query := func(context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+		calls++
+		return nil, false, fmt.Errorf("upstream unavailable")
+	}
+
+	_, _, err := queries.Query(context.TODO(), "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+	assert.Error(t, err)
+	_, _, err = queries.Query(context.TODO(), "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSharedQueriesAreIndependentOfTheCallers(t *testing.T) {
+	queries := NewSharedQueries(time.Minute)
+	release := make(chan struct{})
+	query := func(ctx context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+		select {
+		case <-release:
+			return []external_metrics.ExternalMetricValue{{MetricName: "s0-queue"}}, true, nil
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+
+	// the caller which started the query gives up, the query keeps running for the other callers
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		_, _, err := queries.Query(ctx, "key", "aws-sqs-queue", "s0-queue", time.Second, query)
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+	assert.Eventually(t, func() bool {
+		queries.mutex.Lock()
+		defer queries.mutex.Unlock()
+		return queries.queries["key"] != nil
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-started
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	metrics, activity, err := queries.Query(context.Background(), "key", "aws-sqs-queue", "s1-queue", time.Second, query)
+	assert.NoError(t, err)
+	assert.True(t, activity)
+	assert.Equal(t, "s1-queue", metrics[0].MetricName)
+
+	// the query is bounded by the timeout
+	_, _, err = queries.Query(context.Background(), "other", "aws-sqs-queue", "s0-queue", 10*time.Millisecond,
+		func(ctx context.Context) ([]external_metrics.ExternalMetricValue, bool, error) {
+			<-ctx.Done()
+			return nil, false, ctx.Err()
+		})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
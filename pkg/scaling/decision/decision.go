@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// Trigger is the last value of a trigger of the scaled object
+type Trigger struct {
+	Name       string  `json:"name,omitempty"`
+	MetricName string  `json:"metricName,omitempty"`
+	Value      float64 `json:"value"`
+	Active     bool    `json:"active"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Request is the proposed scale change posted to the scale decision webhook
+type Request struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// CurrentReplicas is the replica count of the scale target of a ScaledObject, it isn't set for ScaledJobs
+	CurrentReplicas *int32 `json:"currentReplicas,omitempty"`
+	// DesiredReplicas is the replica count the scale target is about to be scaled to by KEDA or the HPA,
+	// or the number of Jobs the triggers of a ScaledJob ask for
+	DesiredReplicas int32     `json:"desiredReplicas"`
+	IsActive        bool      `json:"isActive"`
+	IsError         bool      `json:"isError"`
+	Triggers        []Trigger `json:"triggers,omitempty"`
+}
+
+// Response is the decision of the scale decision webhook on the proposed scale change
+type Response struct {
+	// Allowed approves the proposed scale change, it is vetoed otherwise
+	Allowed bool `json:"allowed"`
+	// Replicas clamps the approved scale change to the given replica count, it is ignored unless it is in between
+	// the current and the desired replicas and within the replica count bounds, or below the desired Jobs
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Reason is logged and recorded in the event of a vetoed scale change
+	Reason string `json:"reason,omitempty"`
+}
+
+// Decide posts the proposed scale change to the scale decision webhook and returns its decision, authParams are
+// the parameters of the authModes of the webhook. If the webhook fails, its failure policy decides and the error
+// is returned together with the decision.
+func Decide(ctx context.Context, webhook *kedav1alpha1.ScaleDecisionWebhook, authParams map[string]string, request *Request) (*Response, error) {
+	response, err := post(ctx, webhook, authParams, request)
+	if err != nil {
+		return &Response{Allowed: !webhook.FailsClosed(), Reason: fmt.Sprintf("scale decision webhook failed: %s", err)}, err
+	}
+	return response, nil
+}
+
+func post(ctx context.Context, webhook *kedav1alpha1.ScaleDecisionWebhook, authParams map[string]string, request *Request) (*Response, error) {
+	auth := &authentication.Config{}
+	if webhook.AuthModes != "" {
+		config := &scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{authentication.AuthModesKey: webhook.AuthModes},
+			AuthParams:      authParams,
+		}
+		if err := config.TypedConfig(auth); err != nil {
+			return nil, fmt.Errorf("error parsing authentication: %w", err)
+		}
+	}
+
+	httpClient := kedautil.CreateHTTPClient(webhook.GetTimeout(), false)
+	defer httpClient.CloseIdleConnections()
+	if auth.CA != "" || auth.EnabledTLS() {
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, auth.ToAuthMeta())
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = transport
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	switch {
+	case auth.EnabledBearerAuth():
+		req.Header.Set("Authorization", auth.GetBearerToken())
+	case auth.EnabledBasicAuth():
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case auth.EnabledCustomAuth():
+		req.Header.Set(auth.CustomAuthHeader, auth.CustomAuthValue)
+	}
+
+	r, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode < 200 || r.StatusCode > 299 {
+		return nil, fmt.Errorf("scale decision webhook returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(b, response); err != nil {
+		return nil, fmt.Errorf("error parsing response of scale decision webhook: %w", err)
+	}
+	if response.Replicas != nil && *response.Replicas < 0 {
+		return nil, fmt.Errorf("scale decision webhook returned negative replicas %d", *response.Replicas)
+	}
+	return response, nil
+}
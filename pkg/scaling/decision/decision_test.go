@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		delay         time.Duration
+		failurePolicy kedav1alpha1.ScaleDecisionFailurePolicy
+		expected      *Response
+		isError       bool
+	}{
+		{name: "approved", status: http.StatusOK, body: `{"allowed":true}`, expected: &Response{Allowed: true}},
+		{name: "clamped", status: http.StatusOK, body: `{"allowed":true,"replicas":3,"reason":"budget"}`, expected: &Response{Allowed: true, Replicas: ptr.To[int32](3), Reason: "budget"}},
+		{name: "vetoed", status: http.StatusOK, body: `{"allowed":false,"reason":"freeze"}`, expected: &Response{Allowed: false, Reason: "freeze"}},
+		{name: "server error fails open", status: http.StatusInternalServerError, body: `boom`, expected: &Response{Allowed: true}, isError: true},
+		{name: "server error fails closed", status: http.StatusInternalServerError, body: `boom`, failurePolicy: kedav1alpha1.ScaleDecisionFailurePolicyFail, expected: &Response{Allowed: false}, isError: true},
+		{name: "invalid response", status: http.StatusOK, body: `allowed`, failurePolicy: kedav1alpha1.ScaleDecisionFailurePolicyFail, expected: &Response{Allowed: false}, isError: true},
+		{name: "negative replicas", status: http.StatusOK, body: `{"allowed":true,"replicas":-1}`, expected: &Response{Allowed: true}, isError: true},
+		{name: "timeout", status: http.StatusOK, body: `{"allowed":false}`, delay: 200 * time.Millisecond, expected: &Response{Allowed: true}, isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, http.MethodPost, request.Method)
+				assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+				received := &Request{}
+				assert.NoError(t, json.NewDecoder(request.Body).Decode(received))
+				assert.Equal(t, int32(5), received.DesiredReplicas)
+				time.Sleep(test.delay)
+				writer.WriteHeader(test.status)
+				fmt.Fprint(writer, test.body)
+			}))
+			defer server.Close()
+
+			webhook := &kedav1alpha1.ScaleDecisionWebhook{
+				URL:           server.URL,
+				Timeout:       &metav1.Duration{Duration: 100 * time.Millisecond},
+				FailurePolicy: test.failurePolicy,
+				AuthModes:     "bearer",
+			}
+			response, err := Decide(context.Background(), webhook, map[string]string{"bearerToken": "secret"}, &Request{
+				Kind:            "ScaledObject",
+				Namespace:       "default",
+				Name:            "consumer",
+				CurrentReplicas: ptr.To[int32](2),
+				DesiredReplicas: 5,
+				IsActive:        true,
+			})
+			assert.Equal(t, test.isError, err != nil, "error: %v", err)
+			require.NotNil(t, response)
+			assert.Equal(t, test.expected.Allowed, response.Allowed)
+			if !test.isError {
+				assert.Equal(t, test.expected, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// Default behavior of the HPA, used in native scaling mode for the rules not defined in the ScaledObject
+const (
+	defaultScaleUpStabilizationWindowSeconds   = 0
+	defaultScaleDownStabilizationWindowSeconds = 5 * 60
+	defaultScalingPolicyPeriodSeconds          = 15
+	defaultScaleUpPodsPolicyValue              = 4
+	defaultScalingPercentPolicyValue           = 100
+)
+
+// nativeScalingState holds the replica recommendations and scale events of a ScaledObject in native scaling mode,
+// the HPA keeps the same state for the stabilization windows and the scaling policies of its behavior
+type nativeScalingState struct {
+	recommendations []timestampedRecommendation
+	scaleUpEvents   []timestampedScaleEvent
+	scaleDownEvents []timestampedScaleEvent
+}
+
+type timestampedRecommendation struct {
+	recommendation int32
+	timestamp      time.Time
+}
+
+type timestampedScaleEvent struct {
+	replicaChange int32
+	timestamp     time.Time
+}
+
+// scaleNatively scales the target to the replicas computed from the metrics, stabilized and rate limited
+// by the behavior of the ScaledObject, the same way the HPA does. Like the HPA, it doesn't scale a target
+// with 0 replicas, activation is left to KEDA.
+func (e *scaleExecutor) scaleNatively(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, options *ScaleExecutorOptions) {
+	desiredReplicas := options.DesiredReplicas
+	if desiredReplicas == nil || currentReplicas == 0 {
+		return
+	}
+
+	key := scaledObject.GenerateIdentifier()
+	scaleUpRules, scaleDownRules := getScalingRules(scaledObject.GetHPABehavior())
+	now := time.Now()
+
+	e.nativeScalingStatesLock.Lock()
+	state, ok := e.nativeScalingStates[key]
+	if !ok {
+		state = &nativeScalingState{}
+		e.nativeScalingStates[key] = state
+	}
+	replicas := state.normalizeDesiredReplicas(now, currentReplicas, *desiredReplicas,
+		*scaledObject.GetHPAMinReplicas(), scaledObject.GetHPAMaxReplicas(), scaleUpRules, scaleDownRules)
+	e.nativeScalingStatesLock.Unlock()
+	replicas = e.clampToScalingBudget(logger, scaledObject, currentReplicas, decideReplicas(options, replicas))
+
+	if replicas == currentReplicas {
+		logger.V(1).Info("ScaleTarget no change", "Desired Replicas Count", *desiredReplicas)
+		return
+	}
+
+	if _, err := e.updateScaleOnScaleTarget(ctx, scaledObject, nil, replicas); err != nil {
+		logger.Error(err, "Error scaling the ScaleTarget", "Original Replicas Count", currentReplicas, "New Replicas Count", replicas)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetScaleFailed,
+			"Failed to scale %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+		return
+	}
+
+	e.nativeScalingStatesLock.Lock()
+	state.storeScaleEvent(now, replicas-currentReplicas, scaleUpRules, scaleDownRules)
+	e.nativeScalingStatesLock.Unlock()
+
+	logger.Info("Successfully scaled ScaleTarget",
+		"Original Replicas Count", currentReplicas,
+		"New Replicas Count", replicas)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaled,
+		"Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+}
+
+// normalizeDesiredReplicas stabilizes the desired replicas over the stabilization windows and limits
+// the change of replicas by the scaling policies and the min and max replicas. As with the HPA, replicas
+// outside of the min and max replicas are corrected even if the stabilized replicas don't change.
+func (s *nativeScalingState) normalizeDesiredReplicas(now time.Time, currentReplicas, desiredReplicas, minReplicas, maxReplicas int32, scaleUpRules, scaleDownRules *autoscalingv2.HPAScalingRules) int32 {
+	stabilizedReplicas := s.stabilizeRecommendation(now, currentReplicas, desiredReplicas, scaleUpRules, scaleDownRules)
+
+	scaleUpLimit := max(calculateScaleUpLimit(now, currentReplicas, s.scaleUpEvents, s.scaleDownEvents, scaleUpRules), currentReplicas)
+	scaleDownLimit := min(calculateScaleDownLimit(now, currentReplicas, s.scaleUpEvents, s.scaleDownEvents, scaleDownRules), currentReplicas)
+	maximumAllowedReplicas := min(maxReplicas, scaleUpLimit)
+	minimumAllowedReplicas := max(minReplicas, scaleDownLimit)
+
+	switch {
+	case stabilizedReplicas < minimumAllowedReplicas:
+		return minimumAllowedReplicas
+	case stabilizedReplicas > maximumAllowedReplicas:
+		return maximumAllowedReplicas
+	default:
+		return stabilizedReplicas
+	}
+}
+
+// stabilizeRecommendation records the desired replicas and returns the replicas within the lowest recommendation
+// of the scale up stabilization window and the highest recommendation of the scale down stabilization window
+func (s *nativeScalingState) stabilizeRecommendation(now time.Time, currentReplicas, desiredReplicas int32, scaleUpRules, scaleDownRules *autoscalingv2.HPAScalingRules) int32 {
+	upCutoff := now.Add(-time.Second * time.Duration(*scaleUpRules.StabilizationWindowSeconds))
+	downCutoff := now.Add(-time.Second * time.Duration(*scaleDownRules.StabilizationWindowSeconds))
+
+	upRecommendation, downRecommendation := desiredReplicas, desiredReplicas
+	recommendations := s.recommendations[:0]
+	for _, rec := range s.recommendations {
+		if rec.timestamp.After(upCutoff) {
+			upRecommendation = min(rec.recommendation, upRecommendation)
+		}
+		if rec.timestamp.After(downCutoff) {
+			downRecommendation = max(rec.recommendation, downRecommendation)
+		}
+		if rec.timestamp.After(upCutoff) || rec.timestamp.After(downCutoff) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	s.recommendations = append(recommendations, timestampedRecommendation{recommendation: desiredReplicas, timestamp: now})
+
+	return min(max(currentReplicas, upRecommendation), downRecommendation)
+}
+
+// storeScaleEvent records the change of replicas and drops the events older than the longest policy period
+func (s *nativeScalingState) storeScaleEvent(now time.Time, replicaChange int32, scaleUpRules, scaleDownRules *autoscalingv2.HPAScalingRules) {
+	if replicaChange > 0 {
+		s.scaleUpEvents = append(pruneScaleEvents(now, s.scaleUpEvents, scaleUpRules),
+			timestampedScaleEvent{replicaChange: replicaChange, timestamp: now})
+	} else {
+		s.scaleDownEvents = append(pruneScaleEvents(now, s.scaleDownEvents, scaleDownRules),
+			timestampedScaleEvent{replicaChange: -replicaChange, timestamp: now})
+	}
+}
+
+func pruneScaleEvents(now time.Time, events []timestampedScaleEvent, rules *autoscalingv2.HPAScalingRules) []timestampedScaleEvent {
+	var longestPeriod int32
+	for _, policy := range rules.Policies {
+		longestPeriod = max(longestPeriod, policy.PeriodSeconds)
+	}
+	cutoff := now.Add(-time.Second * time.Duration(longestPeriod))
+	pruned := events[:0]
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			pruned = append(pruned, event)
+		}
+	}
+	return pruned
+}
+
+// calculateScaleUpLimit returns the highest replicas the scale up policies allow
+func calculateScaleUpLimit(now time.Time, currentReplicas int32, scaleUpEvents, scaleDownEvents []timestampedScaleEvent, rules *autoscalingv2.HPAScalingRules) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	result, selectPolicy := int32(math.MinInt32), maxReplicas
+	if *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result, selectPolicy = math.MaxInt32, minReplicas
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas - getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleUpEvents) +
+			getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleDownEvents)
+		var proposed int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			proposed = periodStartReplicas + policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			proposed = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		}
+		result = selectPolicy(result, proposed)
+	}
+	return result
+}
+
+// calculateScaleDownLimit returns the lowest replicas the scale down policies allow
+func calculateScaleDownLimit(now time.Time, currentReplicas int32, scaleUpEvents, scaleDownEvents []timestampedScaleEvent, rules *autoscalingv2.HPAScalingRules) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	result, selectPolicy := int32(math.MaxInt32), minReplicas
+	if *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect {
+		result, selectPolicy = math.MinInt32, maxReplicas
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas - getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleUpEvents) +
+			getReplicasChangePerPeriod(now, policy.PeriodSeconds, scaleDownEvents)
+		var proposed int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			proposed = periodStartReplicas - policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			proposed = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		}
+		result = selectPolicy(result, proposed)
+	}
+	return result
+}
+
+func minReplicas(a, b int32) int32 { return min(a, b) }
+
+func maxReplicas(a, b int32) int32 { return max(a, b) }
+
+// getReplicasChangePerPeriod returns the replicas changed by the scale events within the period
+func getReplicasChangePerPeriod(now time.Time, periodSeconds int32, events []timestampedScaleEvent) int32 {
+	cutoff := now.Add(-time.Second * time.Duration(periodSeconds))
+	var replicas int32
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			replicas += event.replicaChange
+		}
+	}
+	return replicas
+}
+
+// getScalingRules returns the scale up and scale down rules of the behavior, completed by the defaults of the HPA
+func getScalingRules(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) (*autoscalingv2.HPAScalingRules, *autoscalingv2.HPAScalingRules) {
+	var scaleUp, scaleDown *autoscalingv2.HPAScalingRules
+	if behavior != nil {
+		scaleUp, scaleDown = behavior.ScaleUp, behavior.ScaleDown
+	}
+	scaleUpRules := withDefaultScalingRules(scaleUp, defaultScaleUpStabilizationWindowSeconds, []autoscalingv2.HPAScalingPolicy{
+		{Type: autoscalingv2.PodsScalingPolicy, Value: defaultScaleUpPodsPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+		{Type: autoscalingv2.PercentScalingPolicy, Value: defaultScalingPercentPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+	})
+	scaleDownRules := withDefaultScalingRules(scaleDown, defaultScaleDownStabilizationWindowSeconds, []autoscalingv2.HPAScalingPolicy{
+		{Type: autoscalingv2.PercentScalingPolicy, Value: defaultScalingPercentPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+	})
+	return scaleUpRules, scaleDownRules
+}
+
+func withDefaultScalingRules(rules *autoscalingv2.HPAScalingRules, stabilizationWindowSeconds int32, policies []autoscalingv2.HPAScalingPolicy) *autoscalingv2.HPAScalingRules {
+	result := &autoscalingv2.HPAScalingRules{}
+	if rules != nil {
+		result = rules.DeepCopy()
+	}
+	if result.StabilizationWindowSeconds == nil {
+		result.StabilizationWindowSeconds = ptr.To(stabilizationWindowSeconds)
+	}
+	if result.SelectPolicy == nil {
+		result.SelectPolicy = ptr.To(autoscalingv2.MaxChangePolicySelect)
+	}
+	if result.Policies == nil {
+		result.Policies = policies
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetScalingRules(t *testing.T) {
+	scaleUp, scaleDown := getScalingRules(nil)
+	assert.Equal(t, int32(0), *scaleUp.StabilizationWindowSeconds)
+	assert.Equal(t, autoscalingv2.MaxChangePolicySelect, *scaleUp.SelectPolicy)
+	assert.Len(t, scaleUp.Policies, 2)
+	assert.Equal(t, int32(300), *scaleDown.StabilizationWindowSeconds)
+	assert.Len(t, scaleDown.Policies, 1)
+
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(60)),
+		},
+	}
+	_, scaleDown = getScalingRules(behavior)
+	assert.Equal(t, int32(60), *scaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, autoscalingv2.MaxChangePolicySelect, *scaleDown.SelectPolicy)
+	assert.Len(t, scaleDown.Policies, 1)
+	assert.Nil(t, behavior.ScaleDown.SelectPolicy, "the behavior of the ScaledObject must not be modified")
+}
+
+func TestNormalizeDesiredReplicas(t *testing.T) {
+	now := time.Now()
+	scaleUp, scaleDown := getScalingRules(nil)
+
+	tests := []struct {
+		name            string
+		state           nativeScalingState
+		currentReplicas int32
+		desiredReplicas int32
+		expected        int32
+	}{
+		{
+			name:            "scale up limited by the pods policy",
+			currentReplicas: 2,
+			desiredReplicas: 10,
+			expected:        6,
+		},
+		{
+			name:            "scale up limited by the percent policy",
+			currentReplicas: 5,
+			desiredReplicas: 50,
+			expected:        10,
+		},
+		{
+			name:            "scale up limited by max replicas",
+			currentReplicas: 10,
+			desiredReplicas: 15,
+			expected:        12,
+		},
+		{
+			name: "scale up limited by recent scale up events",
+			state: nativeScalingState{
+				scaleUpEvents: []timestampedScaleEvent{{replicaChange: 4, timestamp: now.Add(-5 * time.Second)}},
+			},
+			currentReplicas: 6,
+			desiredReplicas: 10,
+			expected:        6,
+		},
+		{
+			name: "scale down held by the stabilization window",
+			state: nativeScalingState{
+				recommendations: []timestampedRecommendation{{recommendation: 8, timestamp: now.Add(-time.Minute)}},
+			},
+			currentReplicas: 8,
+			desiredReplicas: 2,
+			expected:        8,
+		},
+		{
+			name: "scale down to the highest recommendation of the stabilization window",
+			state: nativeScalingState{
+				recommendations: []timestampedRecommendation{
+					{recommendation: 8, timestamp: now.Add(-10 * time.Minute)},
+					{recommendation: 4, timestamp: now.Add(-time.Minute)},
+				},
+			},
+			currentReplicas: 8,
+			desiredReplicas: 2,
+			expected:        4,
+		},
+		{
+			name:            "scale down limited by min replicas",
+			currentReplicas: 8,
+			desiredReplicas: 0,
+			expected:        1,
+		},
+		{
+			name:            "replicas above max replicas are corrected",
+			currentReplicas: 15,
+			desiredReplicas: 15,
+			expected:        12,
+		},
+		{
+			name:            "replicas below min replicas are corrected",
+			currentReplicas: 0,
+			desiredReplicas: 0,
+			expected:        1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			replicas := test.state.normalizeDesiredReplicas(now, test.currentReplicas, test.desiredReplicas, 1, 12, scaleUp, scaleDown)
+			assert.Equal(t, test.expected, replicas)
+		})
+	}
+}
+
+func TestNormalizeDesiredReplicasDisabledPolicy(t *testing.T) {
+	scaleUp, scaleDown := getScalingRules(&autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			SelectPolicy: ptr.To(autoscalingv2.DisabledPolicySelect),
+		},
+	})
+	state := nativeScalingState{}
+	assert.Equal(t, int32(2), state.normalizeDesiredReplicas(time.Now(), 2, 10, 1, 100, scaleUp, scaleDown))
+}
+
+func TestStoreScaleEvent(t *testing.T) {
+	now := time.Now()
+	scaleUp, scaleDown := getScalingRules(nil)
+	state := nativeScalingState{
+		scaleUpEvents: []timestampedScaleEvent{{replicaChange: 2, timestamp: now.Add(-time.Minute)}},
+	}
+
+	state.storeScaleEvent(now, 3, scaleUp, scaleDown)
+	state.storeScaleEvent(now, -1, scaleUp, scaleDown)
+
+	// events older than the longest policy period are dropped
+	assert.Equal(t, []timestampedScaleEvent{{replicaChange: 3, timestamp: now}}, state.scaleUpEvents)
+	assert.Equal(t, []timestampedScaleEvent{{replicaChange: 1, timestamp: now}}, state.scaleDownEvents)
+}
@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,15 +39,42 @@ const (
 	defaultCooldownPeriod        = 5 * 60 // 5 minutes
 )
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+// ScaleExecutor contains methods RequestJobScale, RequestScale and DeleteScalingState
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
+	DeleteScalingState(key string)
 }
 
 // ScaleExecutorOptions contains the optional parameters for the RequestScale method.
 type ScaleExecutorOptions struct {
 	ActiveTriggers []string
+	// FailedTriggers are the indexes of the triggers which failed to return metrics,
+	// all triggers are considered failed if an error occurred but none is set
+	FailedTriggers []int
+	// DesiredReplicas is the replica count the HPA would compute from the metrics,
+	// it is set in dry-run and native scaling mode only
+	DesiredReplicas *int32
+	// DecidedReplicas is the replica count the scale decision webhook decided on for the scale change proposed by KEDA,
+	// it replaces the replicas KEDA scales the target to. A vetoed change keeps the current replicas.
+	DecidedReplicas *int32
+}
+
+// getFailedTriggers returns the indexes of the failed triggers
+func getFailedTriggers(options *ScaleExecutorOptions) []int {
+	if options == nil {
+		return nil
+	}
+	return options.FailedTriggers
+}
+
+// decideReplicas returns the replica count the scale decision webhook decided on instead of the given replicas,
+// if it was consulted on the scale change
+func decideReplicas(options *ScaleExecutorOptions, replicas int32) int32 {
+	if options != nil && options.DecidedReplicas != nil {
+		return *options.DecidedReplicas
+	}
+	return replicas
 }
 
 type scaleExecutor struct {
@@ -55,6 +83,10 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+
+	// nativeScalingStates holds the recommendations and scale events of ScaledObjects in native scaling mode
+	nativeScalingStates     map[string]*nativeScalingState
+	nativeScalingStatesLock sync.Mutex
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
@@ -65,9 +97,18 @@ func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGette
 		reconcilerScheme: reconcilerScheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
 		recorder:         recorder,
+
+		nativeScalingStates: map[string]*nativeScalingState{},
 	}
 }
 
+// DeleteScalingState removes the scaling state kept for the ScaledObject with the given key
+func (e *scaleExecutor) DeleteScalingState(key string) {
+	e.nativeScalingStatesLock.Lock()
+	defer e.nativeScalingStatesLock.Unlock()
+	delete(e.nativeScalingStates, key)
+}
+
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
 	now := metav1.Now()
 	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
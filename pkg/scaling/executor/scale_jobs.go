@@ -60,7 +60,11 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		if scaledJob.Status.ScalingFreeze != nil {
+			logger.V(1).Info("ScaledJob is frozen, not creating any Job", "scalingFreeze", scaledJob.Status.ScalingFreeze.String())
+		} else {
+			e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		}
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
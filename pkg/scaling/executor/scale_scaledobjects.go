@@ -26,9 +26,11 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
@@ -68,8 +70,14 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	status := scaledObject.Status.DeepCopy()
 	if pausedCount != nil {
 		// Scale the target to the paused replica count
-		if *pausedCount != currentReplicas {
-			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *pausedCount)
+		replicas := e.clampToScalingBudget(logger, scaledObject, currentReplicas, *pausedCount)
+		if scaledObject.IsDryRun() {
+			status.PausedReplicaCount = pausedCount
+			e.recordDryRunReplicas(ctx, logger, scaledObject, status, currentReplicas, replicas)
+			return
+		}
+		if replicas != currentReplicas {
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, replicas)
 			if err != nil {
 				logger.Error(err, "error scaling target to paused replicas count", "paused replicas", *pausedCount)
 				if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionUnknown,
@@ -79,7 +87,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				return
 			}
 		}
-		if *pausedCount != currentReplicas || status.PausedReplicaCount == nil {
+		if replicas != currentReplicas || status.PausedReplicaCount == nil {
 			status.PausedReplicaCount = pausedCount
 			err = kedastatus.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status)
 			if err != nil {
@@ -91,15 +99,46 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
-	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0)
+	// While a ScalingFreeze or ClusterScalingFreeze is active, hold the current replicas or scale the target to the replica count of the freeze
+	if freeze := scaledObject.Status.ScalingFreeze; freeze != nil {
+		if freeze.ReplicaCount == nil {
+			return
+		}
+		replicas := e.clampToScalingBudget(logger, scaledObject, currentReplicas, *freeze.ReplicaCount)
+		if scaledObject.IsDryRun() {
+			e.recordDryRunReplicas(ctx, logger, scaledObject, status, currentReplicas, replicas)
+			return
+		}
+		if replicas != currentReplicas {
+			if _, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, replicas); err != nil {
+				logger.Error(err, "error scaling target to the replica count of scaling freeze", "scalingFreeze", freeze.String())
+				return
+			}
+			logger.Info("Successfully scaled target to the replica count of scaling freeze", "scalingFreeze", freeze.String(), "replicas", replicas)
+		}
+		return
+	}
+
+	// In dry-run mode only record the replica count the target would have been scaled to
+	if scaledObject.IsDryRun() {
+		e.dryRunScale(ctx, logger, scaledObject, currentReplicas, isActive, isError, options)
+		e.updateActiveCondition(ctx, logger, scaledObject, isActive)
+		return
+	}
+
+	// MinReplicaCount and IdleReplicaCount could be overridden by the active replicaCountSchedule
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	idleReplicaCount := scaledObject.GetIdleReplicaCount()
+
+	// if minReplicaCount is not set, then set the default value (0)
 	minReplicas := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		minReplicas = *scaledObject.Spec.MinReplicaCount
+	if minReplicaCount != nil {
+		minReplicas = *minReplicaCount
 	}
 
 	if isActive {
 		switch {
-		case scaledObject.Spec.IdleReplicaCount != nil && currentReplicas < minReplicas,
+		case idleReplicaCount != nil && currentReplicas < minReplicas,
 			// triggers are active, Idle Replicas mode is enabled
 			// AND
 			// replica count is less than minimum replica count
@@ -110,7 +149,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// replica count is equal to 0
 
 			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers)
+			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, currentReplicas, options)
 		case isError:
 			// some triggers are active, but some responded with error
 
@@ -122,6 +161,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 					logger.Error(err, "error setting ready condition")
 				}
 			}
+			if scaledObject.IsNativeScaling() {
+				e.scaleNatively(ctx, logger, scaledObject, currentReplicas, options)
+			}
 		default:
 			// triggers are active, but we didn't need to scale (replica count > 0)
 
@@ -131,21 +173,27 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				logger.Error(err, "Error updating last active time")
 				return
 			}
+			if scaledObject.IsNativeScaling() {
+				e.scaleNatively(ctx, logger, scaledObject, currentReplicas, options)
+			}
 		}
 	} else {
 		// isActive == false
 		switch {
-		case isError && scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.Replicas != 0:
+		case isError && scaledObject.HasFallbackReplicasForTriggers(getFailedTriggers(options)):
 			// We need to have this switch case even if just for logging.
 			// Otherwise, if we have `minReplicas=zero`, we will fall into the third case expression,
 			// which will scale the target to 0. Scaling the target to 0 means the HPA will not scale it to fallback.replicas
 			// after fallback.failureThreshold has passed because of what's described here:
 			// https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/#implicit-maintenance-mode-deactivation
 			logger.V(1).Info("ScaleTarget will fallback to Fallback.Replicas after Fallback.FailureThreshold")
-		case isError && scaledObject.Spec.Fallback == nil:
+			if scaledObject.IsNativeScaling() {
+				e.scaleNatively(ctx, logger, scaledObject, currentReplicas, options)
+			}
+		case isError && !scaledObject.HasFallbackForTriggers(getFailedTriggers(options)):
 			// there are no active triggers, but a scaler responded with an error
 			// AND
-			// there is not a fallback replicas count defined
+			// there is not a fallback defined for the failed triggers
 
 			// Set ScaledObject.Status.ReadyCondition to false
 			msg := "Triggers defined in ScaledObject are not working correctly"
@@ -155,7 +203,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 					logger.Error(err, "error setting ready condition")
 				}
 			}
-		case scaledObject.Spec.IdleReplicaCount != nil && currentReplicas > *scaledObject.Spec.IdleReplicaCount,
+		case idleReplicaCount != nil && currentReplicas > *idleReplicaCount,
 			// there are no active triggers, Idle Replicas mode is enabled
 			// AND
 			// current replicas count is greater than Idle Replicas count
@@ -166,8 +214,14 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
-		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
+			if scaledObject.IsNativeScaling() {
+				// the HPA keeps scaling the target while cooling down, so does native scaling
+				if elapsed, _ := isCooldownPeriodElapsed(scaledObject); !elapsed {
+					e.scaleNatively(ctx, logger, scaledObject, currentReplicas, options)
+				}
+			}
+			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale, currentReplicas, options)
+		case currentReplicas < minReplicas && idleReplicaCount == nil:
 			// there are no active triggers
 			// AND
 			// ScaleTarget replicas count is less than minimum replica count specified in ScaledObject
@@ -175,20 +229,34 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// Idle Replicas mode is disabled
 
 			// ScaleTarget replicas count to correct value
-			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
+			replicas := e.clampToScalingBudget(logger, scaledObject, currentReplicas, decideReplicas(options, minReplicas))
+			if replicas == currentReplicas {
+				logger.V(1).Info("ScaleTarget no change")
+				break
+			}
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, replicas)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
-					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+					"New Replicas Count", replicas)
 			}
 		default:
 			// there are no active triggers
 			// AND
 			// nothing needs to be done (eg. deployment is scaled down)
+			if scaledObject.IsNativeScaling() {
+				e.scaleNatively(ctx, logger, scaledObject, currentReplicas, options)
+				break
+			}
 			logger.V(1).Info("ScaleTarget no change")
 		}
 	}
 
+	e.updateActiveCondition(ctx, logger, scaledObject, isActive)
+}
+
+// updateActiveCondition sets the active condition of the ScaledObject if it has changed
+func (e *scaleExecutor) updateActiveCondition(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, isActive bool) {
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	if condition.IsUnknown() || condition.IsTrue() != isActive {
 		if isActive {
@@ -205,9 +273,120 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	}
 }
 
-// An object will be scaled down to 0 only if it's passed its cooldown period
-// or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+// dryRunScale records the replica count the target would have been scaled to in the status of the ScaledObject,
+// in a metric and in an event, without scaling the target
+func (e *scaleExecutor) dryRunScale(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, isActive bool, isError bool, options *ScaleExecutorOptions) {
+	if isActive {
+		// LastActiveTime drives the cooldown period in dry-run mode as well
+		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
+			logger.Error(err, "Error updating last active time")
+			return
+		}
+	}
+
+	replicas := GetProposedReplicaCount(scaledObject, currentReplicas, isActive, isError, options)
+	replicas = e.clampToScalingBudget(logger, scaledObject, currentReplicas, decideReplicas(options, replicas))
+	e.recordDryRunReplicas(ctx, logger, scaledObject, scaledObject.Status.DeepCopy(), currentReplicas, replicas)
+}
+
+// recordDryRunReplicas records the replica count the target would have been scaled to in dry-run mode,
+// together with the other changes of status, e.g. the paused replica count
+func (e *scaleExecutor) recordDryRunReplicas(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject,
+	status *kedav1alpha1.ScaledObjectStatus, currentReplicas int32, replicas int32) {
+	metricscollector.RecordScaledObjectDryRunReplicas(scaledObject.Namespace, scaledObject.Name, replicas)
+
+	if ptr.Equal(scaledObject.Status.DryRunReplicaCount, &replicas) &&
+		ptr.Equal(scaledObject.Status.PausedReplicaCount, status.PausedReplicaCount) {
+		return
+	}
+	status.DryRunReplicaCount = &replicas
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating status dry-run replica count")
+		return
+	}
+	logger.Info("ScaledObject is in dry-run mode, ScaleTarget is not scaled",
+		"Current Replicas Count", currentReplicas,
+		"Dry-run Replicas Count", replicas)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDryRun,
+		"Dry-run: would scale %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+}
+
+// GetProposedReplicaCount returns the replica count the target would be scaled to, by KEDA when
+// the triggers are not active or by the HPA (desiredReplicas computed from the metrics) otherwise,
+// it drives dry-run mode and the proposed change posted to the scale decision webhook
+func GetProposedReplicaCount(scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, isActive bool, isError bool, options *ScaleExecutorOptions) int32 {
+	minReplicas := int32(0)
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+
+	if !isActive {
+		switch {
+		case isError && scaledObject.HasFallbackReplicasForTriggers(getFailedTriggers(options)):
+			// the HPA scales to the fallback replicas through the metrics
+		case isError && !scaledObject.HasFallbackForTriggers(getFailedTriggers(options)):
+			// the target is not scaled when the triggers are failing
+			return currentReplicas
+		case scaledObject.GetIdleReplicaCount() != nil || minReplicas == 0:
+			if elapsed, _ := isCooldownPeriodElapsed(scaledObject); !elapsed {
+				return currentReplicas
+			}
+			_, replicas := getIdleOrMinimumReplicaCount(scaledObject)
+			return replicas
+		}
+	}
+
+	replicas := currentReplicas
+	if options != nil && options.DesiredReplicas != nil {
+		replicas = *options.DesiredReplicas
+	}
+	return min(max(replicas, *scaledObject.GetHPAMinReplicas()), scaledObject.GetHPAMaxReplicas())
+}
+
+// GetScaleReplicaCount returns the replica count KEDA itself scales the target of a ScaledObject with an HPA to, which is
+// the activation, the deactivation once the cooldown period elapsed and the correction to the minimum replica count.
+// The second return value is false if the replicas are left to the HPA.
+func GetScaleReplicaCount(scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, isActive bool, isError bool, options *ScaleExecutorOptions) (int32, bool) {
+	minReplicas := int32(0)
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+	idleReplicaCount := scaledObject.GetIdleReplicaCount()
+
+	if isActive {
+		if (idleReplicaCount != nil && currentReplicas < minReplicas) || currentReplicas == 0 {
+			return getActivationReplicaCount(scaledObject), true
+		}
+		return currentReplicas, false
+	}
+
+	switch {
+	case isError && (scaledObject.HasFallbackReplicasForTriggers(getFailedTriggers(options)) || !scaledObject.HasFallbackForTriggers(getFailedTriggers(options))):
+		// the HPA scales to the fallback replicas through the metrics, or the target is not scaled at all
+		return currentReplicas, false
+	case (idleReplicaCount != nil && currentReplicas > *idleReplicaCount) || (currentReplicas > 0 && minReplicas == 0):
+		if elapsed, _ := isCooldownPeriodElapsed(scaledObject); !elapsed {
+			return currentReplicas, false
+		}
+		_, replicas := getIdleOrMinimumReplicaCount(scaledObject)
+		return replicas, true
+	case currentReplicas < minReplicas && idleReplicaCount == nil:
+		return minReplicas, true
+	}
+	return currentReplicas, false
+}
+
+// getActivationReplicaCount returns the replica count the target is scaled to from zero or idle replicas
+func getActivationReplicaCount(scaledObject *kedav1alpha1.ScaledObject) int32 {
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil && *minReplicaCount > 0 {
+		return *minReplicaCount
+	}
+	return 1
+}
+
+// isCooldownPeriodElapsed returns whether the (initial) cooldown period since the triggers were active elapsed,
+// together with the cooldown period
+func isCooldownPeriodElapsed(scaledObject *kedav1alpha1.ScaledObject) (bool, time.Duration) {
 	var initialCooldownPeriod, cooldownPeriod time.Duration
 
 	if scaledObject.Spec.InitialCooldownPeriod != nil {
@@ -229,10 +408,23 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 
 	// LastActiveTime can be nil if the ScaleTarget was scaled outside of KEDA.
 	// In this case we will ignore the cooldown period and scale it down
-	if (scaledObject.Status.LastActiveTime == nil && scaledObject.ObjectMeta.CreationTimestamp.Add(initialCooldownPeriod).Before(time.Now())) || (scaledObject.Status.LastActiveTime != nil &&
-		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now())) {
+	elapsed := (scaledObject.Status.LastActiveTime == nil && scaledObject.ObjectMeta.CreationTimestamp.Add(initialCooldownPeriod).Before(time.Now())) || (scaledObject.Status.LastActiveTime != nil &&
+		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now()))
+	return elapsed, cooldownPeriod
+}
+
+// An object will be scaled down to 0 only if it's passed its cooldown period
+// or if LastActiveTime is nil
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas int32, options *ScaleExecutorOptions) {
+	elapsed, cooldownPeriod := isCooldownPeriodElapsed(scaledObject)
+	if elapsed {
 		// or last time a trigger was active was > cooldown period, so scale in.
 		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
+		scaleToReplicas = e.clampToScalingBudget(logger, scaledObject, currentReplicas, decideReplicas(options, scaleToReplicas))
+		if scaleToReplicas == currentReplicas {
+			logger.V(1).Info("ScaleTarget no change, the scale decision webhook keeps the current replicas")
+			return
+		}
 
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
 		if err == nil {
@@ -269,12 +461,15 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, activeTriggers []string) {
-	var replicas int32
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		replicas = *scaledObject.Spec.MinReplicaCount
-	} else {
-		replicas = 1
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas int32, options *ScaleExecutorOptions) {
+	replicas := e.clampToScalingBudget(logger, scaledObject, currentReplicas, decideReplicas(options, getActivationReplicaCount(scaledObject)))
+	if replicas == currentReplicas {
+		// the triggers are active even though the scale decision webhook keeps the current replicas
+		logger.V(1).Info("ScaleTarget no change, the scale decision webhook keeps the current replicas")
+		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
+			logger.Error(err, "Error updating last active time")
+		}
+		return
 	}
 
 	currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
@@ -283,7 +478,7 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
-		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(activeTriggers, ";"))
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(options.ActiveTriggers, ";"))
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
@@ -314,27 +509,60 @@ func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObje
 	scale.Spec.Replicas = replicas
 
 	_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
-	return currentReplicas, err
+	if err != nil {
+		return currentReplicas, err
+	}
+
+	e.updateScaleOnScaleTargets(ctx, scaledObject, replicas)
+	return currentReplicas, nil
+}
+
+// updateScaleOnScaleTargets scales the additional targets of scaleTargetRefs along with scaleTargetRef,
+// every target gets the replicas of scaleTargetRef adjusted by its weight. Between the scale operations
+// of KEDA (activation, deactivation, pause, freeze) the targets are scaled by their own HPAs.
+func (e *scaleExecutor) updateScaleOnScaleTargets(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, replicas int32) {
+	for _, target := range scaledObject.Status.ScaleTargets {
+		if target.ScaleTargetGVKR == nil {
+			continue
+		}
+		logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace, "scaleTarget.Name", target.Name)
+		scale, err := e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, target.ScaleTargetGVKR.GroupResource(), target.Name, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Error getting the scale of the additional scale target")
+			continue
+		}
+		targetReplicas := kedav1alpha1.WeightedReplicaCount(replicas, target.GetWeight())
+		if scale.Spec.Replicas == targetReplicas {
+			continue
+		}
+		scale.Spec.Replicas = targetReplicas
+		if _, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, target.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{}); err != nil {
+			logger.Error(err, "Error scaling the additional scale target", "replicas", targetReplicas)
+			continue
+		}
+		logger.V(1).Info("Successfully scaled the additional scale target", "replicas", targetReplicas)
+	}
 }
 
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
 func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
-	if scaledObject.Spec.IdleReplicaCount != nil {
-		return true, *scaledObject.Spec.IdleReplicaCount
+	if idleReplicaCount := scaledObject.GetIdleReplicaCount(); idleReplicaCount != nil {
+		return true, *idleReplicaCount
 	}
 
-	if scaledObject.Spec.MinReplicaCount == nil {
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	if minReplicaCount == nil {
 		return false, 0
 	}
 
-	return false, *scaledObject.Spec.MinReplicaCount
+	return false, *minReplicaCount
 }
 
 // GetPausedReplicaCount returns the paused replica count of the ScaledObject.
-// If not paused, it returns nil.
+// If not paused or if the pause expired, it returns nil.
 func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, error) {
-	if scaledObject.Annotations != nil {
+	if scaledObject.Annotations != nil && !kedav1alpha1.IsPauseExpired(scaledObject.Annotations, time.Now()) {
 		if val, ok := scaledObject.Annotations[kedav1alpha1.PausedReplicasAnnotation]; ok {
 			conv, err := strconv.ParseInt(val, 10, 32)
 			if err != nil {
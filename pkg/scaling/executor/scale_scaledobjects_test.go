@@ -20,6 +20,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -155,6 +156,75 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	assert.Equal(t, true, condition.IsFalse())
 }
 
+func TestScaleToScheduledMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(1)
+	scheduledMinReplicas := int32(5)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			ReplicaCountSchedules: []v1alpha1.ReplicaCountSchedule{
+				{
+					// fires every minute and lasts for an hour, so it is always active
+					Name:            "always",
+					Start:           "* * * * *",
+					Duration:        v1.Duration{Duration: time.Hour},
+					MinReplicaCount: &scheduledMinReplicas,
+				},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(1)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, scheduledMinReplicas, scale.Spec.Replicas)
+}
+
 func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -400,6 +470,203 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	assert.Equal(t, false, condition.IsTrue())
 }
 
+func TestScaleToScalingFreezeReplicaCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	frozenReplicaCount := int32(5)
+	replicaCount := int32(2)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			ScalingFreeze: &v1alpha1.ScalingFreezeStatus{
+				Kind:         v1alpha1.ClusterScalingFreezeKind,
+				Name:         "release",
+				ReplicaCount: &frozenReplicaCount,
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: replicaCount,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	// only the ready condition is updated
+	client.EXPECT().Status().Return(statusWriter).Times(1)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, frozenReplicaCount, scale.Spec.Replicas)
+}
+
+func TestScaleTargetsFollowScaleTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	frozenReplicaCount := int32(5)
+	replicaCount := int32(2)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			ScaleTargets: []v1alpha1.ScaleTargetStatus{
+				{
+					Name: "name-zone-b",
+					ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					Weight: "0.5",
+				},
+			},
+			ScalingFreeze: &v1alpha1.ScalingFreezeStatus{
+				Kind:         v1alpha1.ClusterScalingFreezeKind,
+				Name:         "release",
+				ReplicaCount: &frozenReplicaCount,
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: replicaCount,
+		},
+	}
+	targetScale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: 1,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(4)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), "name", gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), "name-zone-b", gomock.Any()).Return(targetScale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(targetScale), gomock.Any())
+
+	// only the ready condition is updated
+	client.EXPECT().Status().Return(statusWriter).Times(1)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, frozenReplicaCount, scale.Spec.Replicas)
+	assert.Equal(t, int32(3), targetScale.Spec.Replicas)
+}
+
+func TestScalingFreezeHoldsReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	replicaCount := int32(0)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			ScalingFreeze: &v1alpha1.ScalingFreezeStatus{
+				Kind: v1alpha1.ScalingFreezeKind,
+				Name: "maintenance",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	// the target isn't scaled from zero even though the triggers are active
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Times(0)
+
+	client.EXPECT().Status().Return(statusWriter).Times(1)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, false, condition.IsTrue())
+}
+
 func TestEventWitTriggerInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -460,3 +727,481 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	eventstring := <-recorder.Events
 	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger", eventstring)
 }
+
+func TestDryRunDoesNotScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	replicaCount := int32(2)
+	desiredReplicas := int32(4)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+			Annotations: map[string]string{
+				v1alpha1.DryRunAnnotation: "true",
+			},
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetKind: "apps/v1.Deployment",
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	// the scale subresource must not be touched in dry-run mode
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Times(0)
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: &desiredReplicas})
+
+	assert.NotNil(t, scaledObject.Status.DryRunReplicaCount)
+	assert.Equal(t, desiredReplicas, *scaledObject.Status.DryRunReplicaCount)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsTrue())
+
+	eventstring := <-recorder.Events
+	assert.Equal(t, "Normal KEDAScaleTargetDryRun Dry-run: would scale apps/v1.Deployment namespace/name from 2 to 4", eventstring)
+}
+
+func TestDryRunDoesNotScaleToPausedOrFrozenReplicas(t *testing.T) {
+	pausedReplicaCount := int32(0)
+	frozenReplicaCount := int32(1)
+	tests := []struct {
+		name                 string
+		annotations          map[string]string
+		scalingFreeze        *v1alpha1.ScalingFreezeStatus
+		expectedReplicas     int32
+		expectedPausedStatus *int32
+	}{
+		{
+			name:                 "paused replicas",
+			annotations:          map[string]string{v1alpha1.DryRunAnnotation: "true", v1alpha1.PausedReplicasAnnotation: "0"},
+			expectedReplicas:     pausedReplicaCount,
+			expectedPausedStatus: &pausedReplicaCount,
+		},
+		{
+			name:             "scaling freeze",
+			annotations:      map[string]string{v1alpha1.DryRunAnnotation: "true"},
+			scalingFreeze:    &v1alpha1.ScalingFreezeStatus{Kind: v1alpha1.ClusterScalingFreezeKind, Name: "release", ReplicaCount: &frozenReplicaCount},
+			expectedReplicas: frozenReplicaCount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := mock_client.NewMockClient(ctrl)
+			recorder := record.NewFakeRecorder(1)
+			mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+			statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+			scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+			replicaCount := int32(2)
+			scaledObject := v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "name",
+					Namespace:   "namespace",
+					Annotations: test.annotations,
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &v1alpha1.ScaleTarget{
+						Name: "name",
+					},
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					ScalingFreeze: test.scalingFreeze,
+				},
+			}
+			scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+			client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicaCount,
+				},
+			})
+
+			// the scale subresource must not be touched in dry-run mode
+			mockScaleClient.EXPECT().Scales(gomock.Any()).Times(0)
+
+			client.EXPECT().Status().Return(statusWriter).AnyTimes()
+			statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+
+			assert.Equal(t, &test.expectedReplicas, scaledObject.Status.DryRunReplicaCount)
+			assert.Equal(t, test.expectedPausedStatus, scaledObject.Status.PausedReplicaCount)
+		})
+	}
+}
+
+func TestNativeScalingScalesToDesiredReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	replicaCount := int32(2)
+	desiredReplicas := int32(10)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScalingMode: v1alpha1.ScalingModeNative,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetKind: "apps/v1.Deployment",
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: replicaCount,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: &desiredReplicas})
+
+	// the default scale up policies allow to add 4 pods or 100% within 15 seconds
+	assert.Equal(t, int32(6), scale.Spec.Replicas)
+
+	eventstring := <-recorder.Events
+	assert.Equal(t, "Normal KEDAScaleTargetScaled Scaled apps/v1.Deployment namespace/name from 2 to 6", eventstring)
+}
+
+func TestGetDryRunReplicaCount(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	cooldownPeriod := int32(300)
+	recentlyActive := v1.NewTime(time.Now())
+	longAgoActive := v1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name            string
+		minReplicas     *int32
+		maxReplicas     *int32
+		idleReplicas    *int32
+		lastActiveTime  *v1.Time
+		fallback        *v1alpha1.Fallback
+		triggers        []v1alpha1.ScaleTriggers
+		isActive        bool
+		isError         bool
+		failedTriggers  []int
+		desiredReplicas *int32
+		expected        int32
+	}{
+		{
+			name:            "active uses desired replicas",
+			minReplicas:     int32Ptr(1),
+			maxReplicas:     int32Ptr(10),
+			isActive:        true,
+			desiredReplicas: int32Ptr(6),
+			expected:        6,
+		},
+		{
+			name:            "desired replicas is capped by max",
+			minReplicas:     int32Ptr(1),
+			maxReplicas:     int32Ptr(10),
+			isActive:        true,
+			desiredReplicas: int32Ptr(25),
+			expected:        10,
+		},
+		{
+			name:        "unknown desired replicas keeps current",
+			minReplicas: int32Ptr(1),
+			maxReplicas: int32Ptr(10),
+			isActive:    true,
+			expected:    3,
+		},
+		{
+			name:           "inactive within cooldown keeps current",
+			minReplicas:    int32Ptr(0),
+			maxReplicas:    int32Ptr(10),
+			lastActiveTime: &recentlyActive,
+			expected:       3,
+		},
+		{
+			name:           "inactive after cooldown scales to zero",
+			minReplicas:    int32Ptr(0),
+			maxReplicas:    int32Ptr(10),
+			lastActiveTime: &longAgoActive,
+			expected:       0,
+		},
+		{
+			name:           "inactive after cooldown scales to idle",
+			minReplicas:    int32Ptr(2),
+			maxReplicas:    int32Ptr(10),
+			idleReplicas:   int32Ptr(0),
+			lastActiveTime: &longAgoActive,
+			expected:       0,
+		},
+		{
+			name:     "failing triggers without fallback keep current",
+			isError:  true,
+			expected: 3,
+		},
+		{
+			name:            "failing triggers with fallback use the fallback metrics",
+			minReplicas:     int32Ptr(1),
+			maxReplicas:     int32Ptr(10),
+			fallback:        &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5},
+			isError:         true,
+			desiredReplicas: int32Ptr(5),
+			expected:        5,
+		},
+		{
+			name:        "failing trigger with fallback uses the fallback metrics",
+			minReplicas: int32Ptr(1),
+			maxReplicas: int32Ptr(10),
+			triggers: []v1alpha1.ScaleTriggers{
+				{Type: "kafka", Fallback: &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5}},
+				{Type: "prometheus"},
+			},
+			isError:         true,
+			failedTriggers:  []int{0},
+			desiredReplicas: int32Ptr(5),
+			expected:        5,
+		},
+		{
+			name:        "failing trigger without fallback keeps current although another trigger has one",
+			minReplicas: int32Ptr(1),
+			maxReplicas: int32Ptr(10),
+			triggers: []v1alpha1.ScaleTriggers{
+				{Type: "kafka", Fallback: &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5}},
+				{Type: "prometheus"},
+			},
+			isError:         true,
+			failedTriggers:  []int{1},
+			desiredReplicas: int32Ptr(5),
+			expected:        3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					CreationTimestamp: longAgoActive,
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					MinReplicaCount:  test.minReplicas,
+					MaxReplicaCount:  test.maxReplicas,
+					IdleReplicaCount: test.idleReplicas,
+					CooldownPeriod:   &cooldownPeriod,
+					Fallback:         test.fallback,
+					Triggers:         test.triggers,
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					LastActiveTime: test.lastActiveTime,
+				},
+			}
+			options := &ScaleExecutorOptions{FailedTriggers: test.failedTriggers, DesiredReplicas: test.desiredReplicas}
+			assert.Equal(t, test.expected, GetProposedReplicaCount(scaledObject, 3, test.isActive, test.isError, options))
+		})
+	}
+}
+
+func TestVetoedScaleFromZeroKeepsReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	replicaCount := int32(0)
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicaCount,
+		},
+	})
+
+	// the scale subresource must not be touched when the scale decision webhook vetoes the activation
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Times(0)
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DecidedReplicas: &replicaCount})
+
+	// the triggers are still active, so the bookkeeping of the ScaledObject goes on
+	assert.NotNil(t, scaledObject.Status.LastActiveTime)
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsTrue())
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestGetScaleReplicaCount(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	cooldownPeriod := int32(300)
+	recentlyActive := v1.NewTime(time.Now())
+	longAgoActive := v1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name            string
+		minReplicas     *int32
+		idleReplicas    *int32
+		lastActiveTime  *v1.Time
+		fallback        *v1alpha1.Fallback
+		currentReplicas int32
+		isActive        bool
+		isError         bool
+		expected        int32
+		expectedScaled  bool
+	}{
+		{
+			name:           "active from zero activates",
+			isActive:       true,
+			expected:       1,
+			expectedScaled: true,
+		},
+		{
+			name:            "active from idle activates to min",
+			minReplicas:     int32Ptr(2),
+			idleReplicas:    int32Ptr(0),
+			currentReplicas: 0,
+			isActive:        true,
+			expected:        2,
+			expectedScaled:  true,
+		},
+		{
+			name:            "active with replicas is left to the HPA",
+			minReplicas:     int32Ptr(1),
+			currentReplicas: 3,
+			isActive:        true,
+			expected:        3,
+		},
+		{
+			name:            "inactive within cooldown is left to the HPA",
+			minReplicas:     int32Ptr(0),
+			lastActiveTime:  &recentlyActive,
+			currentReplicas: 3,
+			expected:        3,
+		},
+		{
+			name:            "inactive after cooldown deactivates",
+			minReplicas:     int32Ptr(0),
+			lastActiveTime:  &longAgoActive,
+			currentReplicas: 3,
+			expected:        0,
+			expectedScaled:  true,
+		},
+		{
+			name:            "failing triggers with fallback are left to the HPA",
+			minReplicas:     int32Ptr(0),
+			lastActiveTime:  &longAgoActive,
+			fallback:        &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5},
+			currentReplicas: 3,
+			isError:         true,
+			expected:        3,
+		},
+		{
+			name:            "below min replicas corrects to min",
+			minReplicas:     int32Ptr(2),
+			currentReplicas: 1,
+			expected:        2,
+			expectedScaled:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					CreationTimestamp: longAgoActive,
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					MinReplicaCount:  test.minReplicas,
+					IdleReplicaCount: test.idleReplicas,
+					CooldownPeriod:   &cooldownPeriod,
+					Fallback:         test.fallback,
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					LastActiveTime: test.lastActiveTime,
+				},
+			}
+			replicas, scaled := GetScaleReplicaCount(scaledObject, test.currentReplicas, test.isActive, test.isError, nil)
+			assert.Equal(t, test.expected, replicas)
+			assert.Equal(t, test.expectedScaled, scaled)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// clampToScalingBudget returns the replica count the target may be scaled to within the ScalingBudget limiting the ScaledObject.
+// The allocation is read from the status of the ScaledObject, which is fetched again before every scale, scaling down is never limited.
+func (e *scaleExecutor) clampToScalingBudget(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, replicas int32) int32 {
+	if replicas <= currentReplicas {
+		return replicas
+	}
+	allocation := scaledObject.Status.ScalingBudget
+	if allocation == nil || replicas <= allocation.MaxReplicas {
+		return replicas
+	}
+
+	clamped := max(allocation.MaxReplicas, currentReplicas)
+	logger.Info("Scaling is limited by scaling budget", "scalingBudget", allocation.Name, "Requested Replicas Count", replicas, "New Replicas Count", clamped)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetLimitedByScalingBudget,
+		"Scaling %s %s/%s to %d is limited to %d by scaling budget %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, replicas, clamped, allocation.Name)
+	return clamped
+}
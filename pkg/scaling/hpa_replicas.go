@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// hpaTolerance is the default tolerance of the HPA, it doesn't scale while the ratio
+// of the metric value to the target is within the tolerance
+const hpaTolerance = 0.1
+
+// scalerMetric is an external metric of a trigger with the values the trigger returned in the poll of the ScaledObject
+type scalerMetric struct {
+	MetricName string
+	Spec       v2.MetricSpec
+	Metrics    []external_metrics.ExternalMetricValue
+	Err        error
+}
+
+// getDesiredReplicas returns the replica count the HPA would compute from the metrics the triggers of the ScaledObject
+// returned in the poll, which is the highest of the replica counts computed for every metric. The fallback is applied
+// to the failing metrics like for the HPA. Resource metrics (cpu/memory) are not considered, nil is returned if no metric
+// could be retrieved.
+func (h *scaleHandler) getDesiredReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, states []scalerState,
+	scalersCache *cache.ScalersCache, formulaContext kedav1alpha1.FormulaContext, logger logr.Logger) *int32 {
+	currentReplicas, err := resolver.GetCurrentReplicas(ctx, h.client, h.scaleClient, scaledObject)
+	if err != nil {
+		logger.Error(err, "error getting current replicas for computing the desired replicas")
+		return nil
+	}
+
+	var specs []v2.MetricSpec
+	var metrics, fallbackMetrics []external_metrics.ExternalMetricValue
+	metricTriggerPairList := map[string]string{}
+	isFallbackActive := false
+	for _, state := range states {
+		for key, value := range state.Pairs {
+			metricTriggerPairList[key] = value
+		}
+		for _, metric := range state.ExternalMetrics {
+			metricValues, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, h.scaleClient, &h.scaledObjectsMetricCache,
+				metric.Metrics, metric.Err, metric.MetricName, state.TriggerIndex, scaledObject, metric.Spec)
+			if err != nil {
+				logger.V(1).Info("Skipping metric for computing the desired replicas", "metricName", metric.MetricName, "error", err)
+				continue
+			}
+			if fallbackActive {
+				isFallbackActive = true
+				fallbackMetrics = append(fallbackMetrics, metricValues...)
+			}
+			specs = append(specs, metric.Spec)
+			metrics = append(metrics, metricValues...)
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	if scaledObject.IsUsingModifiers() && scaledObject.Spec.Advanced.ScalingModifiers.HasFormula() {
+		metrics = modifiers.HandleScalingModifiers(scaledObject, metrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, scalersCache, formulaContext, logger)
+		specs = getCompositeMetricSpecs(scaledObject)
+	}
+
+	var desiredReplicas *int32
+	for _, spec := range specs {
+		value, found := float64(0), false
+		for _, metric := range metrics {
+			if metric.MetricName == spec.External.Metric.Name {
+				value += metric.Value.AsApproximateFloat64()
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+		replicas := calculateHPAReplicas(spec.External.Target, value, currentReplicas)
+		if desiredReplicas == nil || replicas > *desiredReplicas {
+			desiredReplicas = &replicas
+		}
+	}
+	return desiredReplicas
+}
+
+// getCompositeMetricSpecs returns the specs of the composite metrics the HPA of the ScaledObject scales on
+// if scalingModifiers formulas are used
+func getCompositeMetricSpecs(scaledObject *kedav1alpha1.ScaledObject) []v2.MetricSpec {
+	var specs []v2.MetricSpec
+	for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+		target, err := strconv.ParseFloat(composite.Target, 64)
+		if err != nil || target <= 0 {
+			continue
+		}
+		quantity := resource.NewMilliQuantity(int64(target*1000), resource.DecimalSI)
+		metricTarget := v2.MetricTarget{Type: composite.GetMetricType()}
+		if metricTarget.Type == v2.ValueMetricType {
+			metricTarget.Value = quantity
+		} else {
+			metricTarget.AverageValue = quantity
+		}
+		specs = append(specs, v2.MetricSpec{
+			Type: v2.ExternalMetricSourceType,
+			External: &v2.ExternalMetricSource{
+				Metric: v2.MetricIdentifier{Name: composite.MetricName()},
+				Target: metricTarget,
+			},
+		})
+	}
+	return specs
+}
+
+// calculateHPAReplicas returns the replica count the HPA computes for the metric value and its target
+func calculateHPAReplicas(target v2.MetricTarget, value float64, currentReplicas int32) int32 {
+	var replicas, usageRatio float64
+	if target.Type == v2.ValueMetricType {
+		usageRatio = value / target.Value.AsApproximateFloat64()
+		replicas = usageRatio * float64(currentReplicas)
+	} else {
+		replicas = value / target.AverageValue.AsApproximateFloat64()
+		if currentReplicas > 0 {
+			usageRatio = replicas / float64(currentReplicas)
+		}
+	}
+
+	if currentReplicas > 0 && math.Abs(1.0-usageRatio) <= hpaTolerance {
+		return currentReplicas
+	}
+	return int32(math.Ceil(replicas))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestCalculateHPAReplicas(t *testing.T) {
+	averageValue := v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)}
+	value := v2.MetricTarget{Type: v2.ValueMetricType, Value: resource.NewQuantity(10, resource.DecimalSI)}
+
+	tests := []struct {
+		name            string
+		target          v2.MetricTarget
+		value           float64
+		currentReplicas int32
+		expected        int32
+	}{
+		{name: "average value scale out", target: averageValue, value: 45, currentReplicas: 2, expected: 5},
+		{name: "average value scale in", target: averageValue, value: 15, currentReplicas: 4, expected: 2},
+		{name: "average value within tolerance", target: averageValue, value: 42, currentReplicas: 4, expected: 4},
+		{name: "average value from zero", target: averageValue, value: 5, currentReplicas: 0, expected: 1},
+		{name: "value scale out", target: value, value: 30, currentReplicas: 2, expected: 6},
+		{name: "value within tolerance", target: value, value: 10.5, currentReplicas: 2, expected: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, calculateHPAReplicas(test.target, test.value, test.currentReplicas))
+		})
+	}
+}
+
+func TestGetDesiredReplicasFromPolledMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{})).
+		SetArg(2, appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)}}).AnyTimes()
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "consumer"},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Kind: "Deployment"},
+		},
+	}
+	spec := func(metricName string) v2.MetricSpec {
+		return v2.MetricSpec{External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: metricName},
+			Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
+		}}
+	}
+	states := []scalerState{
+		{TriggerIndex: 0, ExternalMetrics: []scalerMetric{{MetricName: "s0-queue", Spec: spec("s0-queue"),
+			Metrics: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 45)}}}},
+		{TriggerIndex: 1, ExternalMetrics: []scalerMetric{{MetricName: "s1-lag", Spec: spec("s1-lag"),
+			Metrics: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s1-lag", 25)}}}},
+		// a failing trigger without fallback is skipped
+		{TriggerIndex: 2, ExternalMetrics: []scalerMetric{{MetricName: "s2-failing", Spec: spec("s2-failing"), Err: errors.New("unavailable")}}},
+	}
+
+	sh := &scaleHandler{client: mockClient}
+	desiredReplicas := sh.getDesiredReplicas(context.Background(), scaledObject, states, nil, kedav1alpha1.FormulaContext{}, logr.Discard())
+	assert.Equal(t, ptr.To[int32](5), desiredReplicas)
+
+	assert.Nil(t, sh.getDesiredReplicas(context.Background(), scaledObject, states[2:], nil, kedav1alpha1.FormulaContext{}, logr.Discard()))
+}
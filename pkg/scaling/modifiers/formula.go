@@ -28,6 +28,7 @@ package modifiers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/go-logr/logr"
@@ -40,8 +41,9 @@ import (
 
 // HandleScalingModifiers is the parent function for scalingModifiers structure.
 // If the structure is defined and conditions are met, apply the formula to
-// manipulate the metrics and return them
-func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, fallbackMetrics []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
+// manipulate the metrics and return them. The formulaContext exposes the runtime
+// context of the ScaledObject to the formula.
+func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, fallbackMetrics []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, formulaContext kedav1alpha1.FormulaContext, log logr.Logger) []external_metrics.ExternalMetricValue {
 	var err error
 	if so == nil || !so.IsUsingModifiers() {
 		return metrics
@@ -51,18 +53,22 @@ func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_me
 		sm := so.Spec.Advanced.ScalingModifiers
 
 		// apply formula if defined
-		metrics, err = applyScalingModifiersFormula(sm, metrics, metricTriggerList, cacheObj)
+		metrics, err = applyScalingModifiersFormula(sm, metrics, metricTriggerList, cacheObj, formulaContext)
 		if err != nil {
 			log.Error(err, "error applying custom scalingModifiers.Formula")
 		}
 		log.V(1).Info("returned metrics after formula is applied", "metrics", metrics)
 	} else if len(fallbackMetrics) > 0 {
-		metrics = []external_metrics.ExternalMetricValue{
-			{
-				MetricName: kedav1alpha1.CompositeMetricName,
-				Value:      fallbackMetrics[0].Value,
-				Timestamp:  fallbackMetrics[0].Timestamp,
-			}}
+		// fallback metrics are computed per composite metric already, every failing
+		// trigger provides them, so keep only the first one of each composite metric
+		metrics = []external_metrics.ExternalMetricValue{}
+		seen := map[string]bool{}
+		for _, metric := range fallbackMetrics {
+			if !seen[metric.MetricName] {
+				seen[metric.MetricName] = true
+				metrics = append(metrics, metric)
+			}
+		}
 	}
 	return metrics
 }
@@ -77,52 +83,87 @@ func ArrayContainsElement(el string, arr []string) bool {
 	return false
 }
 
-// applyScalingModifiersFormula applies formula if formula is defined, otherwise
+// applyScalingModifiersFormula applies formulas if they are defined, otherwise
 // skip
-func applyScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, cacheObj *cache.ScalersCache) ([]external_metrics.ExternalMetricValue, error) {
-	if sm.Formula != "" {
-		metrics, err := calculateScalingModifiersFormula(metrics, cacheObj, pairList)
+func applyScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, cacheObj *cache.ScalersCache, formulaContext kedav1alpha1.FormulaContext) ([]external_metrics.ExternalMetricValue, error) {
+	if sm.HasFormula() {
+		metrics, err := calculateScalingModifiersFormula(sm, metrics, cacheObj, pairList, formulaContext)
 		return metrics, err
 	}
 	return metrics, nil
 }
 
-// calculateScalingModifiersFormula creates custom composite metric & calculates
-// custom formula and returns this finalized metric
-func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, pairList map[string]string) ([]external_metrics.ExternalMetricValue, error) {
-	var ret external_metrics.ExternalMetricValue
-	var out float64
-	ret.MetricName = kedav1alpha1.CompositeMetricName
-	ret.Timestamp = v1.Now()
+// calculateScalingModifiersFormula creates custom composite metrics & calculates
+// their custom formulas and returns these finalized metrics
+func calculateScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, list []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, pairList map[string]string, formulaContext kedav1alpha1.FormulaContext) ([]external_metrics.ExternalMetricValue, error) {
+	timestamp := v1.Now()
+
+	if len(cacheObj.CompiledFormulas) == 0 {
+		return nil, fmt.Errorf("cached compiled formula is nil during its calculation")
+	}
+
+	// history functions operate on the current values only if the cache doesn't keep the history,
+	// the samples of the history kept by the cache are recorded by the scale loop
+	history := cacheObj.MetricsHistory
+	if history == nil {
+		history = cache.NewMetricsHistory(1)
+		recordMetrics(history, list, pairList, timestamp.Time)
+	}
 
 	// using https://github.com/antonmedv/expr to evaluate formula expression
-	data := make(map[string]float64)
+	triggers := make(map[string]float64)
 	for _, v := range list {
-		data[pairList[v.MetricName]] = v.Value.AsApproximateFloat64()
+		triggers[pairList[v.MetricName]] = v.Value.AsApproximateFloat64()
 	}
+	data := kedav1alpha1.NewFormulaEnv(triggers, formulaContext, historyFunctions(history, timestamp.Time))
+
+	composites := sm.GetCompositeMetrics()
+	ret := make([]external_metrics.ExternalMetricValue, 0, len(composites))
+	for _, composite := range composites {
+		metricName := composite.MetricName()
+		program, found := cacheObj.CompiledFormulas[metricName]
+		if !found || program == nil {
+			return nil, fmt.Errorf("cached compiled formula of %s is nil during its calculation", metricName)
+		}
 
-	if cacheObj.CompiledFormula == nil {
-		return nil, fmt.Errorf("cached compiled formula is nil during its calculation")
+		// run expression with precompiled formula and real data
+		tmp, err := expr.Run(program, data)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to run custom formula of %s: %w", metricName, err)
+		}
+
+		// return values to known format for externalMetricValue struct
+		metric := external_metrics.ExternalMetricValue{
+			MetricName: metricName,
+			Timestamp:  timestamp,
+		}
+		metric.Value.SetMilli(int64(tmp.(float64) * 1000))
+		ret = append(ret, metric)
 	}
+	return ret, nil
+}
 
-	// run expression with precompiled formula and real data
-	tmp, err := expr.Run(cacheObj.CompiledFormula, data)
-	if err != nil {
-		return nil, fmt.Errorf("error trying to run custom formula: %w", err)
+// RecordMetricsHistory stores the metric values of the triggers in the history backing the history functions of
+// scalingModifiers formula. It is called once per poll of the scale loop, so reading the metrics doesn't add samples.
+func RecordMetricsHistory(cacheObj *cache.ScalersCache, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, timestamp time.Time) {
+	if cacheObj == nil || cacheObj.MetricsHistory == nil {
+		return
 	}
+	recordMetrics(cacheObj.MetricsHistory, metrics, pairList, timestamp)
+}
 
-	// return values to known format for externalMetricValue struct
-	out = tmp.(float64)
-	ret.Value.SetMilli(int64(out * 1000))
-	return []external_metrics.ExternalMetricValue{ret}, nil
+func recordMetrics(history *cache.MetricsHistory, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, timestamp time.Time) {
+	for _, metric := range metrics {
+		history.Record(pairList[metric.MetricName], metric.Value.AsApproximateFloat64(), timestamp)
+	}
 }
 
 // GetPairTriggerAndMetric adds new pair of trigger-metric to the list for
 // scalingModifiers formula list thats needed to map the metric value to
-// trigger name. This is only ran if scalingModifiers.Formula is defined in SO.
+// trigger name. This is only ran if scalingModifiers formulas are defined in SO.
 func GetPairTriggerAndMetric(so *kedav1alpha1.ScaledObject, metric string, trigger string) (map[string]string, error) {
 	list := map[string]string{}
-	if so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Formula != "" {
+	if so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.HasFormula() {
 		if trigger == "" {
 			return list, fmt.Errorf("trigger name not given with compositeScaler for metric %s", metric)
 		}
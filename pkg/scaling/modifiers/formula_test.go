@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestCalculateScalingModifiersFormulaWithHistory(t *testing.T) {
+	tests := []struct {
+		name          string
+		formula       string
+		history       []float64
+		current       float64
+		expectedValue float64
+	}{
+		{
+			name:          "avg_over",
+			formula:       `avg_over(queue, "5m")`,
+			history:       []float64{2, 4},
+			current:       6,
+			expectedValue: 4,
+		},
+		{
+			name:          "max_over",
+			formula:       `max_over(queue, "5m")`,
+			history:       []float64{2, 9},
+			current:       6,
+			expectedValue: 9,
+		},
+		{
+			name:          "delta",
+			formula:       `delta(queue, "5m")`,
+			history:       []float64{2, 4},
+			current:       8,
+			expectedValue: 6,
+		},
+		{
+			name:          "rate",
+			formula:       `rate(queue, "5m")`,
+			history:       []float64{0, 60},
+			current:       120,
+			expectedValue: 1,
+		},
+		{
+			name:          "window excludes old samples",
+			formula:       `avg_over(queue, "90s")`,
+			history:       []float64{100, 4},
+			current:       6,
+			expectedValue: 5,
+		},
+		{
+			name:          "without history",
+			formula:       `avg_over(queue, "5m") + queue`,
+			current:       3,
+			expectedValue: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &kedav1alpha1.ScaledObject{
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					Advanced: &kedav1alpha1.AdvancedConfig{
+						ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: test.formula, Target: "1"},
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{{Name: "queue", Type: "kafka"}},
+				},
+			}
+			program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+			assert.NoError(t, err)
+
+			// samples are one minute apart, the last one a minute ago
+			history := cache.NewMetricsHistory(cache.MetricsHistorySize(kedav1alpha1.MaxFormulaHistoryWindow, time.Minute))
+			now := time.Now()
+			for i, value := range test.history {
+				history.Record("queue", value, now.Add(time.Duration(i-len(test.history))*time.Minute))
+			}
+			cacheObj := &cache.ScalersCache{CompiledFormulas: program, MetricsHistory: history}
+
+			metrics := []external_metrics.ExternalMetricValue{
+				{MetricName: "s0-queue", Value: *resource.NewMilliQuantity(int64(test.current*1000), resource.DecimalSI)},
+			}
+			// the scale loop records the current value before the formula is calculated
+			pairList := map[string]string{"s0-queue": "queue"}
+			RecordMetricsHistory(cacheObj, metrics, pairList, now)
+			result, err := calculateScalingModifiersFormula(so.Spec.Advanced.ScalingModifiers, metrics, cacheObj, pairList, kedav1alpha1.NewFormulaContext(so, 1, now))
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expectedValue, result[0].Value.AsApproximateFloat64(), 0.01)
+
+			// calculating the formula again, like the HPA reading the metric, doesn't record another sample
+			result, err = calculateScalingModifiersFormula(so.Spec.Advanced.ScalingModifiers, metrics, cacheObj, pairList, kedav1alpha1.NewFormulaContext(so, 1, now))
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expectedValue, result[0].Value.AsApproximateFloat64(), 0.01)
+		})
+	}
+}
+
+func TestCalculateScalingModifiersFormulaWithContext(t *testing.T) {
+	// Monday 2025-03-03 10:30 UTC, 11:30 in Europe/Prague
+	now := time.Date(2025, time.March, 3, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		formula       string
+		timezone      string
+		expectedValue float64
+	}{
+		{
+			name:          "value per replica with a weekday floor",
+			formula:       `weekday >= 1 && weekday <= 5 ? max(queue / currentReplicas, 3) : queue / currentReplicas`,
+			expectedValue: 3,
+		},
+		{
+			name:          "replica bounds",
+			formula:       `minReplicas + maxReplicas`,
+			expectedValue: 12,
+		},
+		{
+			name:          "trigger activity and errors",
+			formula:       `(active.queue ? 1 : 0) + (failed.requests ? 10 : 0) + (failed.queue ? 100 : 0)`,
+			expectedValue: 11,
+		},
+		{
+			name:          "time of day in timezone",
+			formula:       `hour * 100 + minute`,
+			timezone:      "Europe/Prague",
+			expectedValue: 1130,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &kedav1alpha1.ScaledObject{
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					MinReplicaCount: ptr.To[int32](2),
+					MaxReplicaCount: ptr.To[int32](10),
+					Advanced: &kedav1alpha1.AdvancedConfig{
+						ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: test.formula, Target: "1", Timezone: test.timezone},
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{Name: "queue", Type: "kafka"},
+						{Name: "requests", Type: "prometheus"},
+					},
+				},
+			}
+			program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+			assert.NoError(t, err)
+
+			formulaContext := kedav1alpha1.NewFormulaContext(so, 4, now)
+			formulaContext.Active["queue"] = true
+			formulaContext.Failed["requests"] = true
+
+			metrics := []external_metrics.ExternalMetricValue{
+				{MetricName: "s0-queue", Value: *resource.NewQuantity(8, resource.DecimalSI)},
+			}
+			result, err := calculateScalingModifiersFormula(so.Spec.Advanced.ScalingModifiers, metrics, &cache.ScalersCache{CompiledFormulas: program}, map[string]string{"s0-queue": "queue"}, formulaContext)
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expectedValue, result[0].Value.AsApproximateFloat64(), 0.01)
+		})
+	}
+}
+
+func TestHandleScalingModifiersWithCompositeMetrics(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{
+					CompositeMetrics: []kedav1alpha1.CompositeMetric{
+						{Name: "backlog", Formula: "queue / workers", Target: "5"},
+						{Name: "latency", Formula: "latency > 200 ? latency : 0", Target: "100"},
+					},
+				},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "kafka"},
+				{Name: "workers", Type: "prometheus"},
+				{Name: "latency", Type: "prometheus"},
+			},
+		},
+	}
+	programs, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+	assert.NoError(t, err)
+	cacheObj := &cache.ScalersCache{CompiledFormulas: programs}
+
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(40, resource.DecimalSI)},
+		{MetricName: "s1-workers", Value: *resource.NewQuantity(4, resource.DecimalSI)},
+		{MetricName: "s2-latency", Value: *resource.NewQuantity(250, resource.DecimalSI)},
+	}
+	pairs := map[string]string{"s0-queue": "queue", "s1-workers": "workers", "s2-latency": "latency"}
+
+	result := HandleScalingModifiers(so, metrics, pairs, false, nil, cacheObj, kedav1alpha1.NewFormulaContext(so, 1, time.Now()), logr.Discard())
+	assert.Len(t, result, 2)
+	assert.Equal(t, "composite-metric-backlog", result[0].MetricName)
+	assert.InDelta(t, 10, result[0].Value.AsApproximateFloat64(), 0.01)
+	assert.Equal(t, "composite-metric-latency", result[1].MetricName)
+	assert.InDelta(t, 250, result[1].Value.AsApproximateFloat64(), 0.01)
+
+	// every failing trigger provides the fallback metrics of all composite metrics
+	fallbackMetrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "composite-metric-backlog", Value: *resource.NewQuantity(50, resource.DecimalSI)},
+		{MetricName: "composite-metric-latency", Value: *resource.NewQuantity(1000, resource.DecimalSI)},
+		{MetricName: "composite-metric-backlog", Value: *resource.NewQuantity(50, resource.DecimalSI)},
+		{MetricName: "composite-metric-latency", Value: *resource.NewQuantity(1000, resource.DecimalSI)},
+	}
+	result = HandleScalingModifiers(so, nil, pairs, true, fallbackMetrics, cacheObj, kedav1alpha1.FormulaContext{}, logr.Discard())
+	assert.Len(t, result, 2)
+	assert.Equal(t, "composite-metric-backlog", result[0].MetricName)
+	assert.Equal(t, "composite-metric-latency", result[1].MetricName)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// historyFunctions returns the history functions of scalingModifiers formula evaluated
+// over the samples stored in history
+func historyFunctions(history *cache.MetricsHistory, now time.Time) map[string]kedav1alpha1.FormulaHistoryFunc {
+	withWindow := func(aggregate func([]cache.MetricSample) float64) kedav1alpha1.FormulaHistoryFunc {
+		return func(trigger string, window string) (float64, error) {
+			duration, err := kedav1alpha1.ParseFormulaHistoryWindow(window)
+			if err != nil {
+				return 0, err
+			}
+			samples := history.Window(trigger, duration, now)
+			if len(samples) == 0 {
+				return 0, fmt.Errorf("no samples found for trigger %q", trigger)
+			}
+			return aggregate(samples), nil
+		}
+	}
+
+	return map[string]kedav1alpha1.FormulaHistoryFunc{
+		kedav1alpha1.FormulaFunctionAvgOver: withWindow(avgOver),
+		kedav1alpha1.FormulaFunctionMaxOver: withWindow(maxOver),
+		kedav1alpha1.FormulaFunctionRate:    withWindow(rate),
+		kedav1alpha1.FormulaFunctionDelta:   withWindow(delta),
+	}
+}
+
+func avgOver(samples []cache.MetricSample) float64 {
+	sum := 0.0
+	for _, sample := range samples {
+		sum += sample.Value
+	}
+	return sum / float64(len(samples))
+}
+
+func maxOver(samples []cache.MetricSample) float64 {
+	result := samples[0].Value
+	for _, sample := range samples[1:] {
+		result = max(result, sample.Value)
+	}
+	return result
+}
+
+// delta returns the difference between the newest and the oldest sample
+func delta(samples []cache.MetricSample) float64 {
+	return samples[len(samples)-1].Value - samples[0].Value
+}
+
+// rate returns the per-second change between the oldest and the newest sample
+func rate(samples []cache.MetricSample) float64 {
+	elapsed := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return delta(samples) / elapsed
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/decision"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+)
+
+// decideScaledObjectScale posts the replica change KEDA proposes for the ScaledObject to its scale decision webhook
+// and passes the decision to the scale request, a vetoed change keeps the current replicas. In dry-run and native
+// scaling mode every change is proposed, while with an HPA only the changes made by KEDA are, which are the activation,
+// the deactivation, the correction to the minimum replica count and the fallback served to the HPA.
+func (h *scaleHandler) decideScaledObjectScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive, isError bool, metricsRecords map[string]metricscache.MetricsRecord, options *executor.ScaleExecutorOptions) {
+	webhook := scaledObject.GetScaleDecisionWebhook()
+	if webhook == nil || scaledObject.NeedToBePausedByAnnotation() || scaledObject.Status.ScalingFreeze != nil {
+		h.setFallbackDecision(scaledObject.GenerateIdentifier(), nil)
+		return
+	}
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+
+	currentReplicas, err := resolver.GetCurrentReplicas(ctx, h.client, h.scaleClient, scaledObject)
+	if err != nil {
+		logger.Error(err, "error getting current replicas for the scale decision webhook")
+		return
+	}
+
+	var proposedReplicas int32
+	isFallback := false
+	if scaledObject.IsDryRun() || scaledObject.IsNativeScaling() {
+		proposedReplicas = executor.GetProposedReplicaCount(scaledObject, currentReplicas, isActive, isError, options)
+	} else {
+		var scaledByKEDA bool
+		proposedReplicas, scaledByKEDA = executor.GetScaleReplicaCount(scaledObject, currentReplicas, isActive, isError, options)
+		if !scaledByKEDA && isError {
+			proposedReplicas, isFallback = h.getFallbackReplicas(ctx, scaledObject, currentReplicas)
+		}
+		if !isFallback {
+			h.setFallbackDecision(scaledObject.GenerateIdentifier(), nil)
+		}
+	}
+	if proposedReplicas == currentReplicas {
+		h.setFallbackDecision(scaledObject.GenerateIdentifier(), nil)
+		return
+	}
+
+	triggers := make([]decision.Trigger, 0, len(metricsRecords))
+	for metricName, record := range metricsRecords {
+		trigger := decision.Trigger{MetricName: metricName, Active: record.IsActive}
+		for _, metric := range record.Metric {
+			trigger.Value += metric.Value.AsApproximateFloat64()
+		}
+		if record.ScalerError != nil {
+			trigger.Error = record.ScalerError.Error()
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	response := h.decideScale(ctx, scaledObject, logger, webhook, &decision.Request{
+		Kind:            "ScaledObject",
+		Namespace:       scaledObject.Namespace,
+		Name:            scaledObject.Name,
+		CurrentReplicas: &currentReplicas,
+		DesiredReplicas: proposedReplicas,
+		IsActive:        isActive,
+		IsError:         isError,
+		Triggers:        triggers,
+	})
+
+	var decidedReplicas *int32
+	switch {
+	case !response.Allowed:
+		decidedReplicas = ptr.To(currentReplicas)
+	case response.Replicas != nil && *response.Replicas != proposedReplicas:
+		if !isClampInRange(scaledObject, currentReplicas, proposedReplicas, *response.Replicas) {
+			logger.Info("Ignoring the replicas of the scale decision webhook out of range", "currentReplicas", currentReplicas,
+				"desiredReplicas", proposedReplicas, "replicas", *response.Replicas)
+			break
+		}
+		h.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleDecisionClamped,
+			"Scale decision webhook clamped the scale from %d to %d replicas: %s", proposedReplicas, *response.Replicas, response.Reason)
+		decidedReplicas = ptr.To(*response.Replicas)
+	}
+	if isFallback {
+		// the HPA scales to the fallback replicas through the metrics served to it
+		h.setFallbackDecision(scaledObject.GenerateIdentifier(), decidedReplicas)
+		return
+	}
+	options.DecidedReplicas = decidedReplicas
+}
+
+// isClampInRange returns whether the replicas the scale decision webhook clamped the proposed scale change to
+// are in between the current and the proposed replicas and within the replica count bounds of the ScaledObject
+func isClampInRange(scaledObject *kedav1alpha1.ScaledObject, currentReplicas, proposedReplicas, replicas int32) bool {
+	minReplicas := int32(0)
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+	return replicas >= min(currentReplicas, proposedReplicas) && replicas <= max(currentReplicas, proposedReplicas) &&
+		replicas >= minReplicas && replicas <= scaledObject.GetHPAMaxReplicas()
+}
+
+// getFallbackReplicas returns the replica count the HPA is scaled to by the fallbacks active for the ScaledObject,
+// the second return value is false if no fallback with a replica count is active
+func (h *scaleHandler) getFallbackReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32) (int32, bool) {
+	cache, err := h.GetScalersCache(ctx, scaledObject)
+	if err != nil {
+		return currentReplicas, false
+	}
+
+	replicas, found := int32(0), false
+	for triggerIndex := range cache.Scalers {
+		fallbackSpec, _ := scaledObject.GetFallbackForTrigger(triggerIndex)
+		if fallbackSpec == nil || fallbackSpec.Behavior == kedav1alpha1.FallbackBehaviorLastKnownGood {
+			continue
+		}
+		metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
+		if err != nil {
+			continue
+		}
+		for _, spec := range metricSpecs {
+			if spec.External == nil || !fallback.IsFallbackActive(scaledObject, spec.External.Metric.Name) {
+				continue
+			}
+			// the HPA scales to the highest replica count of its metrics
+			replicas, found = max(replicas, fallback.GetFallbackReplicas(fallbackSpec, currentReplicas)), true
+		}
+	}
+	if !found {
+		return currentReplicas, false
+	}
+	return replicas, true
+}
+
+// setFallbackDecision stores the replica count the scale decision webhook decided on for the fallback of the ScaledObject,
+// nil removes the decision
+func (h *scaleHandler) setFallbackDecision(key string, replicas *int32) {
+	h.fallbackDecisionsLock.Lock()
+	defer h.fallbackDecisionsLock.Unlock()
+	if replicas == nil {
+		delete(h.fallbackDecisions, key)
+		return
+	}
+	if h.fallbackDecisions == nil {
+		h.fallbackDecisions = map[string]int32{}
+	}
+	h.fallbackDecisions[key] = *replicas
+}
+
+// getFallbackScaledObject returns the ScaledObject whose fallbacks serve the metrics to the HPA, the fallbacks
+// fall back to the replica count the scale decision webhook decided on, if it was consulted on the fallback
+func (h *scaleHandler) getFallbackScaledObject(scaledObject *kedav1alpha1.ScaledObject) *kedav1alpha1.ScaledObject {
+	h.fallbackDecisionsLock.Lock()
+	replicas, found := h.fallbackDecisions[scaledObject.GenerateIdentifier()]
+	h.fallbackDecisionsLock.Unlock()
+	if !found {
+		return scaledObject
+	}
+
+	decided := scaledObject.DeepCopy()
+	decide := func(fallbackSpec *kedav1alpha1.Fallback) {
+		if fallbackSpec != nil && fallbackSpec.Behavior != kedav1alpha1.FallbackBehaviorLastKnownGood {
+			fallbackSpec.Behavior = kedav1alpha1.FallbackBehaviorStatic
+			fallbackSpec.Replicas = replicas
+		}
+	}
+	decide(decided.Spec.Fallback)
+	for i := range decided.Spec.Triggers {
+		decide(decided.Spec.Triggers[i].Fallback)
+	}
+	return decided
+}
+
+// decideScaledJobScale posts the Job count proposed for the ScaledJob to its scale decision webhook and returns
+// the decision applied to the scale request, a vetoed change deactivates the ScaledJob so no Job is created
+func (h *scaleHandler) decideScaledJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive, isError bool, scaleTo, maxScale int64, scalersMetrics []scaledjob.ScalerMetrics) (bool, int64, int64) {
+	webhook := scaledJob.GetScaleDecisionWebhook()
+	if webhook == nil || !isActive || scaledJob.Status.ScalingFreeze != nil {
+		return isActive, scaleTo, maxScale
+	}
+	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+
+	triggers := make([]decision.Trigger, 0, len(scalersMetrics))
+	for _, metrics := range scalersMetrics {
+		trigger := decision.Trigger{Value: metrics.QueueLength, Active: metrics.IsActive}
+		if metrics.TriggerIndex < len(scaledJob.Spec.Triggers) {
+			trigger.Name = scaledJob.Spec.Triggers[metrics.TriggerIndex].Name
+			if trigger.Name == "" {
+				trigger.Name = scaledJob.Spec.Triggers[metrics.TriggerIndex].Type
+			}
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	proposedJobs := int32(min(scaleTo, maxScale))
+	response := h.decideScale(ctx, scaledJob, logger, webhook, &decision.Request{
+		Kind:            "ScaledJob",
+		Namespace:       scaledJob.Namespace,
+		Name:            scaledJob.Name,
+		DesiredReplicas: proposedJobs,
+		IsActive:        isActive,
+		IsError:         isError,
+		Triggers:        triggers,
+	})
+	if !response.Allowed {
+		return false, scaleTo, maxScale
+	}
+	if response.Replicas != nil && *response.Replicas < proposedJobs {
+		h.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAScaleDecisionClamped,
+			"Scale decision webhook clamped the scale from %d to %d Jobs: %s", proposedJobs, *response.Replicas, response.Reason)
+		replicas := int64(*response.Replicas)
+		return replicas > 0, min(scaleTo, replicas), min(maxScale, replicas)
+	}
+	return isActive, scaleTo, maxScale
+}
+
+// decideScale resolves the authentication of the scale decision webhook, posts the request to it and records
+// the failure of the webhook and a vetoed change in events
+func (h *scaleHandler) decideScale(ctx context.Context, object client.Object, logger logr.Logger, webhook *kedav1alpha1.ScaleDecisionWebhook, request *decision.Request) *decision.Response {
+	authParams, _, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, webhook.AuthenticationRef, nil, object.GetNamespace(), h.secretsLister)
+	var response *decision.Response
+	if err != nil {
+		response = &decision.Response{Allowed: !webhook.FailsClosed()}
+	} else {
+		response, err = decision.Decide(ctx, webhook, authParams, request)
+	}
+	if err != nil {
+		logger.Error(err, "error calling scale decision webhook", "failsClosed", webhook.FailsClosed())
+		h.recorder.Eventf(object, corev1.EventTypeWarning, eventreason.KEDAScaleDecisionWebhookFailed,
+			"Scale decision webhook failed: %s", err)
+	}
+
+	if !response.Allowed {
+		logger.Info("Scale decision webhook vetoed the scale change", "desiredReplicas", request.DesiredReplicas, "reason", response.Reason)
+		h.recorder.Eventf(object, corev1.EventTypeNormal, eventreason.KEDAScaleDecisionVetoed,
+			"Scale decision webhook vetoed the scale to %d: %s", request.DesiredReplicas, response.Reason)
+	}
+	return response
+}
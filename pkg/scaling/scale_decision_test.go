@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+)
+
+func TestDecideScaledObjectScale(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		scalingMode     kedav1alpha1.ScalingMode
+		expectedPosted  bool
+		expectedDecided *int32
+		expectedEvent   string
+	}{
+		{"approved", `{"allowed":true}`, kedav1alpha1.ScalingModeNative, true, nil, ""},
+		{"clamped", `{"allowed":true,"replicas":4}`, kedav1alpha1.ScalingModeNative, true, ptr.To[int32](4),
+			"Normal KEDAScaleDecisionClamped Scale decision webhook clamped the scale from 6 to 4 replicas: "},
+		{"clamped above the proposed replicas is ignored", `{"allowed":true,"replicas":8}`, kedav1alpha1.ScalingModeNative, true, nil, ""},
+		{"clamped below the current replicas is ignored", `{"allowed":true,"replicas":1}`, kedav1alpha1.ScalingModeNative, true, nil, ""},
+		{"clamped below minReplicaCount is ignored", `{"allowed":true,"replicas":2}`, kedav1alpha1.ScalingModeNative, true, nil, ""},
+		{"vetoed keeps the current replicas", `{"allowed":false,"reason":"maintenance"}`, kedav1alpha1.ScalingModeNative, true, ptr.To[int32](2),
+			"Normal KEDAScaleDecisionVetoed Scale decision webhook vetoed the scale to 6: maintenance"},
+		{"scaling by the HPA is not posted", `{"allowed":false}`, "", false, nil, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				requests++
+				fmt.Fprint(writer, test.body)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mock_client.NewMockClient(ctrl)
+			mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{})).
+				SetArg(2, appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)}}).AnyTimes()
+
+			scaledObject := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "consumer"},
+					MinReplicaCount: ptr.To[int32](3),
+					Advanced: &kedav1alpha1.AdvancedConfig{
+						ScalingMode:          test.scalingMode,
+						ScaleDecisionWebhook: &kedav1alpha1.ScaleDecisionWebhook{URL: server.URL},
+					},
+				},
+				Status: kedav1alpha1.ScaledObjectStatus{
+					ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Kind: "Deployment"},
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			sh := &scaleHandler{client: mockClient, recorder: recorder}
+
+			options := &executor.ScaleExecutorOptions{DesiredReplicas: ptr.To[int32](6)}
+			sh.decideScaledObjectScale(context.Background(), scaledObject, true, false, nil, options)
+			assert.Equal(t, test.expectedDecided, options.DecidedReplicas)
+			if test.expectedPosted {
+				assert.Equal(t, 1, requests)
+			} else {
+				assert.Equal(t, 0, requests)
+			}
+			if test.expectedEvent != "" {
+				assert.Equal(t, test.expectedEvent, <-recorder.Events)
+			}
+			assert.Len(t, recorder.Events, 0)
+		})
+	}
+}
+
+func TestDecideScaledJobScale(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		isActive         bool
+		expectedActive   bool
+		expectedScaleTo  int64
+		expectedMaxScale int64
+	}{
+		{"approved", `{"allowed":true}`, true, true, 8, 10},
+		{"clamped", `{"allowed":true,"replicas":4}`, true, true, 4, 4},
+		{"clamped to zero", `{"allowed":true,"replicas":0}`, true, false, 0, 0},
+		{"clamped above the proposed Jobs is ignored", `{"allowed":true,"replicas":12}`, true, true, 8, 10},
+		{"negative replicas are ignored", `{"allowed":true,"replicas":-1}`, true, true, 8, 10},
+		{"vetoed", `{"allowed":false,"reason":"maintenance"}`, true, false, 8, 10},
+		{"inactive is not posted", `{"allowed":true,"replicas":4}`, false, false, 8, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				requests++
+				fmt.Fprint(writer, test.body)
+			}))
+			defer server.Close()
+
+			scaledJob := &kedav1alpha1.ScaledJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+				Spec: kedav1alpha1.ScaledJobSpec{
+					Advanced: &kedav1alpha1.ScaledJobAdvancedConfig{
+						ScaleDecisionWebhook: &kedav1alpha1.ScaleDecisionWebhook{URL: server.URL},
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq"}},
+				},
+			}
+			sh := &scaleHandler{recorder: record.NewFakeRecorder(10)}
+
+			isActive, scaleTo, maxScale := sh.decideScaledJobScale(context.Background(), scaledJob, test.isActive, false, 8, 10,
+				[]scaledjob.ScalerMetrics{{QueueLength: 8, MaxValue: 10, IsActive: test.isActive}})
+			assert.Equal(t, test.expectedActive, isActive)
+			assert.Equal(t, test.expectedScaleTo, scaleTo)
+			assert.Equal(t, test.expectedMaxScale, maxScale)
+			if test.isActive {
+				assert.Equal(t, 1, requests)
+			} else {
+				assert.Equal(t, 0, requests)
+			}
+		})
+	}
+}
@@ -19,14 +19,18 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/expr-lang/expr/vm"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -74,13 +78,42 @@ type scaleHandler struct {
 	scalerCaches             map[string]*cache.ScalersCache
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
-	secretsLister            corev1listers.SecretLister
+	// metricsHistories are guarded by scalerCachesLock
+	metricsHistories map[string]*cache.MetricsHistory
+	// metricTransforms are guarded by scalerCachesLock
+	metricTransforms map[string]*cache.MetricTransforms
+	secretsLister    corev1listers.SecretLister
+	// sharedQueries coalesce the identical upstream queries of the scalers, nil if disabled
+	sharedQueries *cache.SharedQueries
+
+	// triggersStatusUpdates are the times the triggers status of the ScaledObjects was last written,
+	// the status of every ScaledObject is written at most once per triggersStatusUpdateInterval
+	triggersStatusUpdates        map[string]time.Time
+	triggersStatusLock           sync.Mutex
+	triggersStatusUpdateInterval time.Duration
+
+	// triggerActivities are the activations of the triggers kept for their hysteresis
+	triggerActivities     map[string]map[triggerMetricKey]*triggerActivity
+	triggerActivitiesLock sync.Mutex
+
+	// idleScalableObjects are the objects with adaptivePolling which were idle in their last poll
+	idleScalableObjects     map[string]bool
+	idleScalableObjectsLock sync.Mutex
+
+	// disabledTriggers are the triggers disabled by their condition in the last poll
+	disabledTriggers     map[string]map[int]bool
+	disabledTriggersLock sync.Mutex
+
+	// fallbackDecisions are the replica counts the scale decision webhook decided on for the fallback served to the HPA
+	fallbackDecisions     map[string]int32
+	fallbackDecisionsLock sync.Mutex
 }
 
 // NewScaleHandler creates a ScaleHandler object
 func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
+		scaleClient:              scaleClient,
 		scaleLoopContexts:        &sync.Map{},
 		scaleExecutor:            executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
 		globalHTTPTimeout:        globalHTTPTimeout,
@@ -88,7 +121,13 @@ func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, recon
 		scalerCaches:             map[string]*cache.ScalersCache{},
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		metricsHistories:         map[string]*cache.MetricsHistory{},
+		metricTransforms:         map[string]*cache.MetricTransforms{},
 		secretsLister:            secretsLister,
+		sharedQueries:            newSharedQueries(),
+
+		triggersStatusUpdates:        map[string]time.Time{},
+		triggersStatusUpdateInterval: resolveTriggersStatusUpdateInterval(),
 	}
 }
 
@@ -154,6 +193,15 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
 		}
+		h.scaledObjectsMetricCache.DeleteLastKnownGoods(key)
+		h.deleteMetricsHistory(key)
+		h.deleteMetricTransforms(key)
+		h.deleteTriggersStatusUpdate(key)
+		h.deleteTriggerActivities(key)
+		h.deleteAdaptivePolling(key)
+		h.deleteDisabledTriggers(key)
+		h.setFallbackDecision(key, nil)
+		h.scaleExecutor.DeleteScalingState(key)
 		h.recorder.Event(withTriggers, corev1.EventTypeNormal, eventreason.KEDAScalersStopped, "Stopped scalers watch")
 	} else {
 		log.V(1).Info("ScalableObject was not found in controller cache", "key", key)
@@ -177,11 +225,17 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 		delay := time.Since(next)
 		metricscollector.RecordScalableObjectLatency(withTriggers.Namespace, withTriggers.Name, isScaledObject, delay)
 
-		tmr := time.NewTimer(pollingInterval)
-		next = time.Now().Add(pollingInterval)
-
+		start := time.Now()
 		h.checkScalers(ctx, scalableObject, scalingMutex)
 
+		// with adaptivePolling the interval depends on whether the object was idle in this poll
+		if interval := h.getPollingInterval(withTriggers); interval != pollingInterval {
+			logger.V(1).Info("Switching pollingInterval", "PollingInterval", interval)
+			pollingInterval = interval
+		}
+		next = start.Add(pollingInterval)
+		tmr := time.NewTimer(time.Until(next))
+
 		select {
 		case <-tmr.C:
 			tmr.Stop()
@@ -241,13 +295,15 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			log.Error(err, "error getting scaledObject", "object", scalableObject)
 			return
 		}
-		isActive, isError, metricsRecords, activeTriggers, err := h.getScaledObjectState(ctx, obj)
+		obj.ApplyRenderedSpec()
+		isActive, isError, metricsRecords, options, err := h.getScaledObjectState(ctx, obj)
 		if err != nil {
 			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
 			return
 		}
 
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers})
+		h.decideScaledObjectScale(ctx, obj, isActive, isError, metricsRecords, options)
+		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, options)
 
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
@@ -260,7 +316,8 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			return
 		}
 
-		isActive, isError, scaleTo, maxScale := h.isScaledJobActive(ctx, obj)
+		isActive, isError, scaleTo, maxScale, scalersMetrics := h.isScaledJobActive(ctx, obj)
+		isActive, scaleTo, maxScale = h.decideScaledJobScale(ctx, obj, isActive, isError, scaleTo, maxScale, scalersMetrics)
 		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, isError, scaleTo, maxScale)
 	}
 }
@@ -298,7 +355,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	if cache, ok := h.scalerCaches[key]; ok {
 		// generation was specified -> let's include it in the check as well
 		if scalableObjectGeneration != nil {
-			if cache.ScalableObjectGeneration == *scalableObjectGeneration {
+			if cache.ScalableObjectGeneration == *scalableObjectGeneration && !isRenderedSpecChanged(cache, scalableObject) {
 				h.scalerCachesLock.RUnlock()
 				return cache, nil
 			}
@@ -319,6 +376,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 				log.Error(err, "failed to get ScaledObject", "name", scalableObjectName, "namespace", scalableObjectNamespace)
 				return nil, err
 			}
+			scaledObject.ApplyRenderedSpec()
 			scalableObject = scaledObject
 		case "ScaledJob":
 			scaledJob := &kedav1alpha1.ScaledJob{}
@@ -357,29 +415,45 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 		return nil, err
 	}
 
+	triggerConditions, err := kedav1alpha1.CompileTriggerConditions(withTriggers.Spec.Triggers)
+	if err != nil {
+		log.Error(err, "error validating-compiling trigger conditions")
+		return nil, err
+	}
+
 	newCache := &cache.ScalersCache{
 		Scalers:                  scalers,
 		ScalableObjectGeneration: withTriggers.Generation,
 		Recorder:                 h.recorder,
+		SharedQueries:            h.sharedQueries,
+		TriggerConditions:        triggerConditions,
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		if obj.Spec.Advanced != nil && obj.Spec.Advanced.ScalingModifiers.Formula != "" {
-			// validate scalingModifiers struct and compile formula
-			program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(obj)
+		if obj.Spec.Advanced != nil && obj.Spec.Advanced.ScalingModifiers.HasFormula() {
+			// validate scalingModifiers struct and compile formulas
+			programs, err := kedav1alpha1.ValidateAndCompileScalingModifiers(obj)
 			if err != nil {
 				log.Error(err, "error validating-compiling scalingModifiers")
 				return nil, err
 			}
-			newCache.CompiledFormula = program
+			newCache.CompiledFormulas = programs
 		}
 		newCache.ScaledObject = obj
+		newCache.RenderedSpec = obj.Status.RenderedSpec.DeepCopy()
 	default:
 	}
 
 	h.scalerCachesLock.Lock()
 	defer h.scalerCachesLock.Unlock()
 
+	if len(newCache.CompiledFormulas) > 0 {
+		newCache.MetricsHistory = h.getMetricsHistory(key, withTriggers.GetPollingInterval())
+	}
+	if cache.HasTransforms(withTriggers.Spec.Triggers) {
+		newCache.MetricTransforms = h.getMetricTransforms(key, withTriggers.Spec.Triggers)
+	}
+
 	if oldCache, ok := h.scalerCaches[key]; ok {
 		// Scalers Close() could be impacted by timeouts, blocking the mutex
 		// until the timeout happens. Instead of locking the mutex, we take
@@ -392,6 +466,62 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	return h.scalerCaches[key], nil
 }
 
+// getMetricsHistory returns the history of trigger values for the scalableObject, it has to be called with scalerCachesLock held.
+// The history keeps enough samples to cover the longest window of the history functions at the polling interval,
+// it starts over once the polling interval changes the number of samples needed.
+func (h *scaleHandler) getMetricsHistory(key string, pollingInterval time.Duration) *cache.MetricsHistory {
+	if h.metricsHistories == nil {
+		h.metricsHistories = map[string]*cache.MetricsHistory{}
+	}
+	size := cache.MetricsHistorySize(kedav1alpha1.MaxFormulaHistoryWindow, pollingInterval)
+	history, found := h.metricsHistories[key]
+	if !found || history.Size() != size {
+		history = cache.NewMetricsHistory(size)
+		h.metricsHistories[key] = history
+	}
+	return history
+}
+
+// deleteMetricsHistory removes the history of trigger values for the scalableObject
+func (h *scaleHandler) deleteMetricsHistory(key string) {
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	delete(h.metricsHistories, key)
+}
+
+// getMetricTransforms returns the transforms of the trigger metrics for the scalableObject updated to its triggers,
+// it has to be called with scalerCachesLock held
+func (h *scaleHandler) getMetricTransforms(key string, triggers []kedav1alpha1.ScaleTriggers) *cache.MetricTransforms {
+	if h.metricTransforms == nil {
+		h.metricTransforms = map[string]*cache.MetricTransforms{}
+	}
+	transforms, found := h.metricTransforms[key]
+	if !found {
+		transforms = cache.NewMetricTransforms(triggers)
+		h.metricTransforms[key] = transforms
+		return transforms
+	}
+	transforms.SetTriggers(triggers)
+	return transforms
+}
+
+// deleteMetricTransforms removes the transforms of the trigger metrics for the scalableObject
+func (h *scaleHandler) deleteMetricTransforms(key string) {
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	delete(h.metricTransforms, key)
+}
+
+// isRenderedSpecChanged returns true if the ScaledObject was rendered again from its template since the cache was built,
+// which doesn't change the generation of the ScaledObject
+func isRenderedSpecChanged(cache *cache.ScalersCache, scalableObject interface{}) bool {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok || scaledObject.Spec.TemplateRef == nil {
+		return false
+	}
+	return !equality.Semantic.DeepEqual(cache.RenderedSpec, scaledObject.Status.RenderedSpec)
+}
+
 // ClearScalersCache invalidates chache for the input scalableObject
 func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject interface{}) error {
 	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
@@ -463,6 +593,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		triggerName       string
 		triggerIndex      int
 		metricSpec        v2.MetricSpec
+		isActive          bool
 		err               error
 	}
 	allScalers, scalerConfigs := cache.GetScalers()
@@ -508,24 +639,39 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 						logger.Error(err, "error pairing triggers & metrics for compositeScaler")
 					}
 					var metrics []external_metrics.ExternalMetricValue
+					var isActive bool
+
+					// a trigger disabled by its condition in the last poll reports zero
+					triggerDisabled := h.isTriggerDisabled(scaledObjectIdentifier, triggerIndex)
+					if triggerDisabled {
+						logger.V(1).Info("Trigger is disabled by its condition", "trigger", triggerName, "metricName", metricName)
+						metrics = []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, 0)}
+					}
 
-					// if cache is defined for this scaler/metric, let's try to hit it first
+					// if cache is defined for this scaler/metric, let's try to hit it first,
+					// the transformed metrics of the last poll are served without querying the scaler again
 					metricsFoundInCache := false
-					if scalerConfig.TriggerUseCachedMetrics {
+					if !triggerDisabled && (scalerConfig.TriggerUseCachedMetrics || cache.MetricTransforms.HasTransform(triggerIndex)) {
 						var metricsRecord metricscache.MetricsRecord
 						if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadRecord(scaledObjectIdentifier, metricName); metricsFoundInCache {
 							logger.V(1).Info("Reading metrics from cache", "scaler", triggerName, "metricName", metricName, "metricsRecord", metricsRecord)
 							metrics = metricsRecord.Metric
+							isActive = metricsRecord.IsActive
 							err = metricsRecord.ScalerError
 						}
 					}
 
-					if !metricsFoundInCache {
+					if !triggerDisabled && !metricsFoundInCache {
 						var latency time.Duration
-						metrics, _, latency, err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
+						metrics, isActive, latency, err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
 						if latency != -1 {
 							metricscollector.RecordScalerLatency(scaledObjectNamespace, scaledObject.Name, triggerName, triggerIndex, metricName, true, latency)
 						}
+						if err == nil {
+							// the smoothing of the transform advances in the scale loop only, until the first poll
+							// the value is transformed as if it was the first one
+							metrics = cache.MetricTransforms.Last(triggerIndex, metricName, metrics)
+						}
 						logger.V(1).Info("Getting metrics from trigger", "trigger", triggerName, "metricName", metricName, "metrics", metrics, "scalerError", err)
 					}
 					result.metricName = metricName
@@ -533,6 +679,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 					result.triggerIndex = triggerIndex
 					result.metricSpec = spec
 					result.metrics = metrics
+					result.isActive = isActive
 					result.err = err
 					results <- result
 					wg.Done()
@@ -543,12 +690,18 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 
 	wg.Wait()
 	close(matchingMetricsChan)
+	activeByTrigger, failedByTrigger := map[string]bool{}, map[string]bool{}
+	fallbackScaledObject := h.getFallbackScaledObject(scaledObject)
 	for result := range matchingMetricsChan {
 		for key, value := range result.metricTriggerPair {
 			metricTriggerPairList[key] = value
 		}
+		activeByTrigger[result.triggerName] = activeByTrigger[result.triggerName] || (result.isActive && result.err == nil)
+		failedByTrigger[result.triggerName] = failedByTrigger[result.triggerName] || result.err != nil
 		// check if we need to set a fallback
-		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, h.scaleClient, result.metrics, result.err, result.metricName, scaledObject, result.metricSpec)
+		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, h.scaleClient, &h.scaledObjectsMetricCache, result.metrics, result.err, result.metricName, result.triggerIndex, fallbackScaledObject, result.metricSpec)
+		// the health of the metrics is kept in the status of the cached ScaledObject
+		scaledObject.Status = fallbackScaledObject.Status
 		if err != nil {
 			isScalerError = true
 			logger.Error(err, "error getting metric for trigger", "trigger", result.triggerName)
@@ -585,7 +738,14 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 	}
 
 	// handle scalingModifiers here and simply return the matchingMetrics
-	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, cache, logger)
+	formulaContext := h.getFormulaContext(ctx, scaledObject, cache.CompiledFormulas, activeByTrigger, failedByTrigger, logger)
+	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, cache, formulaContext, logger)
+	if scaledObject.IsUsingModifiers() && scaledObject.Spec.Advanced.ScalingModifiers.HasFormula() {
+		// every composite metric is a separate metric of the HPA, return only the requested one
+		matchingMetrics = slices.DeleteFunc(matchingMetrics, func(metric external_metrics.ExternalMetricValue) bool {
+			return metric.MetricName != metricsName
+		})
+	}
 	return &external_metrics.ExternalMetricValueList{
 		Items: matchingMetrics,
 	}, nil
@@ -595,8 +755,10 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 // is active as the first return value,
 // the second return value indicates whether there was any error during querying scalers,
 // the third return value is a map of metrics record - a metric value for each scaler and its metric
-// the fourth return value contains error if is not able to access scalers cache
-func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, []string, error) {
+// the fourth return value are the options of the scale executor: the names of the active triggers, the indexes of the failed
+// triggers and the replica count the HPA would compute from the metrics, which is set in dry-run and native scaling mode only
+// the fifth return value contains error if is not able to access scalers cache
+func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, *executor.ScaleExecutorOptions, error) {
 	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 
 	isScaledObjectActive := false
@@ -605,11 +767,12 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	metricTriggerPairList := make(map[string]string)
 	var matchingMetrics []external_metrics.ExternalMetricValue
 	var activeTriggers []string
+	var failedTriggers []int
 
 	cache, err := h.GetScalersCache(ctx, scaledObject)
 	metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
 	if err != nil {
-		return false, true, map[string]metricscache.MetricsRecord{}, []string{}, fmt.Errorf("error getting scalers cache %w", err)
+		return false, true, map[string]metricscache.MetricsRecord{}, &executor.ScaleExecutorOptions{ActiveTriggers: []string{}}, fmt.Errorf("error getting scalers cache %w", err)
 	}
 
 	// count the number of non-external triggers (cpu/mem) in order to check for
@@ -624,37 +787,54 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	}
 
 	// Let's collect status of all allScalers in parallel,
-	// no matter if any scaler raises error or is active
+	// no matter if any scaler raises error or is active.
+	// The triggers whose condition depends on the activity of other triggers are collected last.
 	allScalers, scalerConfigs := cache.GetScalers()
-	results := make(chan scalerState, len(allScalers))
-	wg := sync.WaitGroup{}
-	for scalerIndex := 0; scalerIndex < len(allScalers); scalerIndex++ {
-		wg.Add(1)
-		go func(scaler scalers.Scaler, index int, scalerConfig scalersconfig.ScalerConfig, results chan scalerState, wg *sync.WaitGroup) {
-			results <- h.getScalerState(ctx, scaler, index, scalerConfig, cache, logger, scaledObject)
-			wg.Done()
-		}(allScalers[scalerIndex], scalerIndex, scalerConfigs[scalerIndex], results, &wg)
-	}
-	wg.Wait()
-	close(results)
-	for result := range results {
-		if result.IsActive {
-			isScaledObjectActive = true
-			activeTriggers = append(activeTriggers, result.TriggerName)
-		}
-		if result.Err != nil {
-			isScaledObjectError = true
-		}
-		matchingMetrics = append(matchingMetrics, result.Metrics...)
-		for k, v := range result.Pairs {
-			metricTriggerPairList[k] = v
-		}
-		for k, v := range result.Records {
-			metricsRecord[k] = v
+	states := make([]scalerState, 0, len(allScalers))
+	activeByTrigger, failedByTrigger := map[string]bool{}, map[string]bool{}
+	disabledTriggers := map[int]bool{}
+	for _, phase := range triggerConditionPhases(len(allScalers), cache.TriggerConditions) {
+		results := make(chan scalerState, len(phase))
+		wg := sync.WaitGroup{}
+		for _, scalerIndex := range phase {
+			if !isTriggerEnabled(scaledObject, cache.TriggerConditions[scalerIndex], scalerConfigs[scalerIndex].TriggerName, activeByTrigger, failedByTrigger, logger) {
+				disabledTriggers[scalerIndex] = true
+				results <- getDisabledScalerState(ctx, scalerIndex, scalerConfigs[scalerIndex], cache, logger, scaledObject)
+				continue
+			}
+			wg.Add(1)
+			go func(scaler scalers.Scaler, index int, scalerConfig scalersconfig.ScalerConfig, results chan scalerState, wg *sync.WaitGroup) {
+				results <- h.getScalerState(ctx, scaler, index, scalerConfig, cache, logger, scaledObject)
+				wg.Done()
+			}(allScalers[scalerIndex], scalerIndex, scalerConfigs[scalerIndex], results, &wg)
 		}
+		wg.Wait()
+		close(results)
+		for result := range results {
+			if result.IsActive {
+				isScaledObjectActive = true
+				activeTriggers = append(activeTriggers, result.TriggerName)
+			}
+			if result.Err != nil {
+				isScaledObjectError = true
+				failedTriggers = append(failedTriggers, result.TriggerIndex)
+			}
+			activeByTrigger[result.TriggerName] = result.IsActive
+			failedByTrigger[result.TriggerName] = result.Err != nil
+			matchingMetrics = append(matchingMetrics, result.Metrics...)
+			for k, v := range result.Pairs {
+				metricTriggerPairList[k] = v
+			}
+			for k, v := range result.Records {
+				metricsRecord[k] = v
+			}
 
-		metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, result.Err)
+			metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, result.Err)
+			states = append(states, result)
+		}
 	}
+	h.setDisabledTriggers(scaledObject.GenerateIdentifier(), disabledTriggers)
+	h.updateTriggersStatus(ctx, scaledObject, states, logger)
 
 	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
 	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
@@ -667,7 +847,11 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	}
 
 	// apply scaling modifiers
-	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, nil, cache, logger)
+	formulaContext := h.getFormulaContext(ctx, scaledObject, cache.CompiledFormulas, activeByTrigger, failedByTrigger, logger)
+	if scaledObject.IsUsingModifiers() && scaledObject.Spec.Advanced.ScalingModifiers.HasFormula() {
+		modifiers.RecordMetricsHistory(cache, matchingMetrics, metricTriggerPairList, time.Now())
+	}
+	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, nil, cache, formulaContext, logger)
 
 	// when we are using formula, we need to reevaluate if it's active here
 	if scaledObject.IsUsingModifiers() {
@@ -675,17 +859,21 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		isScaledObjectActive = false
 		activeTriggers = []string{}
 		if !isScaledObjectError {
-			activationValue := float64(0)
-			if scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget != "" {
-				targetValue, err := strconv.ParseFloat(scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget, 64)
-				if err != nil {
-					return false, true, metricsRecord, []string{}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+			// every composite metric is compared with its own activation target
+			activationValues := map[string]float64{}
+			for _, composite := range scaledObject.Spec.Advanced.ScalingModifiers.GetCompositeMetrics() {
+				if composite.ActivationTarget != "" {
+					targetValue, err := strconv.ParseFloat(composite.ActivationTarget, 64)
+					if err != nil {
+						return false, true, metricsRecord, &executor.ScaleExecutorOptions{ActiveTriggers: []string{}, FailedTriggers: failedTriggers}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+					}
+					activationValues[composite.MetricName()] = targetValue
 				}
-				activationValue = targetValue
 			}
 
 			for _, metric := range matchingMetrics {
 				value := metric.Value.AsApproximateFloat64()
+				activationValue := activationValues[metric.MetricName]
 				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value)
 				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value > activationValue)
 				if !isScaledObjectActive {
@@ -704,7 +892,53 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	if len(scaledObject.Spec.Triggers) <= cpuMemCount && !isScaledObjectError {
 		isScaledObjectActive = true
 	}
-	return isScaledObjectActive, isScaledObjectError, metricsRecord, activeTriggers, err
+
+	triggerValues := map[int]float64{}
+	for _, state := range states {
+		if state.Value != nil {
+			triggerValues[state.TriggerIndex] = state.Value.AsApproximateFloat64()
+		}
+	}
+	h.updateAdaptivePolling(scaledObject.GenerateIdentifier(), scaledObject.Spec.AdaptivePolling, scaledObject.Spec.Triggers, isScaledObjectActive, triggerValues,
+		func() bool { return h.isScaledObjectScaledIn(ctx, scaledObject) })
+
+	var desiredReplicas *int32
+	if (scaledObject.IsDryRun() || scaledObject.IsNativeScaling()) && !scaledObject.NeedToBePausedByAnnotation() {
+		// there is no HPA in dry-run and native scaling mode, so compute the replicas it would have scaled to
+		desiredReplicas = h.getDesiredReplicas(ctx, scaledObject, states, cache, formulaContext, logger)
+	}
+	options := &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers, FailedTriggers: failedTriggers, DesiredReplicas: desiredReplicas}
+	return isScaledObjectActive, isScaledObjectError, metricsRecord, options, err
+}
+
+// getFormulaContext returns the runtime context of the ScaledObject exposed to scalingModifiers formula,
+// the current replica count is resolved only if the formula references it
+func (h *scaleHandler) getFormulaContext(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, compiledFormulas map[string]*vm.Program,
+	activeByTrigger, failedByTrigger map[string]bool, logger logr.Logger) kedav1alpha1.FormulaContext {
+	if !scaledObject.IsUsingModifiers() {
+		return kedav1alpha1.FormulaContext{}
+	}
+
+	var currentReplicas int32
+	for _, program := range compiledFormulas {
+		if kedav1alpha1.FormulaReferencesVariable(program, kedav1alpha1.FormulaVariableCurrentReplicas) {
+			var err error
+			currentReplicas, err = resolver.GetCurrentReplicas(ctx, h.client, h.scaleClient, scaledObject)
+			if err != nil {
+				logger.Error(err, "error getting current replicas for scalingModifiers formula")
+			}
+			break
+		}
+	}
+
+	formulaContext := kedav1alpha1.NewFormulaContext(scaledObject, currentReplicas, time.Now())
+	for trigger, active := range activeByTrigger {
+		formulaContext.Active[trigger] = active
+	}
+	for trigger, failed := range failedByTrigger {
+		formulaContext.Failed[trigger] = failed
+	}
+	return formulaContext
 }
 
 // scalerState is used as return
@@ -713,27 +947,39 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 // info for calculating the ScaledObjectState
 type scalerState struct {
 	// IsActive will be overrided by formula calculation
-	IsActive    bool
-	TriggerName string
-	Metrics     []external_metrics.ExternalMetricValue
-	Pairs       map[string]string
-	Records     map[string]metricscache.MetricsRecord
-	Err         error
+	IsActive     bool
+	TriggerName  string
+	TriggerType  string
+	TriggerIndex int
+	Metrics      []external_metrics.ExternalMetricValue
+	Pairs        map[string]string
+	Records      map[string]metricscache.MetricsRecord
+	// ExternalMetrics are the external metrics of the trigger with their values, the desired replicas are computed from
+	ExternalMetrics []scalerMetric
+	Err             error
+	// Disabled is set if the condition of the trigger evaluated to false, the scaler isn't queried then
+	Disabled bool
+	// MetricName, Value and Target of the first external metric of the trigger, reported in the triggers status
+	MetricName string
+	Value      *resource.Quantity
+	Target     *v2.MetricTarget
 }
 
 // getScalerState returns getStateScalerResult with the state
 // for an specific scaler. The state contains if it's active or
 // with erros, but also the records for the cache and he metrics
 // for the custom formulas
-func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
+func (h *scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
 	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
 	result := scalerState{
-		IsActive:    false,
-		Err:         nil,
-		TriggerName: "",
-		Metrics:     []external_metrics.ExternalMetricValue{},
-		Pairs:       map[string]string{},
-		Records:     map[string]metricscache.MetricsRecord{},
+		IsActive:     false,
+		Err:          nil,
+		TriggerName:  "",
+		TriggerType:  scalerConfig.TriggerType,
+		TriggerIndex: triggerIndex,
+		Metrics:      []external_metrics.ExternalMetricValue{},
+		Pairs:        map[string]string{},
+		Records:      map[string]metricscache.MetricsRecord{},
 	}
 
 	result.TriggerName = strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
@@ -761,10 +1007,28 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		if latency != -1 {
 			metricscollector.RecordScalerLatency(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, latency)
 		}
+		if err == nil {
+			// every poll advances the smoothing of the transform once, the activity is kept from the original value
+			metrics = cache.MetricTransforms.Apply(triggerIndex, metricName, metrics)
+		}
 		result.Metrics = append(result.Metrics, metrics...)
+		result.ExternalMetrics = append(result.ExternalMetrics, scalerMetric{MetricName: metricName, Spec: spec, Metrics: metrics, Err: err})
+		if result.MetricName == "" {
+			result.MetricName = metricName
+			result.Target = spec.External.Target.DeepCopy()
+			if err == nil {
+				value := resource.NewMilliQuantity(0, resource.DecimalSI)
+				for _, metric := range metrics {
+					value.Add(metric.Value)
+				}
+				result.Value = value
+			}
+		}
 		logger.V(1).Info("Getting metrics and activity from scaler", "scaler", result.TriggerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
 
-		if scalerConfig.TriggerUseCachedMetrics {
+		// the metrics of transformed triggers are kept as well, so reading the metrics serves the value
+		// the smoothing advanced to in this poll instead of querying the scaler again
+		if scalerConfig.TriggerUseCachedMetrics || cache.MetricTransforms.HasTransform(triggerIndex) {
 			result.Records[metricName] = metricscache.MetricsRecord{
 				IsActive:    isMetricActive,
 				Metric:      metrics,
@@ -782,6 +1046,8 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 				cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
 			}
 		} else {
+			isMetricActive = h.applyActivationHysteresis(scaledObject.GenerateIdentifier(), scaledObject.Spec.Triggers, scaledObject.Status.Triggers,
+				triggerIndex, metricName, isMetricActive, metrics)
 			result.IsActive = isMetricActive
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
@@ -826,64 +1092,79 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 	var isError bool
 	var scalersMetrics []scaledjob.ScalerMetrics
 	scalers, scalerConfigs := cache.GetScalers()
-	for scalerIndex, scaler := range scalers {
-		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
-		if scalerConfigs[scalerIndex].TriggerName != "" {
-			scalerName = scalerConfigs[scalerIndex].TriggerName
-		}
-		isActive := false
-		scalerType := fmt.Sprintf("%T:", scaler)
-
-		scalerLogger := log.WithValues("scaledJob.Name", scaledJob.Name, "Scaler", scalerType)
-
-		metricSpecs := scaler.GetMetricSpecForScaling(ctx)
-
-		for _, spec := range metricSpecs {
-			// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
-			// or skip cpu/memory resource scaler
-			if len(metricSpecs) < 1 || spec.External == nil {
-				continue
+	// the triggers whose condition depends on the activity of other triggers are queried last
+	activeByTrigger, failedByTrigger := map[string]bool{}, map[string]bool{}
+	for _, phase := range triggerConditionPhases(len(scalers), cache.TriggerConditions) {
+		for _, scalerIndex := range phase {
+			scaler := scalers[scalerIndex]
+			scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
+			if scalerConfigs[scalerIndex].TriggerName != "" {
+				scalerName = scalerConfigs[scalerIndex].TriggerName
 			}
-			metricName := spec.External.Metric.Name
-			metrics, isTriggerActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
-			metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
-			if latency != -1 {
-				metricscollector.RecordScalerLatency(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, latency)
-			}
-			if err != nil {
-				scalerLogger.Error(err, "Error getting scaler metrics and activity, but continue")
-				cache.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-				isError = true
+			if !isTriggerEnabled(scaledJob, cache.TriggerConditions[scalerIndex], scalerName, activeByTrigger, failedByTrigger, logger) {
+				// a disabled trigger is left out of the metrics of the ScaledJob
 				continue
 			}
-			if isTriggerActive {
-				isActive = true
-			}
-			queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, metricSpecs, scaledJob.MaxReplicaCount())
+			isActive := false
+			scalerType := fmt.Sprintf("%T:", scaler)
 
-			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+			scalerLogger := log.WithValues("scaledJob.Name", scaledJob.Name, "Scaler", scalerType)
 
-			scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
-				QueueLength: queueLength,
-				MaxValue:    maxValue,
-				IsActive:    isActive,
-			})
-			for _, metric := range metrics {
-				metricValue := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metric.MetricName, false, metricValue)
-			}
+			metricSpecs := scaler.GetMetricSpecForScaling(ctx)
 
-			if isTriggerActive {
-				if spec.External != nil {
-					logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", metricName)
+			for _, spec := range metricSpecs {
+				// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
+				// or skip cpu/memory resource scaler
+				if len(metricSpecs) < 1 || spec.External == nil {
+					continue
 				}
-				if spec.Resource != nil {
-					logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", spec.Resource.Name)
+				metricName := spec.External.Metric.Name
+				metrics, isTriggerActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
+				metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
+				if latency != -1 {
+					metricscollector.RecordScalerLatency(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, latency)
+				}
+				if err != nil {
+					scalerLogger.Error(err, "Error getting scaler metrics and activity, but continue")
+					cache.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+					isError = true
+					failedByTrigger[scalerName] = true
+					continue
+				}
+				metrics = cache.MetricTransforms.Apply(scalerIndex, metricName, metrics)
+				isTriggerActive = h.applyActivationHysteresis(scaledJob.GenerateIdentifier(), scaledJob.Spec.Triggers, nil,
+					scalerIndex, metricName, isTriggerActive, metrics)
+				if isTriggerActive {
+					isActive = true
+				}
+				queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, metricSpecs, scaledJob.MaxReplicaCount())
+
+				scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+
+				scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
+					QueueLength:  queueLength,
+					MaxValue:     maxValue,
+					IsActive:     isActive,
+					TriggerIndex: scalerIndex,
+				})
+				for _, metric := range metrics {
+					metricValue := metric.Value.AsApproximateFloat64()
+					metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metric.MetricName, false, metricValue)
+				}
+
+				if isTriggerActive {
+					if spec.External != nil {
+						logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", metricName)
+					}
+					if spec.Resource != nil {
+						logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", spec.Resource.Name)
+					}
 				}
-			}
 
-			metricscollector.RecordScalerError(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, err)
-			metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
+				metricscollector.RecordScalerError(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, err)
+				metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
+			}
+			activeByTrigger[scalerName] = isActive
 		}
 	}
 	return scalersMetrics, isError
@@ -891,8 +1172,9 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 
 // isScaledJobActive returns whether the input ScaledJob:
 // is active as the first return value,
-// the second and the third return values indicate queueLength and maxValue for scale
-func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64) {
+// the second and the third return values indicate queueLength and maxValue for scale,
+// the last return value are the metrics of the scalers
+func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64, []scaledjob.ScalerMetrics) {
 	logger := logf.Log.WithName("scalemetrics")
 
 	scalersMetrics, isError := h.getScaledJobMetrics(ctx, scaledJob)
@@ -900,7 +1182,14 @@ func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1a
 		scaledjob.IsScaledJobActive(scalersMetrics, scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
 
 	logger.V(1).WithValues("scaledJob.Name", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxFloatValue, "MultipleScalersCalculation", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
-	return isActive, isError, queueLength, maxValue
+
+	triggerValues := map[int]float64{}
+	for _, scalerMetrics := range scalersMetrics {
+		triggerValues[scalerMetrics.TriggerIndex] = max(triggerValues[scalerMetrics.TriggerIndex], scalerMetrics.QueueLength)
+	}
+	h.updateAdaptivePolling(scaledJob.GenerateIdentifier(), scaledJob.Spec.AdaptivePolling, scaledJob.Spec.Triggers, isActive, triggerValues,
+		func() bool { return h.isScaledJobScaledIn(ctx, scaledJob) })
+	return isActive, isError, queueLength, maxValue, scalersMetrics
 }
 
 // getTrueMetricArray is a help function made for composite scaler to determine
@@ -914,7 +1203,7 @@ func (h *scaleHandler) getTrueMetricArray(ctx context.Context, metricName string
 
 	// bug fix for the invalid cache (not loaded properly) and needs to be fetched again
 	// Tracking issue: https://github.com/kedacore/keda/issues/4955
-	if so != nil && so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Target != "" {
+	if so != nil && so.Spec.Advanced != nil && (so.Spec.Advanced.ScalingModifiers.Target != "" || len(so.Spec.Advanced.ScalingModifiers.CompositeMetrics) > 0) {
 		if len(so.Status.ExternalMetricNames) == 0 {
 			scaledObject := &kedav1alpha1.ScaledObject{}
 			err := h.client.Get(ctx, types.NamespacedName{Name: so.Name, Namespace: so.Namespace}, scaledObject)
@@ -25,7 +25,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/expr-lang/expr"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
@@ -122,6 +121,7 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
@@ -211,6 +211,7 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
@@ -229,6 +230,98 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	scalerCache.Close(context.Background())
 }
 
+func TestGetScaledObjectMetrics_Transformed(t *testing.T) {
+	scaledObjectName := "testName3"
+	scaledObjectNamespace := "testNamespace3"
+	metricName := "test-metric-name"
+	longPollingInterval := int32(300)
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(10, metricName)}
+	metricValue := scalers.GenerateMetricInMili(metricName, float64(10))
+
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scalerConfig := scalersconfig.ScalerConfig{TriggerUseCachedMetrics: false}
+	factory := func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+		return scaler, &scalerConfig, nil
+	}
+
+	triggers := []kedav1alpha1.ScaleTriggers{{Type: "kafka", Transform: &kedav1alpha1.MetricTransform{Scale: "2"}}}
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scaledObjectName,
+			Namespace: scaledObjectNamespace,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			PollingInterval: &longPollingInterval,
+			Triggers:        triggers,
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalerConfig,
+			Factory:      factory,
+		}},
+		Recorder:         recorder,
+		MetricTransforms: cache.NewMetricTransforms(triggers),
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	// before the first poll the scaler is queried and its value is transformed
+	expectNoStatusPatch(ctrl)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
+	metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, metricName)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(20), metrics.Items[0].Value.AsApproximateFloat64())
+
+	expectTriggersStatusUpdate(ctrl, mockClient)
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, float64(15))}, true, nil)
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+
+	// the transformed value of the poll is served without querying the scaler again
+	expectNoStatusPatch(ctrl)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
+	metrics, err = sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, metricName)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(30), metrics.Items[0].Value.AsApproximateFloat64())
+
+	scaler.EXPECT().Close(gomock.Any())
+	scalerCache.Close(context.Background())
+}
+
 // TestGetScaledObjectMetrics_InParallel executes
 // a request to multiple scalers with a delay.
 // The sum off all the scalers is more than the timeout
@@ -333,6 +426,7 @@ func TestGetScaledObjectMetrics_InParallel(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	for i := 0; i < len(metricNames); i++ {
@@ -420,13 +514,14 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, options, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
 	assert.Equal(t, true, isError)
-	assert.Empty(t, activeTriggers)
+	assert.Empty(t, options.ActiveTriggers)
 }
 
 func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
@@ -538,12 +633,12 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, options, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
 	assert.Equal(t, true, isError)
-	assert.Empty(t, activeTriggers)
+	assert.Empty(t, options.ActiveTriggers)
 
 	failureEvent := <-recorder.Events
 	assert.Contains(t, failureEvent, "KEDAScalerFailed")
@@ -617,13 +712,14 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, options, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, true, isError)
-	assert.Equal(t, []string{"*mock_scalers.MockScaler"}, activeTriggers)
+	assert.Equal(t, []string{"*mock_scalers.MockScaler"}, options.ActiveTriggers)
 }
 
 func TestIsScaledJobActive(t *testing.T) {
@@ -655,7 +751,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(20), queueLength)
@@ -713,7 +809,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		}
 		fmt.Printf("index: %d", index)
 		// nosemgrep: context-todo
-		isActive, isError, queueLength, maxValue = sh.isScaledJobActive(context.TODO(), scaledJob)
+		isActive, isError, queueLength, maxValue, _ = sh.isScaledJobActive(context.TODO(), scaledJob)
 		//	assert.Equal(t, 5, index)
 		assert.Equal(t, scalerTestData.ResultIsActive, isActive)
 		assert.Equal(t, scalerTestData.ResultIsError, isError)
@@ -723,6 +819,46 @@ func TestIsScaledJobActive(t *testing.T) {
 	}
 }
 
+func TestIsScaledJobActiveWithHysteresis(t *testing.T) {
+	metricName := "s0-queueLength"
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	scaledJob := createScaledJob(0, 100, "")
+	scaledJob.Spec.Triggers = []kedav1alpha1.ScaleTriggers{
+		{Type: "fake", Hysteresis: &kedav1alpha1.TriggerHysteresis{DeactivationThreshold: "2"}},
+	}
+
+	sh := scaleHandler{
+		scaleLoopContexts:        &sync.Map{},
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+	poll := func(queueLength int64, isActive bool) bool {
+		scaler := createScaler(ctrl, queueLength, 2, isActive, metricName)
+		scalerCache := cache.ScalersCache{
+			Scalers: []cache.ScalerBuilder{{
+				Scaler: scaler,
+				Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+					return scaler, &scalersconfig.ScalerConfig{}, nil
+				},
+			}},
+			Recorder: recorder,
+		}
+		defer scalerCache.Close(context.Background())
+		sh.scalerCaches = map[string]*cache.ScalersCache{scaledJob.GenerateIdentifier(): &scalerCache}
+
+		isActive, _, _, _, _ = sh.isScaledJobActive(context.Background(), scaledJob)
+		return isActive
+	}
+
+	assert.True(t, poll(20, true))
+	// the trigger stays active while the queue is above the deactivation threshold
+	assert.True(t, poll(4, false))
+	assert.False(t, poll(1, false))
+}
+
 func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T) {
 	metricName := "s0-queueLength"
 	ctrl := gomock.NewController(t)
@@ -755,7 +891,7 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	}
 
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(0), queueLength)
@@ -957,7 +1093,7 @@ func TestScalingModifiersFormula(t *testing.T) {
 	}
 
 	// formula is compiled and cached
-	compiledFormula, err := expr.Compile(scaledObject.Spec.Advanced.ScalingModifiers.Formula)
+	compiledFormulas, err := kedav1alpha1.ValidateAndCompileScalingModifiers(&scaledObject)
 	assert.Equal(t, err, nil)
 
 	scalerCache := cache.ScalersCache{
@@ -973,8 +1109,8 @@ func TestScalingModifiersFormula(t *testing.T) {
 				Factory:      factory2,
 			},
 		},
-		Recorder:        recorder,
-		CompiledFormula: compiledFormula,
+		Recorder:         recorder,
+		CompiledFormulas: compiledFormulas,
 	}
 
 	caches := map[string]*cache.ScalersCache{}
@@ -990,6 +1126,7 @@ func TestScalingModifiersFormula(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
+	expectTriggersStatusUpdate(ctrl, mockClient)
 
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler1.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs1)
@@ -1029,3 +1166,13 @@ func expectNoStatusPatch(ctrl *gomock.Controller) {
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 }
+
+// expectTriggersStatusUpdate allows getScaledObjectState to resolve the current replicas and to write the triggers status
+func expectTriggersStatusUpdate(ctrl *gomock.Controller, mockClient *mock_client.MockClient) {
+	replicas := int32(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockClient.EXPECT().Status().Return(statusWriter).AnyTimes()
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{})).
+		SetArg(2, appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}).AnyTimes()
+}
@@ -45,9 +45,10 @@ func CalculateQueueLengthAndMaxValue(metrics []external_metrics.ExternalMetricVa
 }
 
 type ScalerMetrics struct {
-	QueueLength float64
-	MaxValue    float64
-	IsActive    bool
+	QueueLength  float64
+	MaxValue     float64
+	IsActive     bool
+	TriggerIndex int
 }
 
 // IsScaledJobActive returns whether the input ScaledJob is active and queueLength and maxValue for scale
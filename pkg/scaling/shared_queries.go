@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"time"
+
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// sharedQueryTTLEnvVar overrides how long the results of the shared scaler queries are reused, 0 disables the sharing
+	sharedQueryTTLEnvVar = "KEDA_SHARED_QUERY_TTL"
+
+	defaultSharedQueryTTL = 5 * time.Second
+)
+
+// newSharedQueries returns the shared query layer of the scalers, or nil if the sharing is disabled
+func newSharedQueries() *cache.SharedQueries {
+	ttl, err := kedautil.ResolveOsEnvDuration(sharedQueryTTLEnvVar)
+	if err != nil {
+		log.Error(err, "invalid "+sharedQueryTTLEnvVar+", using the default", "default", defaultSharedQueryTTL)
+		return cache.NewSharedQueries(defaultSharedQueryTTL)
+	}
+	switch {
+	case ttl == nil || *ttl < 0:
+		return cache.NewSharedQueries(defaultSharedQueryTTL)
+	case *ttl == 0:
+		return nil
+	default:
+		return cache.NewSharedQueries(*ttl)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+)
+
+// triggerConditionPhases returns the indexes of the triggers in the order their conditions can be evaluated:
+// the triggers whose condition doesn't depend on the activity of other triggers first, the others afterwards
+func triggerConditionPhases(count int, conditions map[int]*kedav1alpha1.CompiledTriggerCondition) [][]int {
+	independent := make([]int, 0, count)
+	var dependent []int
+	for index := 0; index < count; index++ {
+		if condition := conditions[index]; condition != nil && condition.DependsOnTriggers {
+			dependent = append(dependent, index)
+		} else {
+			independent = append(independent, index)
+		}
+	}
+	if len(dependent) == 0 {
+		return [][]int{independent}
+	}
+	return [][]int{independent, dependent}
+}
+
+// isTriggerEnabled evaluates the condition of the trigger with the activity of the triggers queried so far,
+// a trigger without condition is enabled and so is a trigger whose condition fails to evaluate
+func isTriggerEnabled(object metav1.Object, condition *kedav1alpha1.CompiledTriggerCondition, triggerName string, activeByTrigger, failedByTrigger map[string]bool, logger logr.Logger) bool {
+	if condition == nil {
+		return true
+	}
+	enabled, err := condition.Evaluate(kedav1alpha1.TriggerConditionContext{
+		Annotations: object.GetAnnotations(),
+		Labels:      object.GetLabels(),
+		Active:      activeByTrigger,
+		Failed:      failedByTrigger,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		logger.Error(err, "error evaluating trigger condition, the trigger is kept enabled", "trigger", triggerName)
+		return true
+	}
+	if !enabled {
+		logger.V(1).Info("Trigger is disabled by its condition", "trigger", triggerName)
+	}
+	return enabled
+}
+
+// getDisabledScalerState returns the state of a trigger disabled by its condition, without querying the scaler:
+// the trigger is inactive and its metrics report zero, so the HPA metric spec is kept unchanged
+func getDisabledScalerState(ctx context.Context, triggerIndex int, scalerConfig scalersconfig.ScalerConfig, scalersCache *cache.ScalersCache,
+	logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
+	result := scalerState{
+		TriggerName:  scalerConfig.TriggerName,
+		TriggerType:  scalerConfig.TriggerType,
+		TriggerIndex: triggerIndex,
+		Metrics:      []external_metrics.ExternalMetricValue{},
+		Pairs:        map[string]string{},
+		Records:      map[string]metricscache.MetricsRecord{},
+		Disabled:     true,
+	}
+	allScalers, _ := scalersCache.GetScalers()
+	if result.TriggerName == "" && triggerIndex < len(allScalers) {
+		result.TriggerName = strings.Replace(fmt.Sprintf("%T", allScalers[triggerIndex]), "*scalers.", "", 1)
+	}
+
+	metricSpecs, err := scalersCache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
+	if err != nil {
+		logger.V(1).Info("Error getting metric spec for the disabled trigger", "trigger", result.TriggerName, "error", err)
+	}
+	for _, spec := range metricSpecs {
+		if spec.External == nil {
+			continue
+		}
+		metricName := spec.External.Metric.Name
+		metric := scalers.GenerateMetricInMili(metricName, 0)
+		result.Metrics = append(result.Metrics, metric)
+		result.ExternalMetrics = append(result.ExternalMetrics, scalerMetric{MetricName: metricName, Spec: spec, Metrics: []external_metrics.ExternalMetricValue{metric}})
+		if result.MetricName == "" {
+			result.MetricName = metricName
+			result.Target = spec.External.Target.DeepCopy()
+		}
+		metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, false)
+
+		pairs, err := modifiers.GetPairTriggerAndMetric(scaledObject, metricName, scalerConfig.TriggerName)
+		if err != nil {
+			logger.Error(err, "error pairing triggers & metrics for compositeScaler")
+		}
+		for k, v := range pairs {
+			result.Pairs[k] = v
+		}
+	}
+	return result
+}
+
+// setDisabledTriggers keeps the triggers of the scalableObject disabled in the last poll,
+// the metrics served to the HPA follow the conditions evaluated by the scale loop
+func (h *scaleHandler) setDisabledTriggers(key string, disabled map[int]bool) {
+	h.disabledTriggersLock.Lock()
+	defer h.disabledTriggersLock.Unlock()
+	if h.disabledTriggers == nil {
+		h.disabledTriggers = map[string]map[int]bool{}
+	}
+	if len(disabled) == 0 {
+		delete(h.disabledTriggers, key)
+		return
+	}
+	h.disabledTriggers[key] = disabled
+}
+
+// isTriggerDisabled returns whether the trigger of the scalableObject was disabled in the last poll
+func (h *scaleHandler) isTriggerDisabled(key string, triggerIndex int) bool {
+	h.disabledTriggersLock.Lock()
+	defer h.disabledTriggersLock.Unlock()
+	return h.disabledTriggers[key][triggerIndex]
+}
+
+// deleteDisabledTriggers removes the triggers of the scalableObject disabled in the last poll
+func (h *scaleHandler) deleteDisabledTriggers(key string) {
+	h.disabledTriggersLock.Lock()
+	defer h.disabledTriggersLock.Unlock()
+	delete(h.disabledTriggers, key)
+}
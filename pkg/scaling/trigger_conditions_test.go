@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestTriggerConditionPhases(t *testing.T) {
+	conditions := map[int]*kedav1alpha1.CompiledTriggerCondition{
+		1: {DependsOnTriggers: true},
+		2: {},
+	}
+	assert.Equal(t, [][]int{{0, 2}, {1}}, triggerConditionPhases(3, conditions))
+	assert.Equal(t, [][]int{{0, 1}}, triggerConditionPhases(2, nil))
+}
+
+func TestGetScaledJobMetricsWithTriggerConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		condition       string
+		queueActive     bool
+		expectedBacklog bool
+	}{
+		{"annotation set", map[string]string{"feature": "on"}, `annotations["feature"] == "on"`, true, true},
+		{"annotation not set", nil, `annotations["feature"] == "on"`, true, false},
+		{"other trigger active", nil, `active.queue`, true, true},
+		{"other trigger inactive", nil, `active.queue`, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			recorder := record.NewFakeRecorder(1)
+
+			scaledJob := createScaledJob(0, 100, "")
+			scaledJob.Annotations = test.annotations
+			scaledJob.Spec.Triggers = []kedav1alpha1.ScaleTriggers{
+				{Type: "prometheus", Name: "backlog", Condition: &kedav1alpha1.TriggerCondition{Expression: test.condition}},
+				{Type: "rabbitmq", Name: "queue"},
+			}
+			conditions, err := kedav1alpha1.CompileTriggerConditions(scaledJob.Spec.Triggers)
+			require.NoError(t, err)
+
+			// a disabled trigger isn't queried
+			var backlogScaler *mock_scalers.MockScaler
+			if test.expectedBacklog {
+				backlogScaler = createScaler(ctrl, 10, 1, true, "s0-backlog")
+			} else {
+				backlogScaler = mock_scalers.NewMockScaler(ctrl)
+				backlogScaler.EXPECT().Close(gomock.Any())
+			}
+			scalerCache := cache.ScalersCache{
+				Scalers: []cache.ScalerBuilder{
+					{Scaler: backlogScaler, ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "backlog", TriggerIndex: 0}},
+					{Scaler: createScaler(ctrl, 5, 1, test.queueActive, "s1-queue"), ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "queue", TriggerIndex: 1}},
+				},
+				Recorder:          recorder,
+				TriggerConditions: conditions,
+			}
+
+			sh := scaleHandler{
+				scaleLoopContexts: &sync.Map{},
+				recorder:          recorder,
+				scalerCaches:      map[string]*cache.ScalersCache{scaledJob.GenerateIdentifier(): &scalerCache},
+				scalerCachesLock:  &sync.RWMutex{},
+			}
+
+			scalersMetrics, isError := sh.getScaledJobMetrics(context.Background(), scaledJob)
+			assert.False(t, isError)
+			triggerIndexes := []int{}
+			for _, metrics := range scalersMetrics {
+				triggerIndexes = append(triggerIndexes, metrics.TriggerIndex)
+			}
+			if test.expectedBacklog {
+				assert.ElementsMatch(t, []int{0, 1}, triggerIndexes)
+			} else {
+				assert.Equal(t, []int{1}, triggerIndexes)
+			}
+			scalerCache.Close(context.Background())
+		})
+	}
+}
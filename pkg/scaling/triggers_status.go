@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// triggersStatusUpdateIntervalEnvVar overrides how often the triggers status of every ScaledObject is written
+	// when only the trigger values change. The interval applies to each ScaledObject on its own, the operator
+	// writes up to one status per ScaledObject within the interval.
+	triggersStatusUpdateIntervalEnvVar = "KEDA_TRIGGERS_STATUS_UPDATE_INTERVAL_PER_SCALEDOBJECT"
+
+	defaultTriggersStatusUpdateInterval = 30 * time.Second
+
+	// maxTriggerStatusErrorLength limits the size of the errors stored in the status
+	maxTriggerStatusErrorLength = 512
+)
+
+// resolveTriggersStatusUpdateInterval returns the minimal interval between two writes of the triggers status of a ScaledObject
+func resolveTriggersStatusUpdateInterval() time.Duration {
+	interval, err := kedautil.ResolveOsEnvDuration(triggersStatusUpdateIntervalEnvVar)
+	if err != nil {
+		log.Error(err, "invalid "+triggersStatusUpdateIntervalEnvVar+", using the default", "default", defaultTriggersStatusUpdateInterval)
+		return defaultTriggersStatusUpdateInterval
+	}
+	if interval == nil || *interval <= 0 {
+		return defaultTriggersStatusUpdateInterval
+	}
+	return *interval
+}
+
+// updateTriggersStatus writes the last observed state of the triggers into the status of the ScaledObject.
+// The status is written right away when the activation or the error of any trigger changes, otherwise
+// at most once per triggersStatusUpdateInterval for every ScaledObject, so the trigger values don't flood the API server.
+func (h *scaleHandler) updateTriggersStatus(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, states []scalerState, logger logr.Logger) {
+	slices.SortFunc(states, func(a, b scalerState) int {
+		return a.TriggerIndex - b.TriggerIndex
+	})
+
+	now := time.Now()
+	previous := make(map[triggerMetricKey]kedav1alpha1.TriggerStatus, len(scaledObject.Status.Triggers))
+	for _, trigger := range scaledObject.Status.Triggers {
+		previous[triggerMetricKey{triggerIndex: int(trigger.Index), metricName: trigger.MetricName}] = trigger
+	}
+
+	changed := len(previous) != len(states)
+	triggers := make([]kedav1alpha1.TriggerStatus, 0, len(states))
+	for _, state := range states {
+		trigger := kedav1alpha1.TriggerStatus{
+			Name:       state.TriggerName,
+			Index:      int32(state.TriggerIndex),
+			Type:       state.TriggerType,
+			MetricName: state.MetricName,
+			Active:     state.IsActive,
+			Disabled:   state.Disabled,
+		}
+		last, found := previous[triggerMetricKey{triggerIndex: state.TriggerIndex, metricName: state.MetricName}]
+		if state.Err != nil {
+			trigger.LastError = truncateTriggerError(state.Err.Error())
+			// keep the last value returned by the trigger
+			if found {
+				trigger.Value, trigger.LastValueTime = last.Value, last.LastValueTime
+			}
+		} else if state.Value != nil {
+			trigger.Value = state.Value
+			trigger.LastValueTime = &metav1.Time{Time: now}
+		}
+		if !found || last.Active != trigger.Active || last.Disabled != trigger.Disabled || last.LastError != trigger.LastError {
+			changed = true
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	key := scaledObject.GenerateIdentifier()
+	if !changed && !h.isTriggersStatusUpdateDue(key, now) {
+		return
+	}
+
+	h.setTriggersDesiredReplicas(ctx, scaledObject, states, triggers, logger)
+
+	status := scaledObject.Status.DeepCopy()
+	status.Triggers = triggers
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating triggers status")
+		return
+	}
+
+	h.triggersStatusLock.Lock()
+	defer h.triggersStatusLock.Unlock()
+	if h.triggersStatusUpdates == nil {
+		h.triggersStatusUpdates = map[string]time.Time{}
+	}
+	h.triggersStatusUpdates[key] = now
+}
+
+// setTriggersDesiredReplicas sets the replica count the HPA computes from the value of every trigger,
+// the HPA doesn't scale on the triggers if scalingModifiers formulas are used
+func (h *scaleHandler) setTriggersDesiredReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, states []scalerState, triggers []kedav1alpha1.TriggerStatus, logger logr.Logger) {
+	if scaledObject.Status.ScaleTargetGVKR == nil ||
+		(scaledObject.IsUsingModifiers() && scaledObject.Spec.Advanced.ScalingModifiers.HasFormula()) {
+		return
+	}
+
+	var currentReplicas *int32
+	for i, state := range states {
+		if state.Err != nil || state.Value == nil || state.Target == nil {
+			continue
+		}
+		if currentReplicas == nil {
+			replicas, err := resolver.GetCurrentReplicas(ctx, h.client, h.scaleClient, scaledObject)
+			if err != nil {
+				logger.V(1).Info("Unable to get current replicas for the triggers status", "error", err)
+				return
+			}
+			currentReplicas = &replicas
+		}
+		desiredReplicas := calculateHPAReplicas(*state.Target, state.Value.AsApproximateFloat64(), *currentReplicas)
+		triggers[i].DesiredReplicas = &desiredReplicas
+	}
+}
+
+// isTriggersStatusUpdateDue returns whether the triggers status of the ScaledObject wasn't written within the update interval
+func (h *scaleHandler) isTriggersStatusUpdateDue(key string, now time.Time) bool {
+	h.triggersStatusLock.Lock()
+	defer h.triggersStatusLock.Unlock()
+
+	interval := h.triggersStatusUpdateInterval
+	if interval <= 0 {
+		interval = defaultTriggersStatusUpdateInterval
+	}
+	lastUpdate, found := h.triggersStatusUpdates[key]
+	return !found || now.Sub(lastUpdate) >= interval
+}
+
+// deleteTriggersStatusUpdate forgets when the triggers status of the scalableObject was written
+func (h *scaleHandler) deleteTriggersStatusUpdate(key string) {
+	h.triggersStatusLock.Lock()
+	defer h.triggersStatusLock.Unlock()
+	delete(h.triggersStatusUpdates, key)
+}
+
+func truncateTriggerError(err string) string {
+	if len(err) <= maxTriggerStatusErrorLength {
+		return err
+	}
+	return err[:maxTriggerStatusErrorLength-3] + "..."
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestUpdateTriggersStatusIsRateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	replicas := int32(2)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	sh := scaleHandler{
+		client:                       mockClient,
+		triggersStatusUpdateInterval: time.Hour,
+	}
+
+	target := v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)}
+	state := func(value int64, err error) []scalerState {
+		return []scalerState{{
+			TriggerName:  "queue",
+			TriggerType:  "rabbitmq",
+			TriggerIndex: 0,
+			IsActive:     value > 0,
+			MetricName:   "s0-queue",
+			Value:        resource.NewQuantity(value, resource.DecimalSI),
+			Target:       &target,
+			Err:          err,
+		}}
+	}
+
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		SetArg(2, appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}).Times(1)
+	mockClient.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	// the first observation is written
+	sh.updateTriggersStatus(context.TODO(), scaledObject, state(35, nil), logr.Discard())
+	assert.Len(t, scaledObject.Status.Triggers, 1)
+	trigger := scaledObject.Status.Triggers[0]
+	assert.Equal(t, "queue", trigger.Name)
+	assert.Equal(t, "rabbitmq", trigger.Type)
+	assert.Equal(t, "s0-queue", trigger.MetricName)
+	assert.True(t, trigger.Active)
+	assert.Equal(t, int64(35), trigger.Value.Value())
+	assert.NotNil(t, trigger.LastValueTime)
+	assert.Equal(t, int32(4), *trigger.DesiredReplicas)
+
+	// only the value changed, the write is rate-limited
+	sh.updateTriggersStatus(context.TODO(), scaledObject, state(45, nil), logr.Discard())
+	assert.Equal(t, int64(35), scaledObject.Status.Triggers[0].Value.Value())
+
+	// the trigger failed, which is written right away with the last known value
+	sh.updateTriggersStatus(context.TODO(), scaledObject, state(0, errors.New("connection refused")), logr.Discard())
+	trigger = scaledObject.Status.Triggers[0]
+	assert.False(t, trigger.Active)
+	assert.Equal(t, "connection refused", trigger.LastError)
+	assert.Equal(t, int64(35), trigger.Value.Value())
+	assert.Nil(t, trigger.DesiredReplicas)
+}
+
+func TestUpdateTriggersStatusOfUnnamedTriggers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	// unnamed triggers of the same type share the name of the scaler
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Triggers: []kedav1alpha1.TriggerStatus{
+				{Name: "rabbitMQScaler", Index: 0, MetricName: "s0-queue", Value: resource.NewQuantity(10, resource.DecimalSI)},
+				{Name: "rabbitMQScaler", Index: 1, MetricName: "s1-queue", Value: resource.NewQuantity(20, resource.DecimalSI)},
+			},
+		},
+	}
+	sh := scaleHandler{client: mockClient}
+
+	mockClient.EXPECT().Status().Return(statusWriter).Times(1)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	sh.updateTriggersStatus(context.TODO(), scaledObject, []scalerState{
+		{TriggerName: "rabbitMQScaler", TriggerIndex: 0, MetricName: "s0-queue", Err: errors.New("connection refused")},
+		{TriggerName: "rabbitMQScaler", TriggerIndex: 1, MetricName: "s1-queue", Value: resource.NewQuantity(25, resource.DecimalSI)},
+	}, logr.Discard())
+
+	assert.Len(t, scaledObject.Status.Triggers, 2)
+	// the failed trigger keeps its own last value
+	assert.Equal(t, int32(0), scaledObject.Status.Triggers[0].Index)
+	assert.Equal(t, int64(10), scaledObject.Status.Triggers[0].Value.Value())
+	assert.Equal(t, int32(1), scaledObject.Status.Triggers[1].Index)
+	assert.Equal(t, int64(25), scaledObject.Status.Triggers[1].Value.Value())
+}
+
+func TestTruncateTriggerError(t *testing.T) {
+	assert.Equal(t, "some error", truncateTriggerError("some error"))
+
+	truncated := truncateTriggerError(strings.Repeat("x", 2*maxTriggerStatusErrorLength))
+	assert.Len(t, truncated, maxTriggerStatusErrorLength)
+	assert.True(t, strings.HasSuffix(truncated, "..."))
+}
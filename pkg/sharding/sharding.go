@@ -0,0 +1,421 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ShardLabel marks the Leases of the shards with the index of the shard
+	ShardLabel = "keda.sh/operator-shard"
+	// MemberLabel marks the Leases of the operator replicas taking part in sharding
+	MemberLabel = "keda.sh/operator-member"
+	// AddressAnnotation holds the address of the Metrics Service gRPC server of the Lease holder
+	AddressAnnotation = "keda.sh/metrics-service-address"
+
+	// DefaultLeaseDuration is used when no lease duration is configured
+	DefaultLeaseDuration = 15 * time.Second
+)
+
+var log = logf.Log.WithName("sharding")
+
+// ShardFor returns the shard of the object, the objects are spread across the shards
+// by jump consistent hashing, so only a minimal set of objects moves when the number of shards changes
+func ShardFor(namespace, name string, shards int32) int32 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(namespace + "/" + name))
+	key := hash.Sum64()
+
+	b, j := int64(-1), int64(0)
+	for j < int64(shards) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int32(b)
+}
+
+// Manager distributes the shards between the operator replicas, every replica renews a member Lease
+// and holds the Leases of at most ceil(shards / members) shards. The shards of a replica which stops
+// renewing its Leases are taken over by the other replicas once the Leases expire.
+type Manager struct {
+	client        client.Client
+	reader        client.Reader
+	namespace     string
+	identity      string
+	address       string
+	shards        int32
+	leaseDuration time.Duration
+	now           func() time.Time
+	// lastSync is the time of the last successful renewal of the Leases
+	lastSync time.Time
+
+	owned       map[int32]bool
+	holders     map[int32]string
+	subscribers []chan []int32
+	mutex       sync.RWMutex
+}
+
+// NewManager returns a Manager distributing shards between the replicas, identity must be unique
+// for every replica and address is the address other replicas forward the metric requests to
+func NewManager(c client.Client, reader client.Reader, namespace, identity, address string, shards int32, leaseDuration time.Duration) (*Manager, error) {
+	if shards < 1 {
+		return nil, fmt.Errorf("number of shards must be greater than 0, got %d", shards)
+	}
+	if identity == "" {
+		return nil, fmt.Errorf("identity of the replica must not be empty")
+	}
+	if leaseDuration <= 0 {
+		leaseDuration = DefaultLeaseDuration
+	}
+	return &Manager{
+		client:        c,
+		reader:        reader,
+		namespace:     namespace,
+		identity:      identity,
+		address:       address,
+		shards:        shards,
+		leaseDuration: leaseDuration,
+		now:           time.Now,
+		owned:         map[int32]bool{},
+		holders:       map[int32]string{},
+	}, nil
+}
+
+// Owns returns true if this replica owns the shard of the object, a nil Manager owns every object
+func (m *Manager) Owns(namespace, name string) bool {
+	if m == nil {
+		return true
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.owned[ShardFor(namespace, name, m.shards)]
+}
+
+// OwnsShard returns true if this replica owns the shard
+func (m *Manager) OwnsShard(shard int32) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.owned[shard]
+}
+
+// Shards returns the number of shards
+func (m *Manager) Shards() int32 {
+	return m.shards
+}
+
+// OwnerAddress returns the address of the replica owning the shard of the object,
+// false is returned if the shard is currently not held by any replica
+func (m *Manager) OwnerAddress(namespace, name string) (string, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	address, found := m.holders[ShardFor(namespace, name, m.shards)]
+	return address, found && address != ""
+}
+
+// Subscribe returns a channel which receives the shards whose ownership changed on this replica
+func (m *Manager) Subscribe() <-chan []int32 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	ch := make(chan []int32, 1)
+	m.subscribers = append(m.subscribers, ch)
+	return ch
+}
+
+// Start renews the Leases until the context is done, the Leases are released afterwards,
+// this implements Runnable interface of controller-runtime Manager
+func (m *Manager) Start(ctx context.Context) error {
+	log.Info("Starting sharding", "identity", m.identity, "shards", m.shards)
+	ticker := time.NewTicker(m.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		if err := m.sync(ctx); err != nil {
+			log.Error(err, "error synchronizing shards")
+		}
+		select {
+		case <-ctx.Done():
+			m.release(context.Background())
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime, sharding runs on every replica.
+func (m *Manager) NeedLeaderElection() bool {
+	return false
+}
+
+// sync renews the member Lease of this replica, renews, releases and acquires the shard Leases
+// to keep at most ceil(shards / members) shards on this replica. Once the Leases weren't renewed
+// within the lease duration, the shards are given up as the other replicas may have taken them over.
+func (m *Manager) sync(ctx context.Context) error {
+	now := m.now()
+	if err := m.syncLeases(ctx, now); err != nil {
+		if !now.Before(m.lastSync.Add(m.leaseDuration)) {
+			m.setOwned(map[int32]bool{}, map[int32]string{})
+		}
+		return err
+	}
+	m.lastSync = now
+	return nil
+}
+
+func (m *Manager) syncLeases(ctx context.Context, now time.Time) error {
+	if err := m.renewLease(ctx, m.memberLeaseName(), map[string]string{MemberLabel: "true"}, now); err != nil {
+		return fmt.Errorf("error renewing member lease: %w", err)
+	}
+
+	members, err := m.countMembers(ctx, now)
+	if err != nil {
+		return err
+	}
+	limit := (m.shards + members - 1) / members
+
+	leases := &coordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases, client.InNamespace(m.namespace), client.HasLabels{ShardLabel}); err != nil {
+		return fmt.Errorf("error listing shard leases: %w", err)
+	}
+	shardLeases := map[int32]*coordinationv1.Lease{}
+	for i := range leases.Items {
+		shard, err := strconv.ParseInt(leases.Items[i].Labels[ShardLabel], 10, 32)
+		if err != nil || int32(shard) >= m.shards {
+			continue
+		}
+		shardLeases[int32(shard)] = &leases.Items[i]
+	}
+
+	owned := map[int32]bool{}
+	holders := map[int32]string{}
+	var free []int32
+	for shard := int32(0); shard < m.shards; shard++ {
+		lease, found := shardLeases[shard]
+		switch {
+		case found && m.heldBy(lease, m.identity, now):
+			owned[shard] = true
+		case found && m.isHeld(lease, now):
+			holders[shard] = lease.Annotations[AddressAnnotation]
+		default:
+			free = append(free, shard)
+		}
+	}
+
+	// release the shards above the limit, so the other replicas can take them over
+	ownedShards := sortedShards(owned)
+	for len(ownedShards) > int(limit) {
+		shard := ownedShards[len(ownedShards)-1]
+		ownedShards = ownedShards[:len(ownedShards)-1]
+		if err := m.releaseLease(ctx, shardLeases[shard]); err != nil {
+			log.Error(err, "error releasing shard lease", "shard", shard)
+		}
+		delete(owned, shard)
+	}
+	for _, shard := range ownedShards {
+		if err := m.acquireLease(ctx, shard, shardLeases[shard], now); err != nil {
+			log.Error(err, "error renewing shard lease", "shard", shard)
+			delete(owned, shard)
+		}
+	}
+	for _, shard := range free {
+		if len(owned) >= int(limit) {
+			break
+		}
+		if err := m.acquireLease(ctx, shard, shardLeases[shard], now); err != nil {
+			log.V(1).Info("Unable to acquire shard lease", "shard", shard, "error", err.Error())
+			continue
+		}
+		owned[shard] = true
+	}
+	for shard := range owned {
+		holders[shard] = m.address
+	}
+
+	m.setOwned(owned, holders)
+	return nil
+}
+
+// release gives up all the Leases of this replica, so the other replicas take over the shards immediately
+func (m *Manager) release(ctx context.Context) {
+	leases := &coordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases, client.InNamespace(m.namespace), client.HasLabels{ShardLabel}); err != nil {
+		log.Error(err, "error listing shard leases")
+	}
+	for i := range leases.Items {
+		if m.heldBy(&leases.Items[i], m.identity, m.now()) {
+			if err := m.releaseLease(ctx, &leases.Items[i]); err != nil {
+				log.Error(err, "error releasing shard lease", "lease", leases.Items[i].Name)
+			}
+		}
+	}
+	member := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: m.memberLeaseName(), Namespace: m.namespace}}
+	if err := m.client.Delete(ctx, member); err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "error deleting member lease")
+	}
+	m.setOwned(map[int32]bool{}, map[int32]string{})
+}
+
+// countMembers returns the number of replicas with a valid member Lease, the expired member Leases are deleted
+func (m *Manager) countMembers(ctx context.Context, now time.Time) (int32, error) {
+	leases := &coordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases, client.InNamespace(m.namespace), client.HasLabels{MemberLabel}); err != nil {
+		return 0, fmt.Errorf("error listing member leases: %w", err)
+	}
+	members := int32(0)
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if lease.Name == m.memberLeaseName() || m.isHeld(lease, now) {
+			members++
+			continue
+		}
+		if err := m.client.Delete(ctx, lease); err != nil && !errors.IsNotFound(err) {
+			log.V(1).Info("Unable to delete expired member lease", "lease", lease.Name, "error", err.Error())
+		}
+	}
+	return max(members, 1), nil
+}
+
+func (m *Manager) renewLease(ctx context.Context, name string, labels map[string]string, now time.Time) error {
+	lease := &coordinationv1.Lease{}
+	err := m.reader.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: name}, lease)
+	switch {
+	case errors.IsNotFound(err):
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: m.namespace, Labels: labels}}
+		m.setHolder(lease, now)
+		return m.client.Create(ctx, lease)
+	case err != nil:
+		return err
+	}
+	m.setHolder(lease, now)
+	return m.client.Update(ctx, lease)
+}
+
+// acquireLease creates, renews or takes over the Lease of the shard, the update fails
+// on conflict if another replica modified the Lease in the meantime
+func (m *Manager) acquireLease(ctx context.Context, shard int32, lease *coordinationv1.Lease, now time.Time) error {
+	if lease == nil {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{
+			Name:      m.shardLeaseName(shard),
+			Namespace: m.namespace,
+			Labels:    map[string]string{ShardLabel: strconv.Itoa(int(shard))},
+		}}
+		m.setHolder(lease, now)
+		return m.client.Create(ctx, lease)
+	}
+	if !m.heldBy(lease, m.identity, now) {
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	m.setHolder(lease, now)
+	return m.client.Update(ctx, lease)
+}
+
+func (m *Manager) releaseLease(ctx context.Context, lease *coordinationv1.Lease) error {
+	lease.Spec.HolderIdentity = nil
+	return m.client.Update(ctx, lease)
+}
+
+func (m *Manager) setHolder(lease *coordinationv1.Lease, now time.Time) {
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[AddressAnnotation] = m.address
+	lease.Spec.HolderIdentity = ptr.To(m.identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.leaseDuration.Seconds()))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	if lease.Spec.AcquireTime == nil {
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+	}
+}
+
+// isHeld returns true if the Lease has a holder which renewed it within the lease duration
+func (m *Manager) isHeld(lease *coordinationv1.Lease, now time.Time) bool {
+	if ptr.Deref(lease.Spec.HolderIdentity, "") == "" || lease.Spec.RenewTime == nil {
+		return false
+	}
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, int32(m.leaseDuration.Seconds()))) * time.Second
+	return now.Before(lease.Spec.RenewTime.Add(duration))
+}
+
+func (m *Manager) heldBy(lease *coordinationv1.Lease, identity string, now time.Time) bool {
+	return m.isHeld(lease, now) && *lease.Spec.HolderIdentity == identity
+}
+
+// setOwned stores the owned shards and notifies the subscribers about the shards whose ownership changed
+func (m *Manager) setOwned(owned map[int32]bool, holders map[int32]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var changed []int32
+	for shard := int32(0); shard < m.shards; shard++ {
+		if owned[shard] != m.owned[shard] {
+			changed = append(changed, shard)
+		}
+	}
+	m.owned = owned
+	m.holders = holders
+	if len(changed) == 0 {
+		return
+	}
+	log.Info("Shard ownership changed", "identity", m.identity, "ownedShards", sortedShards(owned))
+	for _, ch := range m.subscribers {
+		notification := slices.Clone(changed)
+		// merge with the not yet consumed notification, so the subscriber never misses a shard
+		select {
+		case pending := <-ch:
+			for _, shard := range pending {
+				if !slices.Contains(notification, shard) {
+					notification = append(notification, shard)
+				}
+			}
+		default:
+		}
+		ch <- notification
+	}
+}
+
+func (m *Manager) memberLeaseName() string {
+	return "keda-operator-member-" + m.identity
+}
+
+func (m *Manager) shardLeaseName(shard int32) string {
+	return fmt.Sprintf("keda-operator-shard-%d", shard)
+}
+
+func sortedShards(shards map[int32]bool) []int32 {
+	result := make([]int32, 0, len(shards))
+	for shard := range shards {
+		result = append(result, shard)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestShardFor(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		shard := ShardFor("default", fmt.Sprintf("scaledobject-%d", i), 4)
+		assert.GreaterOrEqual(t, shard, int32(0))
+		assert.Less(t, shard, int32(4))
+		assert.Equal(t, shard, ShardFor("default", fmt.Sprintf("scaledobject-%d", i), 4))
+		counts[shard]++
+	}
+	for _, count := range counts {
+		assert.Greater(t, count, 150)
+	}
+
+	// growing the number of shards only moves objects to the new shard
+	for i := 0; i < 1000; i++ {
+		before := ShardFor("default", fmt.Sprintf("scaledobject-%d", i), 4)
+		after := ShardFor("default", fmt.Sprintf("scaledobject-%d", i), 5)
+		assert.True(t, before == after || after == 4)
+	}
+}
+
+func TestManagerRebalancesShards(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	c := newFakeClient()
+	first := newTestManager(t, c, "keda-operator-a", &now)
+	second := newTestManager(t, c, "keda-operator-b", &now)
+	changes := first.Subscribe()
+
+	// the only replica owns all the shards
+	assert.NoError(t, first.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(first))
+	assert.ElementsMatch(t, []int32{0, 1, 2, 3}, <-changes)
+
+	// the first replica releases half of the shards once the second replica joins, the second one takes them over
+	assert.NoError(t, second.sync(context.TODO()))
+	assert.Equal(t, 0, countOwned(second))
+	assert.NoError(t, first.sync(context.TODO()))
+	assert.Equal(t, 2, countOwned(first))
+	assert.ElementsMatch(t, []int32{2, 3}, <-changes)
+	assert.NoError(t, second.sync(context.TODO()))
+	assert.Equal(t, 2, countOwned(second))
+
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("scaledobject-%d", i)
+		assert.NotEqual(t, first.Owns("default", name), second.Owns("default", name))
+		if !second.Owns("default", name) {
+			address, found := second.OwnerAddress("default", name)
+			assert.True(t, found)
+			assert.Equal(t, "keda-operator-a:9666", address)
+		}
+	}
+
+	// the second replica takes over all the shards once the leases of the first replica expire
+	now = now.Add(DefaultLeaseDuration + time.Second)
+	assert.NoError(t, second.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(second))
+}
+
+func TestManagerReleasesShardsOnStop(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	c := newFakeClient()
+	first := newTestManager(t, c, "keda-operator-a", &now)
+	second := newTestManager(t, c, "keda-operator-b", &now)
+
+	assert.NoError(t, first.sync(context.TODO()))
+	assert.NoError(t, second.sync(context.TODO()))
+	first.release(context.TODO())
+	assert.Equal(t, 0, countOwned(first))
+
+	assert.NoError(t, second.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(second))
+}
+
+func TestManagerGivesUpShardsWhenRenewalFails(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	failing := false
+	c := interceptor.NewClient(newFakeClient().(client.WithWatch), interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if failing {
+				return fmt.Errorf("api server unavailable")
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+	m := newTestManager(t, c, "keda-operator-a", &now)
+	changes := m.Subscribe()
+
+	assert.NoError(t, m.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(m))
+	assert.ElementsMatch(t, []int32{0, 1, 2, 3}, <-changes)
+
+	// the shards are kept while the Leases are still valid
+	failing = true
+	now = now.Add(DefaultLeaseDuration / 2)
+	assert.Error(t, m.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(m))
+
+	// the Leases expired, the shards are given up
+	now = now.Add(DefaultLeaseDuration / 2)
+	assert.Error(t, m.sync(context.TODO()))
+	assert.Equal(t, 0, countOwned(m))
+	assert.ElementsMatch(t, []int32{0, 1, 2, 3}, <-changes)
+
+	failing = false
+	assert.NoError(t, m.sync(context.TODO()))
+	assert.Equal(t, 4, countOwned(m))
+}
+
+func TestNilManagerOwnsEverything(t *testing.T) {
+	var m *Manager
+	assert.True(t, m.Owns("default", "scaledobject"))
+}
+
+func newFakeClient() client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).Build()
+}
+
+func newTestManager(t *testing.T, c client.Client, identity string, now *time.Time) *Manager {
+	m, err := NewManager(c, c, "keda", identity, identity+":9666", 4, DefaultLeaseDuration)
+	assert.NoError(t, err)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func countOwned(m *Manager) int {
+	count := 0
+	for shard := int32(0); shard < m.Shards(); shard++ {
+		if m.OwnsShard(shard) {
+			count++
+		}
+	}
+	return count
+}